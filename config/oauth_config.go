@@ -14,6 +14,17 @@ var (
 		Scopes:       []string{"openid", "profile", "email"}, // Define the scopes you need
 		Endpoint:     google.Endpoint,
 	}
+
+	// GoogleMobileOAuthConfig is the client of the app, which gets the code through its own redirect and has no secret,
+	// so the code is exchanged with its PKCE verifier instead.
+	GoogleMobileOAuthConfig = &oauth2.Config{
+		Scopes: []string{"openid", "profile", "email"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   google.Endpoint.AuthURL,
+			TokenURL:  google.Endpoint.TokenURL,
+			AuthStyle: oauth2.AuthStyleInParams, // the client ID goes in the body, there is no secret for basic auth
+		},
+	}
 )
 
 func InitialiseOAuthGoogle() {
@@ -24,4 +35,6 @@ func InitialiseOAuthGoogle() {
 	} else {
 		GoogleOAuthConfig.RedirectURL = viper.GetString("GOOGLE_REDIRECT_URI_PROD")
 	}
+	GoogleMobileOAuthConfig.ClientID = viper.GetString("GOOGLE_MOBILE_CLIENT_ID")
+	GoogleMobileOAuthConfig.RedirectURL = viper.GetString("GOOGLE_MOBILE_REDIRECT_URI")
 }
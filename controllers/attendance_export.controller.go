@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/services"
+	"github.com/GDGVIT/attendance-app-backend/utils/export"
+	"github.com/gin-gonic/gin"
+)
+
+// AttendanceExportController handles attendance export routes.
+type AttendanceExportController struct {
+	attendanceExportService services.AttendanceExportServiceInterface
+}
+
+// NewAttendanceExportController creates a new AttendanceExportController.
+func NewAttendanceExportController(attendanceExportService services.AttendanceExportServiceInterface) *AttendanceExportController {
+	return &AttendanceExportController{attendanceExportService}
+}
+
+// ExportMeetingAttendance downloads the attendance register of a meeting. Query ?format=csv (default) or ?format=xlsx.
+func (aec *AttendanceExportController) ExportMeetingAttendance(c *gin.Context) {
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting or team ID", "error": err.Error()})
+		return
+	}
+	format, ok := getExportFormat(c)
+	if !ok {
+		return
+	}
+
+	register, err := aec.attendanceExportService.GetMeetingRegister(meetingID, teamID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to export attendance", "error": err.Error()})
+		return
+	}
+
+	aec.writeRegister(c, register, format, fmt.Sprintf("meeting-%d-attendance", meetingID))
+}
+
+// ExportTeamAttendance downloads the attendance register of a team's meetings between the dates ?from= and ?to= (YYYY-MM-DD, both included).
+// Query ?format=csv (default) or ?format=xlsx.
+func (aec *AttendanceExportController) ExportTeamAttendance(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	format, ok := getExportFormat(c)
+	if !ok {
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, must be YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, must be YYYY-MM-DD"})
+		return
+	}
+
+	// include the whole of the last day
+	register, err := aec.attendanceExportService.GetTeamRegister(uint(teamID), from, to.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to export attendance", "error": err.Error()})
+		return
+	}
+
+	aec.writeRegister(c, register, format, fmt.Sprintf("team-%d-attendance-%s-to-%s", teamID, c.Query("from"), c.Query("to")))
+}
+
+// get the export format from query params, responds with an error if it is not supported
+func getExportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", export.CSV)
+	if format != export.CSV && format != export.XLSX {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, must be csv or xlsx"})
+		return "", false
+	}
+	return format, true
+}
+
+// writeRegister streams the register in the format. Errors once the file has started cannot change the response status, so they are only logged.
+func (aec *AttendanceExportController) writeRegister(c *gin.Context, register models.AttendanceRegister, format, filename string) {
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+"."+format))
+	c.Status(http.StatusOK)
+
+	w, err := export.NewWriter(format, c.Writer, register.TeamName)
+	if err == nil {
+		err = aec.attendanceExportService.WriteRegister(register, w)
+	}
+	if err != nil {
+		logger.Errorf("Failed to export attendance of team %s: %v", register.TeamName, err)
+	}
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/export"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// test ExportTeamAttendance
+func TestAttendanceExportController_ExportTeamAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAttendanceExportService(ctrl)
+
+	r := gin.Default()
+	attendanceExportController := NewAttendanceExportController(mockService)
+	r.GET("/team/:teamID/attendance/export", attendanceExportController.ExportTeamAttendance)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/team/1/attendance/export"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC).Add(24*time.Hour - time.Nanosecond)
+	register := models.AttendanceRegister{TeamName: "Team"}
+
+	// Test case 1: CSV by default, the last day of the range is included
+	mockService.EXPECT().GetTeamRegister(uint(1), from, to).Return(register, nil)
+	mockService.EXPECT().WriteRegister(register, gomock.Any()).DoAndReturn(func(_ models.AttendanceRegister, w export.Writer) error {
+		w.WriteRow("Name", "Email")
+		return w.Close()
+	})
+	w := sendRequest("?from=2024-01-01&to=2024-01-31")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="team-1-attendance-2024-01-01-to-2024-01-31.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "Name,Email\n", w.Body.String())
+
+	// Test case 2: XLSX
+	mockService.EXPECT().GetTeamRegister(uint(1), from, to).Return(register, nil)
+	mockService.EXPECT().WriteRegister(register, gomock.Any()).DoAndReturn(func(_ models.AttendanceRegister, w export.Writer) error {
+		return w.Close()
+	})
+	w = sendRequest("?from=2024-01-01&to=2024-01-31&format=xlsx")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, export.ContentType(export.XLSX), w.Header().Get("Content-Type"))
+
+	// Test case 3: Missing date
+	w = sendRequest("?from=2024-01-01")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 4: No meetings in range
+	mockService.EXPECT().GetTeamRegister(uint(1), from, to).Return(models.AttendanceRegister{}, errors.New("no meetings found in the export range"))
+	w = sendRequest("?from=2024-01-01&to=2024-01-31")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 5: Unsupported format
+	w = sendRequest("?from=2024-01-01&to=2024-01-31&format=pdf")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize is the largest attendance file that can be uploaded, in bytes.
+const maxImportFileSize = 5 << 20
+
+// AttendanceImportController handles attendance import routes.
+type AttendanceImportController struct {
+	attendanceImportService services.AttendanceImportServiceInterface
+}
+
+// NewAttendanceImportController creates a new AttendanceImportController.
+func NewAttendanceImportController(attendanceImportService services.AttendanceImportServiceInterface) *AttendanceImportController {
+	return &AttendanceImportController{attendanceImportService}
+}
+
+// ImportAttendance imports historical attendance from the CSV uploaded as the multipart form field "file".
+// With ?dryRun=true the rows are only checked. Responds with a report of every row, and 422 if invalid rows stopped the import.
+func (aic *AttendanceImportController) ImportAttendance(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dryRun, must be true or false"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Attendance file is required", "error": err.Error()})
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Attendance file is too large"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to read attendance file", "error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	report, err := aic.attendanceImportService.ImportAttendance(auditActor(c), uint(teamID), file, dryRun, time.Now())
+	if errors.Is(err, services.ErrInvalidImportRows) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error(), "report": report})
+		return
+	}
+	if err != nil && len(report.Rows) > 0 {
+		logger.Errorf("Failed to import attendance of team %d: %v", teamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to import attendance", "error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid attendance file", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/services"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// test ImportAttendance
+func TestAttendanceImportController_ImportAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAttendanceImportService(ctrl)
+
+	r := gin.Default()
+	attendanceImportController := NewAttendanceImportController(mockService)
+
+	// Set the acting admin in the context
+	r.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{Model: gorm.Model{ID: 9}})
+	})
+	r.POST("/team/:teamID/attendance/import", attendanceImportController.ImportAttendance)
+
+	// Helper function to upload a file and check the response
+	sendRequest := func(query string, file string) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		if file != "" {
+			part, _ := writer.CreateFormFile("file", "attendance.csv")
+			part.Write([]byte(file))
+		}
+		writer.Close()
+
+		req, _ := http.NewRequest("POST", "/team/1/attendance/import"+query, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	file := "email,meeting,date,status\nalice@example.com,Retro,2024-01-05,present\n"
+	rows := []models.AttendanceImportRow{{Row: 2, Email: "alice@example.com", Meeting: "Retro", Date: "2024-01-05", Status: "present"}}
+
+	// Test case 1: Dry run
+	mockService.EXPECT().ImportAttendance(models.AuditActor{UserID: 9}, uint(1), gomock.Any(), true, gomock.Any()).Return(models.AttendanceImportReport{DryRun: true, Rows: rows, MeetingsCreated: 1, AttendanceAdded: 1}, nil)
+	w := sendRequest("?dryRun=true", file)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"DryRun":true`)
+
+	// Test case 2: Import
+	mockService.EXPECT().ImportAttendance(models.AuditActor{UserID: 9}, uint(1), gomock.Any(), false, gomock.Any()).Return(models.AttendanceImportReport{Imported: true, Rows: rows}, nil)
+	w = sendRequest("", file)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Imported":true`)
+
+	// Test case 3: Invalid rows
+	rows[0].Error = "no user with this email"
+	mockService.EXPECT().ImportAttendance(models.AuditActor{UserID: 9}, uint(1), gomock.Any(), false, gomock.Any()).Return(models.AttendanceImportReport{Rows: rows, ErrorCount: 1}, services.ErrInvalidImportRows)
+	w = sendRequest("", file)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "no user with this email")
+
+	// Test case 4: Malformed file
+	mockService.EXPECT().ImportAttendance(models.AuditActor{UserID: 9}, uint(1), gomock.Any(), false, gomock.Any()).Return(models.AttendanceImportReport{}, errors.New("attendance file is missing the status column"))
+	w = sendRequest("", "email,meeting,date\n")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 5: No file
+	w = sendRequest("", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 6: Invalid dry run
+	w = sendRequest("?dryRun=maybe", file)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController handles team audit log routes.
+type AuditLogController struct {
+	auditLogService services.AuditLogServiceInterface
+}
+
+// NewAuditLogController creates a new AuditLogController.
+func NewAuditLogController(auditLogService services.AuditLogServiceInterface) *AuditLogController {
+	return &AuditLogController{auditLogService}
+}
+
+// auditActor returns the current user and their IP, to be recorded in the audit log.
+func auditActor(c *gin.Context) models.AuditActor {
+	actor := models.AuditActor{IP: c.ClientIP()}
+	if currentUser, ok := c.Get("user"); ok {
+		actor.UserID = currentUser.(*models.User).ID
+	}
+	return actor
+}
+
+// GetTeamAuditLog returns a page of the audit log of a team, newest first.
+// Query ?actorID= filters by user, ?action= by action or action prefix such as "member.", ?from= and ?to= (RFC 3339) by date, and ?page= and ?pageSize= select the page.
+func (alc *AuditLogController) GetTeamAuditLog(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	filter := models.AuditLogFilter{TeamID: uint(teamID), Action: c.Query("action")}
+	if actorParam := c.Query("actorID"); actorParam != "" {
+		actorID, err := strconv.ParseUint(actorParam, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor ID"})
+			return
+		}
+		actor := uint(actorID)
+		filter.ActorID = &actor
+	}
+	for param, field := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(param); value != "" {
+			at, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " date, must be RFC 3339"})
+				return
+			}
+			*field = &at
+		}
+	}
+	for param, field := range map[string]*int{"page": &filter.Page, "pageSize": &filter.PageSize} {
+		if value := c.Query(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+			*field = n
+		}
+	}
+
+	page, err := alc.auditLogService.GetTeamAuditLogs(filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to get the audit log", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
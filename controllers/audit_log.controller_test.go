@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// test GetTeamAuditLog
+func TestAuditLogController_GetTeamAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuditLogService(ctrl)
+
+	r := gin.Default()
+	auditLogController := NewAuditLogController(mockService)
+	r.GET("/team/:teamID/audit-log", auditLogController.GetTeamAuditLog)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test case 1: All filters
+	actorID := uint(9)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetTeamAuditLogs(models.AuditLogFilter{TeamID: 1, ActorID: &actorID, Action: "member.", From: &from, Page: 2, PageSize: 20}).
+		Return(models.AuditLogPage{Page: 2, PageSize: 20}, nil)
+	w := sendRequest("/team/1/audit-log?actorID=9&action=member.&from=2026-03-01T00:00:00Z&page=2&pageSize=20")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 2: Invalid date
+	w = sendRequest("/team/1/audit-log?to=yesterday")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 3: Invalid actor
+	w = sendRequest("/team/1/audit-log?actorID=me")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 4: Page out of range
+	mockService.EXPECT().GetTeamAuditLogs(models.AuditLogFilter{TeamID: 1, PageSize: 1000}).
+		Return(models.AuditLogPage{}, errors.New("page must be at least 1 and page size between 1 and 200"))
+	w = sendRequest("/team/1/audit-log?pageSize=1000")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
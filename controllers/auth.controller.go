@@ -1,9 +1,9 @@
 package controllers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
-	"net/url"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/config"
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
@@ -11,34 +11,39 @@ import (
 	"github.com/GDGVIT/attendance-app-backend/repository"
 	"github.com/GDGVIT/attendance-app-backend/utils/auth"
 	"github.com/GDGVIT/attendance-app-backend/utils/email"
-	"github.com/GDGVIT/attendance-app-backend/utils/token"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/oauth2"
 )
 
 type UserController struct {
 	userRepo             *repository.UserRepository
-	forgotRepo           *repository.ForgotPasswordRepository
-	verifRepo            *repository.VerificationEntryRepository
-	deletionRepo         *repository.DeletionConfirmationRepository
+	oneTimeCodeRepo      *repository.OneTimeCodeRepository
 	passwordAuthRepo     *repository.PasswordAuthRepository
 	authProviderRepo     *repository.AuthProviderRepository
 	teamMemberRepo       *repository.TeamMemberRepository
 	teamRepo             *repository.TeamRepository
 	teamEntryRequestRepo *repository.TeamEntryRequestRepository
+	teamEmailInviteRepo  *repository.TeamEmailInviteRepository
+	sessionRepo          *repository.SessionRepository
+	twoFactorRepo        *repository.TwoFactorRepository
+	authThrottleRepo     repository.AuthThrottleRepositoryInterface
+	oauthStateRepo       *repository.OAuthStateRepository
 }
 
 func NewUserController() *UserController {
 	userRepo := repository.NewUserRepository()
-	forgotRepo := repository.NewForgotPasswordRepository()
-	verifRepo := repository.NewVerificationEntryRepository()
-	deletionRepo := repository.NewDeletionConfirmationRepository()
+	oneTimeCodeRepo := repository.NewOneTimeCodeRepository()
 	passwordAuthRepo := repository.NewPasswordAuthRepository()
 	authProviderRepo := repository.NewAuthProviderRepository()
 	teamMeberRepo := repository.NewTeamMemberRepository()
 	teamRepo := repository.NewTeamRepository()
 	teamEntryRequestRepo := repository.NewTeamEntryRequestRepository()
-	return &UserController{userRepo, forgotRepo, verifRepo, deletionRepo, passwordAuthRepo, authProviderRepo, teamMeberRepo, teamRepo, teamEntryRequestRepo}
+	teamEmailInviteRepo := repository.NewTeamEmailInviteRepository()
+	sessionRepo := repository.NewSessionRepository()
+	twoFactorRepo := repository.NewTwoFactorRepository()
+	authThrottleRepo := repository.NewAuthThrottleRepository()
+	oauthStateRepo := repository.NewOAuthStateRepository()
+	return &UserController{userRepo, oneTimeCodeRepo, passwordAuthRepo, authProviderRepo, teamMeberRepo, teamRepo, teamEntryRequestRepo, teamEmailInviteRepo, sessionRepo, twoFactorRepo, authThrottleRepo, oauthStateRepo}
 }
 
 // RegisterUser handles user registration
@@ -124,25 +129,95 @@ func (uc *UserController) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := auth.LoginCheck(loginData.Email, loginData.Password)
+	accountThrottle := auth.AccountThrottle(loginData.Email)
+	ipThrottle := auth.IPThrottle(c.ClientIP())
+	if uc.throttled(c, accountThrottle, ipThrottle) {
+		return
+	}
+
+	user, err := auth.LoginCheck(loginData.Email, loginData.Password)
 
 	if err != nil {
-		println(err.Error())
+		if uc.recordFailure(accountThrottle, ipThrottle) && user.ID != 0 {
+			uc.sendLockoutMail(user)
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "credentials-error", "message": "The email or password is not correct"})
 		return
 	}
+	uc.resetThrottles(accountThrottle)
+
+	if !user.Verified {
+		c.JSON(http.StatusForbidden, gin.H{"error": "unverified", "message": "Please verify your email before logging in."})
+		return
+	}
+
+	uc.completeLogin(c, user)
+}
+
+// RequestMagicLink emails a link to log in without a password to a verified user. The response does not tell whether the email has an account.
+func (uc *UserController) RequestMagicLink(c *gin.Context) {
+	var magicLinkData struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&magicLinkData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	// no new links while the account is locked out
+	if uc.throttled(c, auth.AccountThrottle(magicLinkData.Email)) {
+		return
+	}
+
+	user, err := uc.userRepo.GetUserByEmail(magicLinkData.Email)
+	if err == nil && user.Verified {
+		// the link sent before stops working
+		if err := email.SendMagicLinkMail(user.Email, user.Name); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "mail", "message": "Error in sending email."})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the email has a verified account, a login link was sent to it."})
+}
+
+// MagicLinkLogin logs in with the code of a magic link, responding like Login. Wrong codes count towards the same lockout as wrong passwords.
+func (uc *UserController) MagicLinkLogin(c *gin.Context) {
+	var magicLinkData struct {
+		Email string `json:"email" binding:"required,email"`
+		OTP   string `json:"otp" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&magicLinkData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	if !uc.consumeOneTimeCode(c, models.OneTimeCodeLogin, magicLinkData.Email, magicLinkData.OTP, auth.AccountThrottle(magicLinkData.Email)) {
+		return
+	}
+
+	user, err := uc.userRepo.GetUserByEmail(magicLinkData.Email)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Invalid verification. Please check email link again."})
+		return
+	}
 
 	if !user.Verified {
 		c.JSON(http.StatusForbidden, gin.H{"error": "unverified", "message": "Please verify your email before logging in."})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
+	uc.completeLogin(c, user)
 }
 
 func (uc *UserController) RequestVerificationAgain(c *gin.Context) {
 	useremail := c.Query("email")
 
+	// no new codes while the account is locked out
+	if uc.throttled(c, auth.AccountThrottle(useremail)) {
+		return
+	}
+
 	user, err := uc.userRepo.GetUserByEmail(useremail)
 	if err != nil {
 		c.JSON(http.StatusOK, gin.H{"message": "Verification email sent."})
@@ -154,16 +229,7 @@ func (uc *UserController) RequestVerificationAgain(c *gin.Context) {
 		return
 	}
 
-	_, err = uc.verifRepo.GetVerificationEntryByEmail(user.Email)
-	if err == nil {
-		err = uc.verifRepo.DeleteVerificationEntry(user.Email)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Error deleting verification entry."})
-			return
-		}
-	}
-
-	// Send verification email
+	// Send verification email, the code sent before stops working
 	err = email.SendRegistrationMail("Account Verification.", "Please visit the following link to verify your account: ", user.Email, user.ID, user.Name, true)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "mail", "message": "Error in sending email."})
@@ -175,42 +241,42 @@ func (uc *UserController) RequestVerificationAgain(c *gin.Context) {
 }
 
 // VerifyEmail takes your email and otp sent of registration to verify a user account.
+// Pending invitations of the email to teams are accepted once verified.
 func (uc *UserController) VerifyEmail(c *gin.Context) {
 	email := c.Query("email")
 	otp := c.Query("otp")
 
-	// Fetch the verification entry by email
-	verificationEntry, err := uc.verifRepo.GetVerificationEntryByEmail(email)
+	if !uc.consumeOneTimeCode(c, models.OneTimeCodeVerification, email, otp, auth.AccountThrottle(email)) {
+		return
+	}
+
+	// Verify the email by updating the user's verification status
+	err := uc.userRepo.VerifyUserEmail(email)
 	if err != nil {
 		logger.Errorf("Error while verifying: " + err.Error())
 		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid verification."})
 		return
 	}
 
-	if verificationEntry.OTP == otp {
-		// Verify the email by updating the user's verification status
-		err = uc.userRepo.VerifyUserEmail(email)
-		if err != nil {
-			logger.Errorf("Error while verifying: " + err.Error())
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid verification."})
-			return
-		}
-
-		// Delete the verification entry
-		err = uc.verifRepo.DeleteVerificationEntry(email)
-		if err != nil {
-			logger.Errorf("Error while deleting verification entry: " + err.Error())
-		}
+	// Join the teams the email was invited to before signing up
+	uc.joinInvitedTeams(email)
 
-		c.JSON(http.StatusOK, gin.H{"message": "Verified! You can now log in."})
-	} else {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Invalid verification."})
-	}
+	c.JSON(http.StatusOK, gin.H{"message": "Verified! You can now log in."})
 }
 
 // GoogleLogin initiates google oauth2 flow
 func (uc *UserController) GoogleLogin(c *gin.Context) {
-	url := config.GoogleOAuthConfig.AuthCodeURL("", oauth2.AccessTypeOffline)
+	oauthState, state, err := auth.NewOAuthState("", time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start Google login."})
+		return
+	}
+	if _, err := uc.oauthStateRepo.CreateOAuthState(oauthState); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start Google login."})
+		return
+	}
+
+	url := config.GoogleOAuthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("nonce", oauthState.Nonce))
 	c.Redirect(http.StatusFound, url)
 }
 
@@ -218,6 +284,15 @@ func (uc *UserController) GoogleLogin(c *gin.Context) {
 func (uc *UserController) GoogleCallback(c *gin.Context) {
 	code := c.Query("code")
 
+	oauthState, ok := uc.consumeOAuthState(c, c.Query("state"))
+	if !ok {
+		return
+	}
+	if oauthState.Mobile() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "oauth-state", "message": "This login was started by the app, finish it there."})
+		return
+	}
+
 	// Exchange the authorization code for an access token and ID token
 	googletoken, err := config.GoogleOAuthConfig.Exchange(c, code)
 	if err != nil {
@@ -226,70 +301,143 @@ func (uc *UserController) GoogleCallback(c *gin.Context) {
 		return
 	}
 
-	// Use the 'accessToken' from the 'token' to fetch user data from the UserInfo endpoint
-	userInfoURL := "https://www.googleapis.com/oauth2/v2/userinfo?access_token=" + url.QueryEscape(googletoken.AccessToken)
-	userInfoResponse, err := http.Get(userInfoURL)
+	uc.googleLogin(c, googletoken, config.GoogleOAuthConfig.ClientID, oauthState.Nonce)
+}
+
+// StartGoogleMobileLogin starts a Google login for the app, which sends the S256 challenge of its PKCE verifier.
+// It responds with the state and nonce of the login and the URL to open, after which the app receives the code
+// through its own redirect and finishes logging in at GoogleMobileExchange.
+func (uc *UserController) StartGoogleMobileLogin(c *gin.Context) {
+	var startData struct {
+		CodeChallenge string `json:"codeChallenge" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&startData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+	if !auth.ValidCodeChallenge(startData.CodeChallenge) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code-challenge", "message": "The code challenge must be the unpadded base64url SHA-256 hash of the code verifier."})
+		return
+	}
+	if config.GoogleMobileOAuthConfig.ClientID == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Google login is not set up for the app."})
+		return
+	}
+
+	oauthState, state, err := auth.NewOAuthState(startData.CodeChallenge, time.Now())
 	if err != nil {
-		// Handle the error
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user info from Google"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start Google login."})
 		return
 	}
-	defer userInfoResponse.Body.Close()
+	if _, err := uc.oauthStateRepo.CreateOAuthState(oauthState); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start Google login."})
+		return
+	}
+
+	url := config.GoogleMobileOAuthConfig.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", oauthState.Nonce),
+		oauth2.SetAuthURLParam("code_challenge", startData.CodeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+	c.JSON(http.StatusOK, gin.H{"state": state, "nonce": oauthState.Nonce, "authUrl": url, "expiresAt": oauthState.ExpiresAt})
+}
 
-	var userInfo struct {
-		ID      string `json:"id"`
-		Email   string `json:"email"`
-		Name    string `json:"name"`
-		Picture string `json:"picture"`
+// GoogleMobileExchange finishes a Google login started at StartGoogleMobileLogin, exchanging the code the app
+// received with its PKCE verifier, and responds like Login.
+func (uc *UserController) GoogleMobileExchange(c *gin.Context) {
+	var exchangeData struct {
+		Code         string `json:"code" binding:"required"`
+		CodeVerifier string `json:"codeVerifier" binding:"required"`
+		State        string `json:"state" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&exchangeData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
 	}
 
-	if err := json.NewDecoder(userInfoResponse.Body).Decode(&userInfo); err != nil {
-		// Handle the error
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse user info response"})
+	// the state is used up even if the rest fails, so it cannot be retried with other verifiers
+	oauthState, ok := uc.consumeOAuthState(c, exchangeData.State)
+	if !ok {
+		return
+	}
+	if !oauthState.Mobile() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "oauth-state", "message": "This login was not started by the app."})
+		return
+	}
+	if !auth.VerifyCodeVerifier(exchangeData.CodeVerifier, oauthState.CodeChallenge) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code-verifier", "message": "The code verifier does not match the code challenge of the login."})
+		return
+	}
+
+	googletoken, err := config.GoogleMobileOAuthConfig.Exchange(c, exchangeData.Code, oauth2.SetAuthURLParam("code_verifier", exchangeData.CodeVerifier))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to exchange code for token"})
+		return
+	}
+
+	uc.googleLogin(c, googletoken, config.GoogleMobileOAuthConfig.ClientID, oauthState.Nonce)
+}
+
+// consumeOAuthState uses up the state a Google login finished with, responding with an error if it cannot be used.
+func (uc *UserController) consumeOAuthState(c *gin.Context, state string) (models.OAuthState, bool) {
+	oauthState, err := uc.oauthStateRepo.ConsumeOAuthState(auth.HashOAuthState(state), time.Now())
+	if errors.Is(err, repository.ErrOAuthStateInvalid) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "oauth-state", "message": err.Error()})
+		return models.OAuthState{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
+		return models.OAuthState{}, false
+	}
+	return oauthState, true
+}
+
+// googleLogin logs in the Google account of the ID token Google issued to clientID for the login started with nonce,
+// linking it to the user with its email, or a new user if there is none.
+func (uc *UserController) googleLogin(c *gin.Context, googletoken *oauth2.Token, clientID, nonce string) {
+	idToken, _ := googletoken.Extra("id_token").(string)
+	identity, err := auth.VerifyGoogleIDToken(idToken, clientID, nonce, time.Now())
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "id-token", "message": "Invalid ID token from Google."})
+		logger.Warnf("Failed to verify Google ID token: " + err.Error())
+		return
+	}
+	// the account is linked by email, which must belong to whoever logs in
+	if !identity.EmailVerified {
+		c.JSON(http.StatusForbidden, gin.H{"error": "unverified", "message": "The email of the Google account is not verified."})
 		return
 	}
 
 	// check if providerid in authprovider. If yes, get userid, generate JWT and log them in. If no, create authprovider entry, and if user entry not there then that as well.
 	// get authprovider entry by providerid
-	authProvider, _ := uc.authProviderRepo.GetAuthProviderByProviderKey(userInfo.ID)
+	authProvider, _ := uc.authProviderRepo.GetAuthProviderByProviderKey(identity.Subject)
 	var emptyProviderEntry models.AuthProvider
 	if authProvider != emptyProviderEntry { // i.e., found
 		user, _ := uc.userRepo.GetUserByID(authProvider.UserID)
-		jwt, err := token.GenerateToken(user)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
-			return
-		}
-		// c.Redirect(http.StatusSeeOther, viper.GetString("FRONTEND_SOCIAL_REDIRECT")+"?token="+jwt)
-		c.JSON(http.StatusOK, gin.H{"token": jwt, "user": user})
+		uc.completeLogin(c, user)
 		return
 	}
 
 	// check is user entry exists for given social email, else create one
-	user, _ := uc.userRepo.GetUserByEmail(userInfo.Email)
+	user, _ := uc.userRepo.GetUserByEmail(identity.Email)
 	var emptyUser models.User
 	if user.ID == emptyUser.ID {
-		user = models.User{Name: userInfo.Name, Email: userInfo.Email, ProfileImage: userInfo.Picture}
+		user = models.User{Name: identity.Name, Email: identity.Email, ProfileImage: identity.Picture}
 		if err := uc.userRepo.CreateUser(user); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user."})
 			return
 		}
+		// Join the teams the email was invited to before signing up, Google has verified it
+		uc.joinInvitedTeams(identity.Email)
 	}
 
-	user, _ = uc.userRepo.GetUserByEmail(userInfo.Email)
+	user, _ = uc.userRepo.GetUserByEmail(identity.Email)
 	// create authprovider entry
-	authProvider = models.AuthProvider{ProviderName: "google", ProviderKey: userInfo.ID, UserID: user.ID}
+	authProvider = models.AuthProvider{ProviderName: "google", ProviderKey: identity.Subject, UserID: user.ID}
 	if err := uc.authProviderRepo.CreateAuthProvider(authProvider); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create authprovider entry."})
 		return
 	}
 
-	jwt, err := token.GenerateToken(user)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
-		return
-	}
-
-	// c.Redirect(http.StatusSeeOther, viper.GetString("FRONTEND_SOCIAL_REDIRECT")+"?token="+jwt)
-	c.JSON(http.StatusOK, gin.H{"token": jwt, "user": user})
+	uc.completeLogin(c, user)
 }
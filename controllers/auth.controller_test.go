@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/otp"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// test MagicLinkLogin and RequestMagicLink
+func TestUserController_MagicLinkLogin(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.User{}, &models.OneTimeCode{}, &models.AuthThrottle{}, &models.Session{}, &models.TwoFactor{})
+	database.DB = db
+	viper.Set("API_SECRET", "test-secret")
+
+	db.Create(&models.User{Name: "Alice", Email: "alice@example.com", Verified: true})
+	db.Create(&models.User{Name: "Bob", Email: "bob@example.com"})
+	db.Create(&models.AuthThrottle{Key: "account:carol@example.com", Failures: 10, LastFailureAt: time.Now(), BlockedUntil: time.Now().Add(time.Hour)})
+
+	userController := NewUserController()
+	r := gin.New()
+	r.POST("/auth/magic-link", userController.RequestMagicLink)
+	r.POST("/auth/magic-link/verify", userController.MagicLinkLogin)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	issue := func(email string) string {
+		code, err := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeLogin, email, time.Now())
+		if err != nil {
+			t.Fatalf("Failed to issue code: %v", err)
+		}
+		return code
+	}
+	accountFailures := func(email string) int {
+		var throttle models.AuthThrottle
+		db.Where("throttle_key = ?", "account:"+email).Limit(1).Find(&throttle)
+		return throttle.Failures
+	}
+
+	// Test case 1: Wrong code, counted against the account
+	code := issue("alice@example.com")
+	w := sendRequest("/auth/magic-link/verify", `{"email": "alice@example.com", "otp": "wrong"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, 1, accountFailures("alice@example.com"))
+
+	// Test case 2: Log in, the failures of the account are forgotten
+	w = sendRequest("/auth/magic-link/verify", `{"email": "alice@example.com", "otp": "`+code+`"}`)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"token"`)
+	assert.Equal(t, 0, accountFailures("alice@example.com"))
+
+	// Test case 3: Reused code
+	w = sendRequest("/auth/magic-link/verify", `{"email": "alice@example.com", "otp": "`+code+`"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Test case 4: Unverified user
+	w = sendRequest("/auth/magic-link/verify", `{"email": "bob@example.com", "otp": "`+issue("bob@example.com")+`"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "unverified")
+
+	// Test case 5: Locked out account, even with the right code
+	w = sendRequest("/auth/magic-link/verify", `{"email": "carol@example.com", "otp": "`+issue("carol@example.com")+`"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// Test case 6: No new links for a locked out account
+	w = sendRequest("/auth/magic-link", `{"email": "Carol@example.com"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// Test case 7: No link for an unverified user, without telling
+	w = sendRequest("/auth/magic-link", `{"email": "bob@example.com"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// test DeleteAccount
+func TestUserController_DeleteAccount(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.User{}, &models.TeamMember{}, &models.OneTimeCode{}, &models.AuthThrottle{})
+	database.DB = db
+
+	alice := models.User{Name: "Alice", Email: "alice@example.com", Verified: true}
+	db.Create(&alice)
+	bob := models.User{Name: "Bob", Email: "bob@example.com", Verified: true}
+	db.Create(&bob)
+	db.Create(&models.TeamMember{TeamID: 1, UserID: alice.ID, Role: models.SuperAdminRole})
+
+	userController := NewUserController()
+	// Helper function to send the request as a logged in user
+	sendRequest := func(user models.User, query string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.DELETE("/auth/delete-account", func(c *gin.Context) {
+			c.Set("user", &user)
+		}, userController.DeleteAccount)
+		req, _ := http.NewRequest("DELETE", "/auth/delete-account?"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	code, err := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeDeletion, "alice@example.com", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to issue code: %v", err)
+	}
+
+	// Test case 1: Another user cannot act on, or probe, the account of an email
+	w := sendRequest(bob, "email=alice@example.com&otp="+code)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "user-role")
+
+	// Test case 2: A super admin cannot delete their account, and keeps the code for after the handover
+	w = sendRequest(alice, "otp="+code)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "user-role")
+	assert.NoError(t, otp.Verify(repository.NewOneTimeCodeRepository(), models.OneTimeCodeDeletion, "alice@example.com", code, time.Now()))
+}
+
+// test the account throttle of VerifyEmail, SetNewPassword and the endpoints sending their codes
+func TestUserController_OneTimeCodeThrottle(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.User{}, &models.OneTimeCode{}, &models.AuthThrottle{})
+	database.DB = db
+
+	db.Create(&models.User{Name: "Alice", Email: "alice@example.com"})
+	db.Create(&models.AuthThrottle{Key: "account:carol@example.com", Failures: 10, LastFailureAt: time.Now(), BlockedUntil: time.Now().Add(time.Hour)})
+
+	userController := NewUserController()
+	r := gin.New()
+	r.POST("/auth/verify", userController.VerifyEmail)
+	r.GET("/auth/request-verification", userController.RequestVerificationAgain)
+	r.GET("/auth/forgot-password", userController.ForgotPasswordRequest)
+	r.POST("/auth/set-forgotten-password", userController.SetNewPassword)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	accountFailures := func(email string) int {
+		var throttle models.AuthThrottle
+		db.Where("throttle_key = ?", "account:"+email).Limit(1).Find(&throttle)
+		return throttle.Failures
+	}
+
+	// Test case 1: Wrong codes are counted against the account, whatever the purpose
+	otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeVerification, "alice@example.com", time.Now())
+	w := sendRequest("POST", "/auth/verify?email=alice@example.com&otp=wrong", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, 1, accountFailures("alice@example.com"))
+	w = sendRequest("POST", "/auth/set-forgotten-password?email=alice@example.com&otp=wrong", `{"new_password": "Str0ng!Password"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Equal(t, 2, accountFailures("alice@example.com"))
+
+	// Test case 2: A locked out account can neither use codes, even the right ones, nor get new ones
+	code, _ := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeVerification, "carol@example.com", time.Now())
+	w = sendRequest("POST", "/auth/verify?email=carol@example.com&otp="+code, "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	w = sendRequest("GET", "/auth/request-verification?email=carol@example.com", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	w = sendRequest("GET", "/auth/forgot-password?email=carol@example.com", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
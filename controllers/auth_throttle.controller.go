@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/auth"
+	"github.com/GDGVIT/attendance-app-backend/utils/email"
+	"github.com/gin-gonic/gin"
+)
+
+// throttled responds with 429 Too Many Requests if any of the keys is backing off or locked out, reporting whether it did.
+func (uc *UserController) throttled(c *gin.Context, keys ...auth.ThrottleKey) bool {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.Key
+	}
+	throttles, err := uc.authThrottleRepo.GetAuthThrottles(names)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check attempts"})
+		logger.Errorf("Failed to get auth throttles: " + err.Error())
+		return true
+	}
+
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, throttle := range throttles {
+		if wait := throttle.RetryAfter(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter == 0 {
+		return false
+	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": "too-many-attempts", "message": "Too many failed attempts, please try again later.", "retryAfter": seconds})
+	return true
+}
+
+// recordFailure counts a failed attempt against each of the keys, reporting whether one of them just locked out.
+func (uc *UserController) recordFailure(keys ...auth.ThrottleKey) bool {
+	now := time.Now()
+	lockedOut := false
+	for _, key := range keys {
+		_, locked, err := uc.authThrottleRepo.RecordAuthFailure(key.Key, key.Policy, now)
+		if err != nil {
+			logger.Errorf("Failed to record auth failure: " + err.Error())
+			continue
+		}
+		lockedOut = lockedOut || locked
+	}
+	return lockedOut
+}
+
+// resetThrottles forgets the failed attempts of the keys after a successful one.
+func (uc *UserController) resetThrottles(keys ...auth.ThrottleKey) {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.Key
+	}
+	if err := uc.authThrottleRepo.ResetAuthThrottles(names); err != nil {
+		logger.Errorf("Failed to reset auth throttles: " + err.Error())
+	}
+}
+
+// sendLockoutMail tells the user their account was locked out after too many failed logins.
+func (uc *UserController) sendLockoutMail(user models.User) {
+	minutes := int(auth.AccountLockoutDuration().Minutes())
+	content := "There were too many failed attempts to log in to your account, so logging in is blocked for " + strconv.Itoa(minutes) + " minutes. If this was not you, consider changing your password."
+	if err := email.GenericSendMail("Account Locked", content, user.Email, user.Name); err != nil {
+		logger.Errorf("Failed to send lockout mail: " + err.Error())
+	}
+}
@@ -1,13 +1,16 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
 	"github.com/GDGVIT/attendance-app-backend/utils/auth"
 	"github.com/GDGVIT/attendance-app-backend/utils/email"
+	"github.com/GDGVIT/attendance-app-backend/utils/otp"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,21 +18,19 @@ import (
 func (uc *UserController) ForgotPasswordRequest(c *gin.Context) {
 	useremail := c.Query("email")
 
-	// Fetch the user by email
-	user, err := uc.userRepo.GetUserByEmail(useremail)
-	if err != nil {
-		c.JSON(http.StatusOK, gin.H{"message": "Forgot Password mail sent."})
+	// no new codes while the account is locked out
+	if uc.throttled(c, auth.AccountThrottle(useremail)) {
 		return
 	}
 
-	// Check if a forgot password entry already exists for the user's email
-	err = uc.forgotRepo.DeleteForgotPasswordByEmail(user.Email)
+	// Fetch the user by email
+	user, err := uc.userRepo.GetUserByEmail(useremail)
 	if err != nil {
 		c.JSON(http.StatusOK, gin.H{"message": "Forgot Password mail sent."})
 		return
 	}
 
-	// Send the forgot password email
+	// Send the forgot password email, the code sent before stops working
 	err = email.SendForgotPasswordMail(user.Email, user.ID, user.Name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "mail", "message": "Error in sending email."})
@@ -53,64 +54,52 @@ func (uc *UserController) SetNewPassword(c *gin.Context) {
 	useremail := c.Query("email")
 	otp := c.Query("otp")
 
-	// Fetch the forgot password entry by email
-	forgotPasswordEntry, err := uc.forgotRepo.GetForgotPasswordByEmail(useremail)
-	if err != nil {
-		logger.Errorf("Error while verifying: %v", err.Error())
-		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Invalid verification. Please check email link again."})
+	if !auth.CheckPasswordStrength(forgotPasswordInput.NewPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "password-strength", "message": "Password not strong enough."})
 		return
 	}
 
-	if forgotPasswordEntry.ValidTill.Before(time.Now()) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "otp-expiry", "message": "Password OTP has expired, please request forgot password again."})
+	if !uc.consumeOneTimeCode(c, models.OneTimeCodeForgot, useremail, otp, auth.AccountThrottle(useremail)) {
 		return
 	}
 
-	if forgotPasswordEntry.OTP == otp {
-		// Fetch the user by email
-		user, err := uc.userRepo.GetUserByEmail(useremail)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
-			return
-		}
-		pwdAuth, err := uc.passwordAuthRepo.GetPwdAuthItemByEmail(useremail)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
-			return
-		}
+	// Fetch the user by email
+	user, err := uc.userRepo.GetUserByEmail(useremail)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
+		return
+	}
+	pwdAuth, err := uc.passwordAuthRepo.GetPwdAuthItemByEmail(useremail)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
+		return
+	}
 
-		if !auth.CheckPasswordStrength(forgotPasswordInput.NewPassword) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "password-strength", "message": "Password not strong enough."})
-			return
-		}
-		pwdAuth.Password = forgotPasswordInput.NewPassword
-		pwdAuth.HashPassword()
+	pwdAuth.Password = forgotPasswordInput.NewPassword
+	pwdAuth.HashPassword()
 
-		err = uc.userRepo.SaveUser(user)
-		if err != nil {
-			logger.Errorf("Save user after forgot and new: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "save-data", "message": "Failed to update password"})
-			return
-		}
-		err = uc.passwordAuthRepo.UpdatePwdAuthItem(pwdAuth)
-		if err != nil {
-			logger.Errorf("Save user after forgot and new: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "save-data", "message": "Failed to update password"})
-			return
-		}
+	err = uc.userRepo.SaveUser(user)
+	if err != nil {
+		logger.Errorf("Save user after forgot and new: " + err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "save-data", "message": "Failed to update password"})
+		return
+	}
+	err = uc.passwordAuthRepo.UpdatePwdAuthItem(pwdAuth)
+	if err != nil {
+		logger.Errorf("Save user after forgot and new: " + err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "save-data", "message": "Failed to update password"})
+		return
+	}
 
-		email.GenericSendMail("Password Reset", "Password for your account was reset recently.", user.Email, user.Name)
+	// log out every device, whoever knew the old password may be logged in
+	err = uc.sessionRepo.RevokeUserSessions(user.ID, 0, time.Now())
+	if err != nil {
+		logger.Errorf("Error while revoking sessions after forgot and new: " + err.Error())
+	}
 
-		// Delete the forgot password entry
-		err = uc.forgotRepo.DeleteForgotPasswordByEmail(useremail)
-		if err != nil {
-			logger.Errorf("Error while deleting forgot password entry: " + err.Error())
-		}
+	email.GenericSendMail("Password Reset", "Password for your account was reset recently.", user.Email, user.Name)
 
-		c.JSON(http.StatusOK, gin.H{"message": "Password set successfully. Please proceed to login."})
-	} else {
-		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Invalid verification. Password not updated."})
-	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password set successfully. Please proceed to login."})
 }
 
 // ResetPasswordController handles the reset password by logged in user
@@ -160,6 +149,12 @@ func (uc *UserController) ResetPassword(c *gin.Context) {
 		return
 	}
 
+	// log out every other device, this one stays logged in
+	err = uc.sessionRepo.RevokeUserSessions(currentUser.ID, c.GetUint("sessionID"), time.Now())
+	if err != nil {
+		logger.Errorf("Revoking sessions after password reset failed: " + err.Error())
+	}
+
 	email.GenericSendMail("Password Reset Successfully", "Your password for Nock was changed. Secure your account if this was not you.", currentUser.Email, currentUser.Name)
 	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
 }
@@ -175,16 +170,13 @@ func (uc *UserController) RequestDeletion(c *gin.Context) {
 	user, _ := c.Get("user")
 	currentUser := user.(*models.User)
 
-	// Check if a deletion confirmation record already exists for the user's email, and remove it
-	err := uc.deletionRepo.DeleteDeletionConfirmationByEmail(currentUser.Email)
-	if err != nil {
-		logger.Errorf("Error while removing past deletion req: %v", err.Error())
-		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Error while removing past deletion request."})
+	// no new codes while the account is locked out
+	if uc.throttled(c, auth.AccountThrottle(currentUser.Email)) {
 		return
 	}
 
-	// Send deletion email
-	err = email.SendDeletionMail(currentUser.Email, currentUser.ID, currentUser.Name)
+	// Send deletion email, the code sent before stops working
+	err := email.SendDeletionMail(currentUser.Email, currentUser.ID, currentUser.Name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "mail", "message": "Error in sending email."})
 		return
@@ -194,93 +186,120 @@ func (uc *UserController) RequestDeletion(c *gin.Context) {
 }
 
 func (uc *UserController) DeleteAccount(c *gin.Context) {
-	useremail := c.Query("email")
+	currentUser, _ := c.Get("user")
+	user := *currentUser.(*models.User)
+	// the account deleted is always the caller's, an email in the query is ignored
+	useremail := user.Email
 	otp := c.Query("otp")
 
-	// Fetch the forgot password entry by email
-	deletionEntry, err := uc.deletionRepo.GetDeletionConfirmationByEmail(useremail)
+	// should not allow user deletion if user is a super admin for some team(s), checked before the code is used up
+	teamMembers, err := uc.teamMemberRepo.GetTeamMembersByUserID(user.ID)
 	if err != nil {
-		logger.Errorf("Error while verifying deletion: %v", err.Error())
-		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Invalid verification. Please check email link again."})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
 		return
 	}
+	for _, teamMember := range teamMembers {
+		if teamMember.Role == models.SuperAdminRole {
+			c.JSON(http.StatusForbidden, gin.H{"error": "user-role", "message": "Cannot delete account. User is a super admin for some team(s)."})
+			return
+		}
+	}
 
-	if deletionEntry.ValidTill.Before(time.Now()) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "otp-expiry", "message": "Password OTP has expired, please request account deletion again."})
+	if !uc.consumeOneTimeCode(c, models.OneTimeCodeDeletion, useremail, otp, auth.AccountThrottle(useremail)) {
 		return
 	}
 
-	if deletionEntry.OTP == otp {
-		// Fetch the user by email
-		user, err := uc.userRepo.GetUserByEmail(useremail)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
-			return
-		}
+	// delete the codes sent to the user
+	err = uc.oneTimeCodeRepo.DeleteOneTimeCodesByEmail(useremail)
+	if err != nil {
+		logger.Errorf("Error while deleting one-time codes: " + err.Error())
+	}
 
-		// should not allow user deletion if user is a super admin for some team(s)
-		teamMembers, err := uc.teamMemberRepo.GetTeamMembersByUserID(user.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "user-fetch", "message": "Failed to fetch user"})
-			return
-		}
-		for _, teamMember := range teamMembers {
-			if teamMember.Role == models.SuperAdminRole {
-				c.JSON(http.StatusForbidden, gin.H{"error": "user-role", "message": "Cannot delete account. User is a super admin for some team(s)."})
-				return
-			}
-		}
+	// delete team_entry_reqs for user
+	err = uc.teamEntryRequestRepo.DeleteTeamEntryRequestByID(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
+		logger.Errorf("Error while deleting team entry request: " + err.Error())
+	}
 
-		// delete forgot_password entries for user
-		err = uc.forgotRepo.DeleteForgotPasswordByEmail(useremail)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
-			logger.Errorf("Error while deleting forgot password entry: " + err.Error())
-		}
+	// delete team_members for user
+	err = uc.teamMemberRepo.DeleteTeamMemberByUserID(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
+		logger.Errorf("Error while deleting team member: " + err.Error())
+	}
 
-		// delete team_entry_reqs for user
-		err = uc.teamEntryRequestRepo.DeleteTeamEntryRequestByID(user.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
-			logger.Errorf("Error while deleting team entry request: " + err.Error())
-		}
+	err = uc.userRepo.DeleteUserByID(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
+		return
+	}
 
-		// delete team_members for user
-		err = uc.teamMemberRepo.DeleteTeamMemberByUserID(user.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
-			logger.Errorf("Error while deleting team member: " + err.Error())
-		}
+	err = uc.passwordAuthRepo.DeletePwdAuthItemByEmail(user.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
+		return
+	}
 
-		err = uc.userRepo.DeleteUserByID(user.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
-			return
-		}
+	err = uc.authProviderRepo.DeleteAuthProviderByUserID(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
+		return
+	}
 
-		err = uc.passwordAuthRepo.DeletePwdAuthItemByEmail(user.Email)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
-			return
-		}
+	err = uc.sessionRepo.RevokeUserSessions(user.ID, 0, time.Now())
+	if err != nil {
+		logger.Errorf("Error while revoking sessions of deleted user: " + err.Error())
+	}
 
-		err = uc.authProviderRepo.DeleteAuthProviderByUserID(user.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "deletion", "message": "Failed to delete user."})
-			return
-		}
+	err = uc.twoFactorRepo.DeleteTwoFactor(user.ID)
+	if err != nil {
+		logger.Errorf("Error while deleting two-factor authentication of deleted user: " + err.Error())
+	}
+
+	email.GenericSendMail("Account Deleted", "Your account on GDSC Attendance App has been deleted.", user.Email, user.Name)
 
-		email.GenericSendMail("Account Deleted", "Your account on GDSC Attendance App has been deleted.", user.Email, user.Name)
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully."})
+	logger.Infof("Account deleted")
+}
+
+// consumeOneTimeCode uses up the code sent for the purpose to the email, responding with why it cannot be used otherwise.
+// Wrong codes count against the IP address of the request, and the throttle keys given, such as the account for logins.
+// The account is emailed if the keys lock it out, and they are reset once the code is used.
+func (uc *UserController) consumeOneTimeCode(c *gin.Context, purpose, email, code string, keys ...auth.ThrottleKey) bool {
+	ipThrottle := auth.IPThrottle(c.ClientIP())
+	if uc.throttled(c, append(keys, ipThrottle)...) {
+		return false
+	}
 
-		// Delete the deletion request entry
-		err = uc.deletionRepo.DeleteDeletionConfirmationByEmail(useremail)
-		if err != nil {
-			logger.Errorf("Error while deleting deletion entry: " + err.Error())
+	err := otp.Verify(uc.oneTimeCodeRepo, purpose, email, code, time.Now())
+	switch {
+	case err == nil:
+		if len(keys) > 0 {
+			uc.resetThrottles(keys...)
 		}
+		return true
+	case errors.Is(err, repository.ErrOneTimeCodeExhausted):
+		uc.recordCodeFailure(email, ipThrottle, keys)
+		c.JSON(http.StatusForbidden, gin.H{"error": "otp-invalidated", "message": "Too many wrong attempts, please request a new code."})
+	case errors.Is(err, repository.ErrOneTimeCodeExpired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "otp-expiry", "message": "The code has expired, please request a new one."})
+	case errors.Is(err, repository.ErrOneTimeCodeNotFound), errors.Is(err, repository.ErrOneTimeCodeIncorrect):
+		uc.recordCodeFailure(email, ipThrottle, keys)
+		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Invalid verification. Please check email link again."})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "verification", "message": "Failed to check the code."})
+		logger.Errorf("Failed to consume one-time code: " + err.Error())
+	}
+	return false
+}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully."})
-		logger.Infof("Account deleted")
-	} else {
-		c.JSON(http.StatusForbidden, gin.H{"error": "verification", "message": "Invalid verification. Account not deleted."})
+// recordCodeFailure counts a wrong code against the IP address and the keys, emailing the user of the email if the keys lock them out.
+func (uc *UserController) recordCodeFailure(email string, ipThrottle auth.ThrottleKey, keys []auth.ThrottleKey) {
+	if !uc.recordFailure(append(keys, ipThrottle)...) || len(keys) == 0 {
+		return
+	}
+	if user, err := uc.userRepo.GetUserByEmail(email); err == nil {
+		uc.sendLockoutMail(user)
 	}
 }
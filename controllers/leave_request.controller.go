@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+// LeaveRequestController handles leave request routes.
+type LeaveRequestController struct {
+	leaveRequestService services.LeaveRequestServiceInterface
+}
+
+// NewLeaveRequestController creates a new LeaveRequestController.
+func NewLeaveRequestController(leaveRequestService services.LeaveRequestServiceInterface) *LeaveRequestController {
+	return &LeaveRequestController{leaveRequestService}
+}
+
+// SubmitLeaveRequest lets the current user ask to be excused from a meeting, with a reason.
+func (lrc *LeaveRequestController) SubmitLeaveRequest(c *gin.Context) {
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting or team ID", "error": err.Error()})
+		return
+	}
+
+	var leaveRequest struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&leaveRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	userID := currentUser.(*models.User).ID
+
+	request, err := lrc.leaveRequestService.SubmitLeaveRequest(teamID, meetingID, userID, leaveRequest.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to submit leave request", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// WithdrawLeaveRequest deletes the current user's pending leave request for a meeting.
+func (lrc *LeaveRequestController) WithdrawLeaveRequest(c *gin.Context) {
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting or team ID", "error": err.Error()})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	userID := currentUser.(*models.User).ID
+
+	if err := lrc.leaveRequestService.WithdrawLeaveRequest(teamID, meetingID, userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to withdraw leave request", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Leave request withdrawn"})
+}
+
+// GetMyLeaveRequests retrieves the current user's leave requests in a team.
+func (lrc *LeaveRequestController) GetMyLeaveRequests(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	userID := currentUser.(*models.User).ID
+
+	requests, err := lrc.leaveRequestService.GetUserLeaveRequests(uint(teamID), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to get leave requests", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// GetLeaveRequests retrieves the leave requests of a team with who made them. Query ?status=pending/approved/rejected and ?meetingID= to filter.
+func (lrc *LeaveRequestController) GetLeaveRequests(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	meetingID, err := strconv.ParseUint(c.DefaultQuery("meetingID", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meeting ID"})
+		return
+	}
+
+	requests, err := lrc.leaveRequestService.GetLeaveRequests(uint(teamID), c.Query("status"), uint(meetingID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to get leave requests", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// ReviewLeaveRequest approves or rejects a pending leave request, with an optional note for the member.
+func (lrc *LeaveRequestController) ReviewLeaveRequest(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	requestID, err := strconv.ParseUint(c.Param("requestID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+
+	var reviewRequest struct {
+		Status string `json:"status" binding:"required"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&reviewRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	request, err := lrc.leaveRequestService.ReviewLeaveRequest(auditActor(c), uint(requestID), uint(teamID), reviewRequest.Status, reviewRequest.Note, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to review leave request", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
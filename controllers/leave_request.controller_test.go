@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// test SubmitLeaveRequest and ReviewLeaveRequest
+func TestLeaveRequestController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockLeaveRequestService(ctrl)
+
+	r := gin.Default()
+	leaveRequestController := NewLeaveRequestController(mockService)
+
+	// Set the current user in the context
+	r.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{Model: gorm.Model{ID: 9}})
+	})
+	r.POST("/team/:teamID/meetings/:meetingID/leave", leaveRequestController.SubmitLeaveRequest)
+	r.GET("/team/:teamID/leave-requests", leaveRequestController.GetLeaveRequests)
+	r.PATCH("/team/:teamID/leave-requests/:requestID", leaveRequestController.ReviewLeaveRequest)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test case 1: Submit
+	mockService.EXPECT().SubmitLeaveRequest(uint(1), uint(2), uint(9), "Exam").Return(models.LeaveRequest{TeamID: 1, MeetingID: 2, UserID: 9, Reason: "Exam", Status: models.LeaveRequestPending}, nil)
+	w := sendRequest("POST", "/team/1/meetings/2/leave", `{"reason": "Exam"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Test case 2: Submit without a reason
+	w = sendRequest("POST", "/team/1/meetings/2/leave", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 3: List filtered by status and meeting
+	mockService.EXPECT().GetLeaveRequests(uint(1), models.LeaveRequestPending, uint(2)).Return([]models.LeaveRequestResponse{}, nil)
+	w = sendRequest("GET", "/team/1/leave-requests?status=pending&meetingID=2", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 4: Review
+	mockService.EXPECT().ReviewLeaveRequest(models.AuditActor{UserID: 9}, uint(7), uint(1), models.LeaveRequestApproved, "Get well", gomock.Any()).Return(models.LeaveRequest{Status: models.LeaveRequestApproved}, nil)
+	w = sendRequest("PATCH", "/team/1/leave-requests/7", `{"status": "approved", "note": "Get well"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 5: Review a request that was already reviewed
+	mockService.EXPECT().ReviewLeaveRequest(models.AuditActor{UserID: 9}, uint(7), uint(1), models.LeaveRequestRejected, "", gomock.Any()).Return(models.LeaveRequest{}, errors.New("leave request has already been reviewed"))
+	w = sendRequest("PATCH", "/team/1/leave-requests/7", `{"status": "rejected"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -31,11 +33,14 @@ func (mc *MeetingController) CreateMeeting(c *gin.Context) {
 	}
 
 	var newMeeting struct {
-		Title       string          `json:"title" binding:"required"`
-		Description string          `json:"description" binding:"required"`
-		Venue       string          `json:"venue" binding:"required"`
-		Location    models.Location `json:"location" binding:"required"`
-		StartTime   time.Time       `json:"startTime" binding:"required"`
+		Title            string                  `json:"title" binding:"required"`
+		Description      string                  `json:"description" binding:"required"`
+		Venue            string                  `json:"venue" binding:"required"`
+		Location         models.Location         `json:"location" binding:"required"`
+		Geofence         models.Geofence         `json:"geofence"`
+		Schedule         models.MeetingSchedule  `json:"schedule"`
+		AttendancePolicy models.AttendancePolicy `json:"attendancePolicy"`
+		StartTime        time.Time               `json:"startTime" binding:"required"`
 	}
 
 	// Bind request body to meeting structure
@@ -45,16 +50,19 @@ func (mc *MeetingController) CreateMeeting(c *gin.Context) {
 	}
 
 	meeting := models.Meeting{
-		TeamID:      uint(teamID),
-		Title:       newMeeting.Title,
-		Description: newMeeting.Description,
-		Venue:       newMeeting.Venue,
-		Location:    newMeeting.Location,
-		StartTime:   newMeeting.StartTime,
+		TeamID:           uint(teamID),
+		Title:            newMeeting.Title,
+		Description:      newMeeting.Description,
+		Venue:            newMeeting.Venue,
+		Location:         newMeeting.Location,
+		Geofence:         newMeeting.Geofence,
+		Schedule:         newMeeting.Schedule,
+		AttendancePolicy: newMeeting.AttendancePolicy,
+		StartTime:        newMeeting.StartTime,
 	}
 
 	// Call the meeting service to create the meeting
-	createdMeeting, err := mc.meetingService.CreateMeeting(meeting.TeamID, meeting.Title, meeting.Description, meeting.Venue, meeting.Location, meeting.StartTime)
+	createdMeeting, err := mc.meetingService.CreateMeeting(auditActor(c), meeting.TeamID, meeting.Title, meeting.Description, meeting.Venue, meeting.Location, meeting.Geofence, meeting.Schedule, meeting.AttendancePolicy, meeting.StartTime)
 	if err != nil {
 		logger.Errorf("Failed to create meeting: " + err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to create the meeting", "error": err.Error()})
@@ -64,6 +72,32 @@ func (mc *MeetingController) CreateMeeting(c *gin.Context) {
 	c.JSON(http.StatusCreated, createdMeeting)
 }
 
+// UpdateMeeting changes the details of a meeting, only the fields present in the body are changed.
+func (mc *MeetingController) UpdateMeeting(c *gin.Context) {
+	// Get meeting ID from route parameters
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting or team ID", "error": err.Error()})
+		return
+	}
+
+	var update models.MeetingUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	// Call the meeting service to update the meeting
+	updatedMeeting, err := mc.meetingService.UpdateMeeting(auditActor(c), meetingID, teamID, update)
+	if err != nil {
+		logger.Errorf("Failed to update meeting: " + err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to update the meeting", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedMeeting)
+}
+
 // GetMeetingsByTeamIDAndMeetingOver retrieves all meetings for a team.
 func (mc *MeetingController) GetMeetingsByTeamID(c *gin.Context) {
 	// Get team ID from route parameters
@@ -137,7 +171,7 @@ func (mc *MeetingController) StartMeeting(c *gin.Context) {
 	}
 
 	// Call the meeting service to start the meeting
-	meeting, err := mc.meetingService.StartMeeting(uint(meetingID), teamID)
+	meeting, err := mc.meetingService.StartMeeting(auditActor(c), meetingID, teamID)
 	if err != nil {
 		logger.Warnf("Failed to start meeting (meeting: %d, team:%d): "+err.Error(), meetingID, teamID)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to start the meeting", "error": err.Error()})
@@ -157,7 +191,7 @@ func (mc *MeetingController) StartAttendance(c *gin.Context) {
 	}
 
 	// Call the meeting service to start attendance for the meeting
-	meeting, err := mc.meetingService.StartAttendance(uint(meetingID), teamID)
+	meeting, err := mc.meetingService.StartAttendance(auditActor(c), meetingID, teamID)
 	if err != nil {
 		logger.Warnf("Failed to start attendance for meeting (meeting: %d, team:%d): "+err.Error(), meetingID, teamID)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to start attendance for the meeting", "error": err.Error()})
@@ -177,7 +211,7 @@ func (mc *MeetingController) EndAttendance(c *gin.Context) {
 	}
 
 	// Call the meeting service to end attendance for the meeting
-	meeting, err := mc.meetingService.EndAttendance(uint(meetingID), teamID)
+	meeting, err := mc.meetingService.EndAttendance(auditActor(c), meetingID, teamID)
 	if err != nil {
 		logger.Warnf("Failed to end attendance for meeting (meeting: %d, team:%d): "+err.Error(), meetingID, teamID)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to end attendance for the meeting", "error": err.Error()})
@@ -197,7 +231,7 @@ func (mc *MeetingController) EndMeeting(c *gin.Context) {
 	}
 
 	// Call the meeting service to end the meeting
-	meeting, err := mc.meetingService.EndMeeting(uint(meetingID), teamID)
+	meeting, err := mc.meetingService.EndMeeting(auditActor(c), meetingID, teamID)
 	if err != nil {
 		logger.Warnf("Failed to end meeting (meeting: %d, team:%d): "+err.Error(), meetingID, teamID)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to end the meeting", "error": err.Error()})
@@ -217,7 +251,7 @@ func (mc *MeetingController) DeleteMeetingByID(c *gin.Context) {
 	}
 
 	// Call the meeting service to delete the meeting
-	err = mc.meetingService.DeleteMeetingByID(uint(meetingID), teamID)
+	err = mc.meetingService.DeleteMeetingByID(auditActor(c), meetingID, teamID)
 	if err != nil {
 		logger.Warnf("Failed to delete meeting (meeting: %d, team:%d): "+err.Error(), meetingID, teamID)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to delete the meeting", "error": err.Error()})
@@ -227,6 +261,25 @@ func (mc *MeetingController) DeleteMeetingByID(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Meeting deleted successfully"})
 }
 
+// GetAttendanceCode retrieves the current rotating attendance code for a meeting, for admins to display.
+func (mc *MeetingController) GetAttendanceCode(c *gin.Context) {
+	// Get meeting ID from route parameters
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting or team ID", "error": err.Error()})
+		return
+	}
+
+	// Call the meeting service to get the current code
+	code, err := mc.meetingService.GetAttendanceCode(meetingID, teamID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to get attendance code", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, code)
+}
+
 // MarkAttendance marks attendance for a meeting.
 func (mc *MeetingController) MarkAttendance(c *gin.Context) {
 	// Get meeting ID from route parameters
@@ -236,18 +289,48 @@ func (mc *MeetingController) MarkAttendance(c *gin.Context) {
 		return
 	}
 
+	// Code is the attendance code displayed by the admins. Location is optional, it is only required for meetings with a geofence.
+	var markRequest struct {
+		Code     string           `json:"code"`
+		Location *models.Location `json:"location"`
+		Accuracy float64          `json:"accuracy"`
+	}
+	if err := c.ShouldBindJSON(&markRequest); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	var position *models.ReportedLocation
+	if markRequest.Location != nil {
+		position = &models.ReportedLocation{Location: *markRequest.Location, Accuracy: markRequest.Accuracy}
+	}
+
 	currentUser, _ := c.Get("user")
 	userID := currentUser.(*models.User).ID
 
 	// Call the meeting service to mark attendance
 	now := time.Now()
-	onTime, err := mc.meetingService.MarkAttendanceForUserInMeeting(userID, meetingID, now, teamID)
+	attendance, err := mc.meetingService.MarkAttendanceForUserInMeeting(userID, meetingID, now, teamID, markRequest.Code, position)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to mark attendance", "error": err.Error()})
+		c.JSON(markAttendanceErrorStatus(err), gin.H{"message": "Failed to mark attendance", "error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Attendance marked successfully.", "onTime": onTime})
+	c.JSON(http.StatusOK, gin.H{"message": "Attendance marked successfully.", "onTime": attendance.OnTime, "status": attendance.Status, "minutesLate": attendance.MinutesLate})
+}
+
+// markAttendanceErrorStatus returns the status to respond with when attendance cannot be marked.
+func markAttendanceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrLocationRequired), errors.Is(err, services.ErrLocationInaccurate):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrInvalidAttendanceCode), errors.Is(err, services.ErrOutsideGeofence), errors.Is(err, services.ErrOutsideAltitudeGeofence),
+		errors.Is(err, services.ErrMeetingNotOpen), errors.Is(err, services.ErrAttendanceNotOpen):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrAttendanceMarked):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
 }
 
 // GetAttendanceForMeeting retrieves attendance for a meeting.
@@ -269,6 +352,96 @@ func (mc *MeetingController) GetAttendanceForMeeting(c *gin.Context) {
 	c.JSON(http.StatusOK, attendance)
 }
 
+// GetMeetingSummary retrieves who was present, on time, late, absent and excused for a meeting.
+func (mc *MeetingController) GetMeetingSummary(c *gin.Context) {
+	// Get meeting ID from route parameters
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting or team ID", "error": err.Error()})
+		return
+	}
+
+	summary, err := mc.meetingService.GetMeetingSummary(meetingID, teamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to get summary for the meeting", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// get meetingID, teamID and the member's userID from route params
+func getTeamMeetingAndUserFromQueryParams(c *gin.Context) (uint, uint, uint, error) {
+	meetingID, teamID, err := getTeamAndMeetingFromQueryParams(c)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	userID, err := strconv.ParseUint(c.Param("userID"), 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return meetingID, teamID, uint(userID), nil
+}
+
+// OverrideAttendance lets an admin mark a member present or late, or flip OnTime of an existing record.
+func (mc *MeetingController) OverrideAttendance(c *gin.Context) {
+	// Get meeting ID, team ID and user ID from route parameters
+	meetingID, teamID, userID, err := getTeamMeetingAndUserFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting, team or user ID", "error": err.Error()})
+		return
+	}
+
+	var overrideRequest struct {
+		OnTime *bool  `json:"onTime" binding:"required"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&overrideRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	// Call the meeting service to override attendance
+	attendance, err := mc.meetingService.OverrideAttendance(auditActor(c), userID, meetingID, teamID, *overrideRequest.OnTime, overrideRequest.Reason, time.Now())
+	if err != nil {
+		logger.Warnf("Failed to override attendance (meeting: %d, team:%d, user:%d): "+err.Error(), meetingID, teamID, userID)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to update attendance", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// RemoveAttendance lets an admin remove a member's attendance record.
+func (mc *MeetingController) RemoveAttendance(c *gin.Context) {
+	// Get meeting ID, team ID and user ID from route parameters
+	meetingID, teamID, userID, err := getTeamMeetingAndUserFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting, team or user ID", "error": err.Error()})
+		return
+	}
+
+	var removeRequest struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&removeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	// Call the meeting service to remove attendance
+	err = mc.meetingService.RemoveAttendance(auditActor(c), userID, meetingID, teamID, removeRequest.Reason, time.Now())
+	if err != nil {
+		logger.Warnf("Failed to remove attendance (meeting: %d, team:%d, user:%d): "+err.Error(), meetingID, teamID, userID)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to remove attendance", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attendance removed successfully"})
+}
+
 // UpcomingUserMeetings retrieves upcoming meetings for a user.
 func (mc *MeetingController) UpcomingUserMeetings(c *gin.Context) {
 	currentUser, _ := c.Get("user")
@@ -11,9 +11,11 @@ import (
 
 	"github.com/GDGVIT/attendance-app-backend/mocks" // Import your mock package
 	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/services"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func createTestMeeting(startTime time.Time) models.Meeting {
@@ -72,16 +74,21 @@ func TestMeetingController_CreateMeeting(t *testing.T) {
 			Longitude: 98.765432,
 			Altitude:  0,
 		},
-		StartTime: time,
+		AttendancePolicy: models.AttendancePolicy{Enabled: true, GraceMinutes: 5, LateMinutes: 15},
+		StartTime:        time,
 	}
 
 	// Mock the service's CreateMeeting function
 	mockService.EXPECT().CreateMeeting(
+		gomock.Any(),
 		uint(1),
 		meeting.Title,
 		meeting.Description,
 		meeting.Venue,
 		meeting.Location,
+		meeting.Geofence,
+		meeting.Schedule,
+		meeting.AttendancePolicy,
 		meeting.StartTime,
 	).Return(meeting, nil)
 
@@ -224,7 +231,7 @@ func TestMeetingController_StartMeeting(t *testing.T) {
 	now := time.Now().Add(time.Hour)
 	meeting := createTestMeeting(now)
 	meeting.MeetingPeriod = true
-	mockService.EXPECT().StartMeeting(uint(1), uint(1)).Return(meeting, nil)
+	mockService.EXPECT().StartMeeting(gomock.Any(), uint(1), uint(1)).Return(meeting, nil)
 	w, responseMeeting := sendRequest("PUT", "/team/1/meetings/1/start")
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, meeting.Title, responseMeeting.Title)
@@ -235,7 +242,7 @@ func TestMeetingController_StartMeeting(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Test case 3: Meeting already ended
-	mockService.EXPECT().StartMeeting(uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
+	mockService.EXPECT().StartMeeting(gomock.Any(), uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
 	w, _ = sendRequest("PUT", "/team/1/meetings/1/start")
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
@@ -264,7 +271,7 @@ func TestMeetingController_StartAttendance(t *testing.T) {
 	now := time.Now().Add(time.Hour)
 	meeting := createTestMeeting(now)
 	meeting.AttendancePeriod = true
-	mockService.EXPECT().StartAttendance(uint(1), uint(1)).Return(meeting, nil)
+	mockService.EXPECT().StartAttendance(gomock.Any(), uint(1), uint(1)).Return(meeting, nil)
 	w, responseMeeting := sendRequest("PUT", "/team/1/meetings/1/attendance/start")
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, meeting.Title, responseMeeting.Title)
@@ -275,7 +282,7 @@ func TestMeetingController_StartAttendance(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Test case 3: Meeting already ended
-	mockService.EXPECT().StartAttendance(uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
+	mockService.EXPECT().StartAttendance(gomock.Any(), uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
 	w, _ = sendRequest("PUT", "/team/1/meetings/1/attendance/start")
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
@@ -304,7 +311,7 @@ func TestMeetingController_EndAttendance(t *testing.T) {
 	now := time.Now().Add(time.Hour)
 	meeting := createTestMeeting(now)
 	meeting.AttendanceOver = true
-	mockService.EXPECT().EndAttendance(uint(1), uint(1)).Return(meeting, nil)
+	mockService.EXPECT().EndAttendance(gomock.Any(), uint(1), uint(1)).Return(meeting, nil)
 	w, responseMeeting := sendRequest("PUT", "/team/1/meetings/1/attendance/end")
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, meeting.Title, responseMeeting.Title)
@@ -315,7 +322,7 @@ func TestMeetingController_EndAttendance(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Test case 3: Meeting already ended
-	mockService.EXPECT().EndAttendance(uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
+	mockService.EXPECT().EndAttendance(gomock.Any(), uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
 	w, _ = sendRequest("PUT", "/team/1/meetings/1/attendance/end")
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
@@ -343,7 +350,7 @@ func TestMeetingController_EndMeeting(t *testing.T) {
 	now := time.Now().Add(time.Hour)
 	meeting := createTestMeeting(now)
 	meeting.MeetingOver = true
-	mockService.EXPECT().EndMeeting(uint(1), uint(1)).Return(meeting, nil)
+	mockService.EXPECT().EndMeeting(gomock.Any(), uint(1), uint(1)).Return(meeting, nil)
 	w, responseMeeting := sendRequest("PUT", "/team/1/meetings/1/end")
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, meeting.Title, responseMeeting.Title)
@@ -354,7 +361,7 @@ func TestMeetingController_EndMeeting(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Test case 3: Meeting already ended
-	mockService.EXPECT().EndMeeting(uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
+	mockService.EXPECT().EndMeeting(gomock.Any(), uint(1), uint(1)).Return(models.Meeting{}, errors.New("some error"))
 	w, _ = sendRequest("PUT", "/team/1/meetings/1/end")
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
@@ -380,7 +387,7 @@ func TestMeetingController_DeleteMeeting(t *testing.T) {
 	}
 
 	// Test case 1: Valid request
-	mockService.EXPECT().DeleteMeetingByID(uint(1), uint(1)).Return(nil)
+	mockService.EXPECT().DeleteMeetingByID(gomock.Any(), uint(1), uint(1)).Return(nil)
 	w, _ := sendRequest("DELETE", "/team/1/meetings/1")
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -389,7 +396,7 @@ func TestMeetingController_DeleteMeeting(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	// Test case 3: Meeting already ended
-	mockService.EXPECT().DeleteMeetingByID(uint(1), uint(1)).Return(errors.New("some error"))
+	mockService.EXPECT().DeleteMeetingByID(gomock.Any(), uint(1), uint(1)).Return(errors.New("some error"))
 	w, _ = sendRequest("DELETE", "/team/1/meetings/1")
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
@@ -436,6 +443,176 @@ func TestMeetingController_GetAttendanceForMeeting(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+// test GetAttendanceCode
+func TestMeetingController_GetAttendanceCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMeetingService(ctrl)
+
+	r := gin.Default()
+	meetingController := NewMeetingController(mockService)
+
+	r.GET("/team/:teamID/meetings/:meetingID/attendance/code", meetingController.GetAttendanceCode)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(method, path string) (*httptest.ResponseRecorder, models.AttendanceCodeResponse) {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var response models.AttendanceCodeResponse
+		_ = json.NewDecoder(w.Body).Decode(&response)
+		return w, response
+	}
+
+	// Test case 1: Valid request
+	code := models.AttendanceCodeResponse{Code: "123456", Period: 30, ExpiresAt: time.Now().Add(10 * time.Second)}
+	mockService.EXPECT().GetAttendanceCode(uint(1), uint(1), gomock.Any()).Return(code, nil)
+	w, response := sendRequest("GET", "/team/1/meetings/1/attendance/code")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, code.Code, response.Code)
+
+	// Test case 2: Invalid meeting ID
+	w, _ = sendRequest("GET", "/team/1/meetings/jj/attendance/code")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 3: Attendance not started
+	mockService.EXPECT().GetAttendanceCode(uint(1), uint(1), gomock.Any()).Return(models.AttendanceCodeResponse{}, errors.New("attendance not started"))
+	w, _ = sendRequest("GET", "/team/1/meetings/1/attendance/code")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// test MarkAttendance
+func TestMeetingController_MarkAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMeetingService(ctrl)
+
+	r := gin.Default()
+	meetingController := NewMeetingController(mockService)
+	r.POST("/team/:teamID/meetings/:meetingID/attendance", func(c *gin.Context) {
+		user := &models.User{}
+		user.ID = 2
+		c.Set("user", user)
+	}, meetingController.MarkAttendance)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"marked", nil, http.StatusOK},
+		{"wrong code", services.ErrInvalidAttendanceCode, http.StatusForbidden},
+		{"too far", services.ErrOutsideGeofence, http.StatusForbidden},
+		{"wrong floor", services.ErrOutsideAltitudeGeofence, http.StatusForbidden},
+		{"attendance not started", services.ErrAttendanceNotOpen, http.StatusForbidden},
+		{"location missing", services.ErrLocationRequired, http.StatusBadRequest},
+		{"location inaccurate", services.ErrLocationInaccurate, http.StatusBadRequest},
+		{"already marked", services.ErrAttendanceMarked, http.StatusConflict},
+		{"database error", errors.New("connection lost"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.EXPECT().MarkAttendanceForUserInMeeting(uint(2), uint(1), gomock.Any(), uint(1), "123456", gomock.Any()).
+				Return(models.MeetingAttendance{Status: models.AttendanceStatusOnTime}, tt.err)
+
+			req, _ := http.NewRequest("POST", "/team/1/meetings/1/attendance", bytes.NewBufferString(`{"code": "123456", "location": {"Latitude": 1, "Longitude": 2}, "accuracy": 5}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+}
+
+// test UpdateMeeting
+func TestMeetingController_UpdateMeeting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMeetingService(ctrl)
+
+	r := gin.Default()
+	meetingController := NewMeetingController(mockService)
+	r.PATCH("/team/:teamID/meetings/:meetingID", meetingController.UpdateMeeting)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	venue := "Room 2"
+
+	// Test case 1: Change the venue
+	mockService.EXPECT().UpdateMeeting(gomock.Any(), uint(1), uint(1), models.MeetingUpdate{Venue: &venue}).Return(models.Meeting{Venue: venue}, nil)
+	w := sendRequest("/team/1/meetings/1", `{"venue": "Room 2"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 2: Change not allowed
+	mockService.EXPECT().UpdateMeeting(gomock.Any(), uint(1), uint(1), models.MeetingUpdate{Venue: &venue}).Return(models.Meeting{}, errors.New("only the title and description can be changed after the meeting has started"))
+	w = sendRequest("/team/1/meetings/1", `{"venue": "Room 2"}`)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// Test case 3: Invalid body
+	w = sendRequest("/team/1/meetings/1", `{"venue": 2}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// test OverrideAttendance
+func TestMeetingController_OverrideAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMeetingService(ctrl)
+
+	r := gin.Default()
+	meetingController := NewMeetingController(mockService)
+
+	// Set the acting admin in the context
+	r.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{Model: gorm.Model{ID: 9}})
+	})
+	r.PUT("/team/:teamID/meetings/:meetingID/attendance/:userID", meetingController.OverrideAttendance)
+	r.DELETE("/team/:teamID/meetings/:meetingID/attendance/:userID", meetingController.RemoveAttendance)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test case 1: Mark late
+	mockService.EXPECT().OverrideAttendance(models.AuditActor{UserID: 9}, uint(2), uint(1), uint(1), false, "phone died", gomock.Any()).Return(models.MeetingAttendance{UserID: 2, MeetingID: 1}, nil)
+	w := sendRequest("PUT", "/team/1/meetings/1/attendance/2", `{"onTime": false, "reason": "phone died"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 2: Missing reason
+	w = sendRequest("PUT", "/team/1/meetings/1/attendance/2", `{"onTime": true}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 3: Invalid user ID
+	w = sendRequest("PUT", "/team/1/meetings/1/attendance/jj", `{"onTime": true, "reason": "phone died"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Test case 4: Remove record
+	mockService.EXPECT().RemoveAttendance(models.AuditActor{UserID: 9}, uint(2), uint(1), uint(1), "marked by proxy", gomock.Any()).Return(nil)
+	w = sendRequest("DELETE", "/team/1/meetings/1/attendance/2", `{"reason": "marked by proxy"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 5: Remove missing record
+	mockService.EXPECT().RemoveAttendance(models.AuditActor{UserID: 9}, uint(3), uint(1), uint(1), "marked by proxy", gomock.Any()).Return(errors.New("attendance record not found"))
+	w = sendRequest("DELETE", "/team/1/meetings/1/attendance/3", `{"reason": "marked by proxy"}`)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
 // test GetUserAttendanceRecords
 func TestMeetingController_GetUserAttendanceRecords(t *testing.T) {
 	ctrl := gomock.NewController(t)
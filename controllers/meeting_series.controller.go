@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/services"
+	"github.com/gin-gonic/gin"
+)
+
+// MeetingSeriesController handles recurring meeting routes.
+type MeetingSeriesController struct {
+	meetingSeriesService services.MeetingSeriesServiceInterface
+}
+
+// NewMeetingSeriesController creates a new MeetingSeriesController.
+func NewMeetingSeriesController(meetingSeriesService services.MeetingSeriesServiceInterface) *MeetingSeriesController {
+	return &MeetingSeriesController{meetingSeriesService}
+}
+
+// get seriesID and teamID from route params
+func getTeamAndSeriesFromQueryParams(c *gin.Context) (uint, uint, error) {
+	seriesID, err := strconv.ParseUint(c.Param("seriesID"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint(seriesID), uint(teamID), nil
+}
+
+// CreateMeetingSeries creates a new recurring meeting.
+func (msc *MeetingSeriesController) CreateMeetingSeries(c *gin.Context) {
+	// Get team ID from route parameters
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var newSeries struct {
+		Title            string                  `json:"title" binding:"required"`
+		Description      string                  `json:"description" binding:"required"`
+		Venue            string                  `json:"venue" binding:"required"`
+		Location         models.Location         `json:"location" binding:"required"`
+		Geofence         models.Geofence         `json:"geofence"`
+		Schedule         models.MeetingSchedule  `json:"schedule"`
+		AttendancePolicy models.AttendancePolicy `json:"attendancePolicy"`
+		StartTime        time.Time               `json:"startTime" binding:"required"`
+		RRule            string                  `json:"rrule" binding:"required"`
+		ExDates          []string                `json:"exDates"`
+	}
+
+	// Bind request body to series structure
+	if err := c.ShouldBindJSON(&newSeries); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	series := models.MeetingSeries{
+		TeamID:           uint(teamID),
+		Title:            newSeries.Title,
+		Description:      newSeries.Description,
+		Venue:            newSeries.Venue,
+		Location:         newSeries.Location,
+		Geofence:         newSeries.Geofence,
+		Schedule:         newSeries.Schedule,
+		AttendancePolicy: newSeries.AttendancePolicy,
+		StartTime:        newSeries.StartTime,
+		RRule:            newSeries.RRule,
+		ExDates:          strings.Join(newSeries.ExDates, ","),
+	}
+
+	// Call the meeting series service to create the series
+	createdSeries, err := msc.meetingSeriesService.CreateMeetingSeries(auditActor(c), series, time.Now())
+	if err != nil {
+		logger.Errorf("Failed to create meeting series: " + err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to create the meeting series", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdSeries)
+}
+
+// GetMeetingSeriesByTeamID retrieves all recurring meetings of a team.
+func (msc *MeetingSeriesController) GetMeetingSeriesByTeamID(c *gin.Context) {
+	// Get team ID from route parameters
+	teamID, err := strconv.ParseUint(c.Param("teamID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	series, err := msc.meetingSeriesService.GetMeetingSeriesByTeamID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to get the meeting series", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetMeetingSeriesDetails retrieves a recurring meeting by its ID.
+func (msc *MeetingSeriesController) GetMeetingSeriesDetails(c *gin.Context) {
+	seriesID, teamID, err := getTeamAndSeriesFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid series or team ID", "error": err.Error()})
+		return
+	}
+
+	series, err := msc.meetingSeriesService.GetMeetingSeriesByID(seriesID, teamID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Meeting series not found", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// UpdateOccurrence edits a meeting of a series. Query ?scope=this (default) edits only this meeting, ?scope=following edits it and all after it.
+func (msc *MeetingSeriesController) UpdateOccurrence(c *gin.Context) {
+	seriesID, teamID, err := getTeamAndSeriesFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid series or team ID", "error": err.Error()})
+		return
+	}
+	meetingID, err := strconv.ParseUint(c.Param("meetingID"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid meeting ID", "error": err.Error()})
+		return
+	}
+
+	var update models.MeetingUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body", "error": err.Error()})
+		return
+	}
+
+	switch c.DefaultQuery("scope", models.SeriesEditThis) {
+	case models.SeriesEditThis:
+		updatedMeeting, err := msc.meetingSeriesService.UpdateOccurrence(auditActor(c), seriesID, uint(meetingID), teamID, update)
+		if err != nil {
+			logger.Errorf("Failed to update meeting of series: " + err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to update the meeting", "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, updatedMeeting)
+	case models.SeriesEditFollowing:
+		createdSeries, err := msc.meetingSeriesService.UpdateFollowingOccurrences(auditActor(c), seriesID, uint(meetingID), teamID, update, time.Now())
+		if err != nil {
+			logger.Errorf("Failed to update following meetings of series: " + err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to update the following meetings", "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, createdSeries)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scope, must be this or following"})
+	}
+}
+
+// DeleteMeetingSeries deletes a recurring meeting along with its meetings that have not started.
+func (msc *MeetingSeriesController) DeleteMeetingSeries(c *gin.Context) {
+	seriesID, teamID, err := getTeamAndSeriesFromQueryParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid series or team ID", "error": err.Error()})
+		return
+	}
+
+	err = msc.meetingSeriesService.DeleteMeetingSeries(auditActor(c), seriesID, teamID, time.Now())
+	if err != nil {
+		logger.Warnf("Failed to delete meeting series (series: %d, team:%d): "+err.Error(), seriesID, teamID)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to delete the meeting series", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Meeting series deleted successfully"})
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// test CreateMeetingSeries
+func TestMeetingSeriesController_CreateMeetingSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMeetingSeriesService(ctrl)
+
+	r := gin.Default()
+	meetingSeriesController := NewMeetingSeriesController(mockService)
+	r.POST("/team/:teamID/meeting-series", meetingSeriesController.CreateMeetingSeries)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/team/1/meeting-series", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Test case 1: Tuesdays and Thursdays, skipping one date
+	mockService.EXPECT().CreateMeetingSeries(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ models.AuditActor, series models.MeetingSeries, _ interface{}) (models.MeetingSeries, error) {
+		assert.Equal(t, uint(1), series.TeamID)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=TU,TH", series.RRule)
+		assert.Equal(t, "2030-01-03,2030-01-08", series.ExDates)
+		return series, nil
+	})
+	w := sendRequest(`{"title": "Sync", "description": "Weekly sync", "venue": "Room 1", "location": {"Latitude": 1, "Longitude": 1}, "startTime": "2030-01-01T18:00:00Z", "rrule": "FREQ=WEEKLY;BYDAY=TU,TH", "exDates": ["2030-01-03", "2030-01-08"]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Test case 2: Missing rule
+	w = sendRequest(`{"title": "Sync", "description": "Weekly sync", "venue": "Room 1", "location": {"Latitude": 1, "Longitude": 1}, "startTime": "2030-01-01T18:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// test UpdateOccurrence
+func TestMeetingSeriesController_UpdateOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMeetingSeriesService(ctrl)
+
+	r := gin.Default()
+	meetingSeriesController := NewMeetingSeriesController(mockService)
+	r.PATCH("/team/:teamID/meeting-series/:seriesID/meetings/:meetingID", meetingSeriesController.UpdateOccurrence)
+
+	// Helper function to send a request and check the response
+	sendRequest := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	venue := "Room 2"
+	update := models.MeetingUpdate{Venue: &venue}
+
+	// Test case 1: Only this meeting by default
+	mockService.EXPECT().UpdateOccurrence(gomock.Any(), uint(7), uint(3), uint(1), update).Return(models.Meeting{Venue: venue}, nil)
+	w := sendRequest("/team/1/meeting-series/7/meetings/3", `{"venue": "Room 2"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 2: This and following meetings
+	mockService.EXPECT().UpdateFollowingOccurrences(gomock.Any(), uint(7), uint(3), uint(1), update, gomock.Any()).Return(models.MeetingSeries{Venue: venue}, nil)
+	w = sendRequest("/team/1/meeting-series/7/meetings/3?scope=following", `{"venue": "Room 2"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Test case 3: Invalid scope
+	w = sendRequest("/team/1/meeting-series/7/meetings/3?scope=all", `{"venue": "Room 2"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
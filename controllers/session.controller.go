@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/token"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxDeviceLength is the most of the user agent kept as the device of a session.
+const maxDeviceLength = 255
+
+// requestDevice returns the device of the request, its user agent.
+func requestDevice(c *gin.Context) string {
+	device := c.Request.UserAgent()
+	if len(device) > maxDeviceLength {
+		device = device[:maxDeviceLength]
+	}
+	return device
+}
+
+// startSession logs the user in on the device of the request, responding with the tokens of a new session and the user.
+func (uc *UserController) startSession(c *gin.Context, user models.User) {
+	refreshToken, err := token.GenerateRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
+		logger.Errorf("Failed to generate refresh token: " + err.Error())
+		return
+	}
+
+	now := time.Now()
+	session, err := uc.sessionRepo.CreateSession(models.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: token.HashRefreshToken(refreshToken),
+		Device:           requestDevice(c),
+		IP:               c.ClientIP(),
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(token.RefreshTokenLifespan()),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
+		logger.Errorf("Failed to create session: " + err.Error())
+		return
+	}
+
+	accessToken, err := token.GenerateToken(user, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":        accessToken,
+		"refreshToken": refreshToken,
+		"expiresIn":    int(token.AccessTokenLifespan().Seconds()),
+		"user":         user,
+	})
+}
+
+// RefreshSession exchanges a refresh token for a new access token and a new refresh token, the old one cannot be used again.
+// Using a refresh token a second time logs out its session, as it may have been stolen.
+func (uc *UserController) RefreshSession(c *gin.Context) {
+	var refreshData struct {
+		RefreshToken string `json:"refreshToken" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&refreshData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	newRefreshToken, err := token.GenerateRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session."})
+		logger.Errorf("Failed to generate refresh token: " + err.Error())
+		return
+	}
+
+	now := time.Now()
+	session, err := uc.sessionRepo.RotateRefreshToken(token.HashRefreshToken(refreshData.RefreshToken), token.HashRefreshToken(newRefreshToken), requestDevice(c), c.ClientIP(), now, now.Add(token.RefreshTokenLifespan()))
+	if errors.Is(err, repository.ErrSessionEnded) || errors.Is(err, repository.ErrRefreshTokenReused) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "session-ended", "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session."})
+		logger.Errorf("Failed to rotate refresh token: " + err.Error())
+		return
+	}
+
+	user, err := uc.userRepo.GetUserByID(session.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "session-ended", "message": "Please log in again."})
+		return
+	}
+
+	accessToken, err := token.GenerateToken(user, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh session."})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SessionTokens{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int(token.AccessTokenLifespan().Seconds()),
+	})
+}
+
+// Logout ends the session of the request. Its access and refresh tokens stop working right away.
+func (uc *UserController) Logout(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	if err := uc.sessionRepo.RevokeSession(currentUser.ID, c.GetUint("sessionID"), time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out."})
+		logger.Errorf("Failed to revoke session: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out."})
+}
+
+// LogoutAll ends every session of the current user, logging them out of all devices including this one.
+func (uc *UserController) LogoutAll(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	if err := uc.sessionRepo.RevokeUserSessions(currentUser.ID, 0, time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out."})
+		logger.Errorf("Failed to revoke sessions: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out of all devices."})
+}
+
+// GetMySessions retrieves the active sessions of the current user, with their device, IP and when they were last seen.
+// Sessions are seen on login and on each refresh.
+func (uc *UserController) GetMySessions(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	sessions, err := uc.sessionRepo.GetActiveSessionsByUserID(currentUser.ID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve sessions"})
+		return
+	}
+
+	currentSessionID := c.GetUint("sessionID")
+	sessionResponses := make([]models.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = models.SessionResponse{Session: session, Current: session.ID == currentSessionID}
+	}
+
+	c.JSON(http.StatusOK, sessionResponses)
+}
+
+// RevokeMySession ends one of the current user's sessions, logging that device out.
+func (uc *UserController) RevokeMySession(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	sessionID, err := strconv.Atoi(c.Param("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	err = uc.sessionRepo.RevokeSession(currentUser.ID, uint(sessionID), time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out session."})
+		logger.Errorf("Failed to revoke session: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session logged out."})
+}
+
+// GetJWKS publishes the public keys access tokens are signed with, so other services can verify them.
+func (uc *UserController) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, token.JWKS())
+}
@@ -1,15 +1,21 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"net/mail"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/services"
 	"github.com/GDGVIT/attendance-app-backend/utils/email"
 	"github.com/GDGVIT/attendance-app-backend/utils/team"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type TeamController struct {
@@ -17,14 +23,53 @@ type TeamController struct {
 	teamMemberRepo       *repository.TeamMemberRepository
 	userRepo             *repository.UserRepository
 	teamEntryRequestRepo *repository.TeamEntryRequestRepository
+	teamInviteRepo       *repository.TeamInviteRepository
+	teamEmailInviteRepo  *repository.TeamEmailInviteRepository
+	teamJoinRuleRepo     *repository.TeamJoinRuleRepository
+	teamRoleRepo         *repository.TeamRoleRepository
+	auditLogService      services.AuditLogServiceInterface
+	twoFactorRepo        *repository.TwoFactorRepository
 }
 
+// maxEmailInvites is the most email addresses that can be invited to a team at once.
+const maxEmailInvites = 100
+
+// maxBulkReviewRequests is the most request IDs that can be reviewed at once.
+const maxBulkReviewRequests = 500
+
+// maxRosterFileSize is the largest roster that can be uploaded for a join rule, in bytes.
+const maxRosterFileSize = 1 << 20
+
 func NewTeamController() *TeamController {
 	teamRepo := repository.NewTeamRepository()
 	teamMemberRepo := repository.NewTeamMemberRepository()
 	userRepo := repository.NewUserRepository()
 	teamEntryRequestRepo := repository.NewTeamEntryRequestRepository()
-	return &TeamController{teamRepo, teamMemberRepo, userRepo, teamEntryRequestRepo}
+	teamInviteRepo := repository.NewTeamInviteRepository()
+	teamEmailInviteRepo := repository.NewTeamEmailInviteRepository()
+	teamJoinRuleRepo := repository.NewTeamJoinRuleRepository()
+	teamRoleRepo := repository.NewTeamRoleRepository()
+	auditLogService := services.NewAuditLogService(repository.NewAuditLogRepository())
+	twoFactorRepo := repository.NewTwoFactorRepository()
+	return &TeamController{teamRepo, teamMemberRepo, userRepo, teamEntryRequestRepo, teamInviteRepo, teamEmailInviteRepo, teamJoinRuleRepo, teamRoleRepo, auditLogService, twoFactorRepo}
+}
+
+// getTeamByInviteCode finds the team of an invite code, either the team's permanent Invite or one of its invite links.
+// The link is nil for the permanent Invite.
+func (tc *TeamController) getTeamByInviteCode(inviteCode string) (models.Team, *models.TeamInviteLink, error) {
+	team, err := tc.teamRepo.GetTeamByInvite(inviteCode)
+	if err == nil {
+		return team, nil, nil
+	}
+	link, err := tc.teamInviteRepo.GetInviteLinkByCode(inviteCode)
+	if err != nil {
+		return models.Team{}, nil, err
+	}
+	team, err = tc.teamRepo.GetTeamByID(link.TeamID)
+	if err != nil {
+		return models.Team{}, nil, err
+	}
+	return team, &link, nil
 }
 
 // --- Can be done by any logged in user ---
@@ -95,6 +140,8 @@ func (tc *TeamController) CreateTeam(c *gin.Context) {
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), createdTeam.ID, models.AuditTeamCreate, models.AuditTargetTeam, createdTeam.ID, nil, createdTeam)
+
 	c.JSON(http.StatusCreated, createdTeam)
 }
 
@@ -103,11 +150,17 @@ func (tc *TeamController) CreateTeam(c *gin.Context) {
 func (tc *TeamController) GetTeamByInviteCode(c *gin.Context) {
 	inviteCode := c.Param("inviteCode")
 
-	team, err := tc.teamRepo.GetTeamByInvite(inviteCode)
+	team, link, err := tc.getTeamByInviteCode(inviteCode)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Invalid invite."})
 		return
 	}
+	if link != nil {
+		if err := link.Usable(time.Now()); err != nil {
+			c.JSON(http.StatusGone, gin.H{"error": "Invalid invite.", "message": err.Error()})
+			return
+		}
+	}
 
 	superAdmin, err := tc.userRepo.GetUserByID(team.SuperAdminID)
 	if err != nil {
@@ -116,13 +169,23 @@ func (tc *TeamController) GetTeamByInviteCode(c *gin.Context) {
 		return
 	}
 
+	// role given on joining, and whether joining a protected team needs approval
+	role, protected := models.MemberRole, team.Protected
+	if link != nil {
+		role, protected = link.Role, team.Protected && !link.BypassApproval
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"team":       team,
 		"superAdmin": superAdmin,
+		"role":       role,
+		"protected":  protected,
 	})
 }
 
 // JoinTeamByInviteCode joins a team by invite code. If unprotected, the user is added as a member. If protected, the user is added as a pending member via TeamRequests.
+// The code can also be one of the team's invite links, which gives the link's role and can skip the approval of protected teams.
+// Requests of users whose verified email matches a join rule of the team are approved right away, recording the rule.
 func (tc *TeamController) JoinTeamByInviteCode(c *gin.Context) {
 	// Get the current user
 	currentUser, _ := c.Get("user")
@@ -134,11 +197,27 @@ func (tc *TeamController) JoinTeamByInviteCode(c *gin.Context) {
 
 	// Get the team by invite code
 	inviteCode := c.Param("inviteCode")
-	team, err := tc.teamRepo.GetTeamByInvite(inviteCode)
+	team, link, err := tc.getTeamByInviteCode(inviteCode)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Invalid invite."})
 		return
 	}
+	now := time.Now()
+	if link != nil {
+		if err := link.Usable(now); err != nil {
+			c.JSON(http.StatusGone, gin.H{"error": "Invalid invite.", "message": err.Error()})
+			return
+		}
+	}
+	role, protected := models.MemberRole, team.Protected
+	if link != nil {
+		role, protected = link.Role, team.Protected && !link.BypassApproval
+	}
+
+	if team.Archived() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "team-archived", "message": "This team has been archived and cannot be joined."})
+		return
+	}
 
 	// Check if the user is already a member of the team
 	teamMember, err := tc.teamMemberRepo.GetTeamMemberByID(team.ID, user.ID)
@@ -148,7 +227,7 @@ func (tc *TeamController) JoinTeamByInviteCode(c *gin.Context) {
 	}
 
 	// Check if the team is protected
-	if team.Protected {
+	if protected {
 		// Check if the user has already requested to join the team
 		existingTeamRequest, err := tc.teamEntryRequestRepo.GetTeamEntryRequestByTeamIDAndUserID(team.ID, user.ID)
 		if err == nil {
@@ -169,14 +248,57 @@ func (tc *TeamController) JoinTeamByInviteCode(c *gin.Context) {
 		teamRequest := models.TeamEntryRequest{
 			TeamID: team.ID,
 			UserID: user.ID,
+			Role:   role,
 		}
 
-		_, err = tc.teamEntryRequestRepo.CreateTeamEntryRequest(teamRequest)
+		// Approve the request right away if the verified email of the user matches a join rule of the team
+		if user.Verified {
+			rule, err := tc.teamJoinRuleRepo.GetMatchingJoinRule(team.ID, user.Email)
+			if err == nil {
+				teamRequest.JoinRuleID = rule.ID
+				teamRequest.Status = models.TeamEntryRequestApproved
+				if link != nil {
+					teamMember := models.TeamMember{TeamID: team.ID, UserID: user.ID, Role: role}
+					err = tc.teamInviteRepo.RedeemInviteLink(*link, user.ID, now, &teamRequest, &teamMember)
+				} else {
+					teamRequest, err = tc.teamEntryRequestRepo.CreateApprovedTeamEntryRequest(teamRequest)
+				}
+				if errors.Is(err, repository.ErrInviteLinkUnusable) {
+					c.JSON(http.StatusGone, gin.H{"error": "Invalid invite.", "message": err.Error()})
+					return
+				}
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create team member"})
+					logger.Errorf("Failed to create auto approved team member: " + err.Error())
+					return
+				}
+				tc.auditLogService.Record(auditActor(c), team.ID, models.AuditMemberJoin, models.AuditTargetMember, user.ID, nil, teamRequest)
+
+				c.JSON(http.StatusCreated, gin.H{"message": "Team member created", "protected": true, "autoApproved": true, "joinRule": rule.Type})
+				return
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check join rules"})
+				logger.Errorf("Failed to check join rules: " + err.Error())
+				return
+			}
+		}
+
+		if link != nil {
+			err = tc.teamInviteRepo.RedeemInviteLink(*link, user.ID, now, &teamRequest)
+		} else {
+			teamRequest, err = tc.teamEntryRequestRepo.CreateTeamEntryRequest(teamRequest)
+		}
+		if errors.Is(err, repository.ErrInviteLinkUnusable) {
+			c.JSON(http.StatusGone, gin.H{"error": "Invalid invite.", "message": err.Error()})
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create team request"})
 			logger.Errorf("Failed to create team request: " + err.Error())
 			return
 		}
+		tc.auditLogService.Record(auditActor(c), team.ID, models.AuditRequestCreate, models.AuditTargetRequest, teamRequest.ID, nil, teamRequest)
 
 		adminTeam, err := tc.teamMemberRepo.GetAdminTeamByTeamID(team.ID)
 		// list of admin team emails
@@ -207,16 +329,26 @@ func (tc *TeamController) JoinTeamByInviteCode(c *gin.Context) {
 	teamMember = models.TeamMember{
 		TeamID: team.ID,
 		UserID: user.ID,
-		Role:   models.MemberRole,
+		Role:   role,
 	}
 
-	_, err = tc.teamMemberRepo.CreateTeamMember(teamMember)
+	if link != nil {
+		err = tc.teamInviteRepo.RedeemInviteLink(*link, user.ID, now, &teamMember)
+	} else {
+		_, err = tc.teamMemberRepo.CreateTeamMember(teamMember)
+	}
+	if errors.Is(err, repository.ErrInviteLinkUnusable) {
+		c.JSON(http.StatusGone, gin.H{"error": "Invalid invite.", "message": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create team member"})
 		logger.Errorf("Failed to create team member: " + err.Error())
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), team.ID, models.AuditMemberJoin, models.AuditTargetMember, user.ID, nil, teamMember)
+
 	c.JSON(http.StatusCreated, gin.H{"message": "Team member created", "protected": false})
 }
 
@@ -268,6 +400,8 @@ func (tc *TeamController) LeaveTeam(c *gin.Context) {
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), team.ID, models.AuditMemberLeave, models.AuditTargetMember, user.ID, teamMember, nil)
+
 	// Email the user
 	// email.SendLeaveTeamNotifToUser(user.Email, user.Name, team.Name)
 
@@ -324,7 +458,42 @@ func (tc *TeamController) GetCurrentUserRoleInTeam(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"role": teamMember.Role})
+	permissions, err := tc.teamRoleRepo.GetPermissions(teamMember.TeamID, teamMember.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve role"})
+		logger.Errorf("Failed to retrieve role: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"role": teamMember.Role, "permissions": permissions})
+}
+
+// GetTeamRoles retrieves every permission, the permissions of the built in roles and the roles defined by the team.
+// Roles defined by the team also have the permissions of members.
+func (tc *TeamController) GetTeamRoles(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	roles, err := tc.teamRoleRepo.GetTeamRolesByTeamID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve roles"})
+		logger.Errorf("Failed to retrieve roles: " + err.Error())
+		return
+	}
+
+	builtIn := make(map[string][]string)
+	for _, role := range []string{models.SuperAdminRole, models.AdminRole, models.MemberRole} {
+		builtIn[role], _ = models.BuiltInRolePermissions(role)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"permissions":  models.AllPermissions,
+		"builtInRoles": builtIn,
+		"roles":        roles,
+	})
 }
 
 // GetTeamMembers retrieves all team members for a given team.
@@ -508,10 +677,15 @@ func (tc *TeamController) UpdateTeamRequestStatus(c *gin.Context) {
 
 	// If the request was accepted, add the user as a member of the team
 	if requestUpdateRequest.Status == models.TeamEntryRequestApproved {
+		// requests made with an invite link get the role of the link
+		role := request.Role
+		if role == "" {
+			role = models.MemberRole
+		}
 		teamMember := models.TeamMember{
 			TeamID: request.TeamID,
 			UserID: request.UserID,
-			Role:   models.MemberRole,
+			Role:   role,
 		}
 
 		_, err := tc.teamMemberRepo.CreateTeamMember(teamMember)
@@ -522,6 +696,8 @@ func (tc *TeamController) UpdateTeamRequestStatus(c *gin.Context) {
 		}
 	}
 
+	tc.auditLogService.Record(auditActor(c), team.ID, models.AuditRequestReview, models.AuditTargetRequest, request.ID, request, updatedRequest)
+
 	// Get the user
 	user, err := tc.userRepo.GetUserByID(request.UserID)
 	if err != nil {
@@ -535,6 +711,383 @@ func (tc *TeamController) UpdateTeamRequestStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, updatedRequest)
 }
 
+// ReviewTeamRequests approves or rejects many requests of a team at once, either a list of request IDs or all pending requests.
+// The requests are updated together or not at all, with the outcome of each in the response, and each updated user is emailed.
+func (tc *TeamController) ReviewTeamRequests(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var reviewRequest struct {
+		RequestIDs []uint `json:"requestIds"`
+		AllPending bool   `json:"allPending"`
+		Status     string `json:"status" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reviewRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Invalid input."})
+		return
+	}
+	if reviewRequest.Status != models.TeamEntryRequestApproved && reviewRequest.Status != models.TeamEntryRequestRejected {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status", "message": "You can set the status to approved or rejected."})
+		return
+	}
+	if reviewRequest.AllPending == (len(reviewRequest.RequestIDs) > 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Give either requestIds or allPending"})
+		return
+	}
+	if len(reviewRequest.RequestIDs) > maxBulkReviewRequests {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At most " + strconv.Itoa(maxBulkReviewRequests) + " requests can be reviewed at once"})
+		return
+	}
+
+	team, err := tc.teamRepo.GetTeamByID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	results, err := tc.teamEntryRequestRepo.ReviewTeamEntryRequests(team.ID, reviewRequest.RequestIDs, reviewRequest.AllPending, reviewRequest.Status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update requests, none were updated"})
+		logger.Errorf("Failed to review requests of team %d: %v", team.ID, err)
+		return
+	}
+
+	// Email the users whose request was updated
+	actor := auditActor(c)
+	for _, result := range results {
+		if !result.Updated {
+			continue
+		}
+		tc.auditLogService.Record(actor, team.ID, models.AuditRequestReview, models.AuditTargetRequest, result.RequestID, nil, result)
+		user, err := tc.userRepo.GetUserByID(result.UserID)
+		if err != nil {
+			logger.Errorf("Failed to get user %d to notify of request %d: %v", result.UserID, result.RequestID, err)
+			continue
+		}
+		email.SendRequestStatusNotifToUser(user.Email, user.Name, team.Name, result.Status)
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// ListInviteLinks retrieves the invite links of a team, including revoked and expired ones.
+func (tc *TeamController) ListInviteLinks(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	links, err := tc.teamInviteRepo.GetInviteLinksByTeamID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invite links"})
+		logger.Errorf("Failed to retrieve invite links: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// CreateInviteLink creates an invite link for a team. Only the super admin can create links that make admins.
+func (tc *TeamController) CreateInviteLink(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var linkRequest struct {
+		ExpiresAt      *time.Time `json:"expiresAt"`
+		MaxUses        uint       `json:"maxUses"`
+		Role           string     `json:"role"`
+		BypassApproval bool       `json:"bypassApproval"`
+	}
+	if err := c.ShouldBindJSON(&linkRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Invalid input."})
+		return
+	}
+	if linkRequest.ExpiresAt != nil && !linkRequest.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Expiry must be in the future"})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+
+	if linkRequest.Role == models.AdminRole {
+		teamMember, err := tc.teamMemberRepo.GetTeamMemberByID(uint(teamID), user.ID)
+		if err != nil || teamMember.Role != models.SuperAdminRole {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the super admin can create invite links for admins"})
+			return
+		}
+	}
+
+	link, err := tc.teamInviteRepo.CreateInviteLink(models.TeamInviteLink{
+		TeamID:         uint(teamID),
+		CreatedByID:    user.ID,
+		ExpiresAt:      linkRequest.ExpiresAt,
+		MaxUses:        linkRequest.MaxUses,
+		Role:           linkRequest.Role,
+		BypassApproval: linkRequest.BypassApproval,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Failed to create invite link"})
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), link.TeamID, models.AuditInviteLinkCreate, models.AuditTargetInviteLink, link.ID, nil, link)
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// RevokeInviteLink stops an invite link of a team from being used.
+func (tc *TeamController) RevokeInviteLink(c *gin.Context) {
+	link, ok := tc.getTeamInviteLink(c)
+	if !ok {
+		return
+	}
+
+	revokedLink, err := tc.teamInviteRepo.RevokeInviteLink(link.ID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invite link"})
+		logger.Errorf("Failed to revoke invite link: " + err.Error())
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), link.TeamID, models.AuditInviteLinkRevoke, models.AuditTargetInviteLink, link.ID, link, revokedLink)
+
+	c.JSON(http.StatusOK, revokedLink)
+}
+
+// GetInviteLinkUses retrieves who used an invite link of a team, and whether they joined or requested to join.
+func (tc *TeamController) GetInviteLinkUses(c *gin.Context) {
+	link, ok := tc.getTeamInviteLink(c)
+	if !ok {
+		return
+	}
+
+	uses, err := tc.teamInviteRepo.GetInviteUsesByInviteLinkID(link.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invite link uses"})
+		logger.Errorf("Failed to retrieve invite link uses: " + err.Error())
+		return
+	}
+
+	usesWithUsers := make([]models.TeamInviteUseResponse, 0, len(uses))
+	for _, use := range uses {
+		user, err := tc.userRepo.GetUserByID(use.UserID)
+		if err != nil {
+			// account deleted since
+			continue
+		}
+		usesWithUsers = append(usesWithUsers, models.TeamInviteUseResponse{Use: use, User: user})
+	}
+
+	c.JSON(http.StatusOK, usesWithUsers)
+}
+
+// getTeamInviteLink gets the invite link of the inviteID route parameter, responding with an error if it is not a link of the teamID team.
+func (tc *TeamController) getTeamInviteLink(c *gin.Context) (models.TeamInviteLink, bool) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return models.TeamInviteLink{}, false
+	}
+	inviteID, err := strconv.Atoi(c.Param("inviteID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invite ID"})
+		return models.TeamInviteLink{}, false
+	}
+
+	link, err := tc.teamInviteRepo.GetInviteLinkByID(uint(inviteID))
+	if err != nil || link.TeamID != uint(teamID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invite link not found"})
+		return models.TeamInviteLink{}, false
+	}
+	return link, true
+}
+
+// InviteByEmail invites a list of email addresses to a team. Users with the email get a pending invitation to accept or decline,
+// other emails are sent a link to sign up, and join the team once verified. Only the super admin can invite admins.
+func (tc *TeamController) InviteByEmail(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var inviteRequest struct {
+		Emails []string `json:"emails" binding:"required"`
+		Role   string   `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&inviteRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Invalid input."})
+		return
+	}
+	if len(inviteRequest.Emails) == 0 || len(inviteRequest.Emails) > maxEmailInvites {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Between 1 and " + strconv.Itoa(maxEmailInvites) + " emails can be invited at once"})
+		return
+	}
+	if inviteRequest.Role == "" {
+		inviteRequest.Role = models.MemberRole
+	}
+	if inviteRequest.Role != models.MemberRole && inviteRequest.Role != models.AdminRole {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be member or admin"})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+
+	if inviteRequest.Role == models.AdminRole {
+		teamMember, err := tc.teamMemberRepo.GetTeamMemberByID(uint(teamID), user.ID)
+		if err != nil || teamMember.Role != models.SuperAdminRole {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the super admin can invite admins"})
+			return
+		}
+	}
+
+	team, err := tc.teamRepo.GetTeamByID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	results := make([]models.TeamEmailInviteResult, 0, len(inviteRequest.Emails))
+	seen := make(map[string]bool)
+	for _, address := range inviteRequest.Emails {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		result := models.TeamEmailInviteResult{Email: address}
+		if parsed, err := mail.ParseAddress(address); err != nil || parsed.Address != address {
+			result.Status = "invalid"
+			results = append(results, result)
+			continue
+		}
+
+		invitedUser, userErr := tc.userRepo.GetUserByEmail(address)
+		if userErr == nil {
+			if _, err := tc.teamMemberRepo.GetTeamMemberByID(team.ID, invitedUser.ID); err == nil {
+				result.Status = "already_member"
+				results = append(results, result)
+				continue
+			}
+		}
+		if _, err := tc.teamEmailInviteRepo.GetPendingEmailInviteByTeamIDAndEmail(team.ID, address); err == nil {
+			result.Status = "already_invited"
+			results = append(results, result)
+			continue
+		}
+
+		invite, err := tc.teamEmailInviteRepo.CreateEmailInvite(models.TeamEmailInvite{
+			TeamID:      team.ID,
+			Email:       address,
+			InvitedByID: user.ID,
+			Role:        inviteRequest.Role,
+			Status:      models.TeamEmailInvitePending,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation", "results": results})
+			logger.Errorf("Failed to create email invite: " + err.Error())
+			return
+		}
+		tc.auditLogService.Record(auditActor(c), team.ID, models.AuditEmailInviteCreate, models.AuditTargetEmailInvite, invite.ID, nil, invite)
+
+		if userErr == nil {
+			err = email.SendTeamInviteNotifToUser(address, invitedUser.Name, team.Name)
+		} else {
+			err = email.SendTeamInviteSignupMail(address, team.Name)
+		}
+		if err != nil {
+			logger.Errorf("Failed to send team invitation to %s: %v", address, err)
+		}
+
+		result.Status = "invited"
+		result.Invite = &invite
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// ListEmailInvites retrieves the email invitations of a team, with a query filter for their status.
+func (tc *TeamController) ListEmailInvites(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	invites, err := tc.teamEmailInviteRepo.GetEmailInvitesByTeamID(uint(teamID), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+		logger.Errorf("Failed to retrieve email invites: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+// RevokeEmailInvite withdraws a pending email invitation of a team.
+func (tc *TeamController) RevokeEmailInvite(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	inviteID, err := strconv.Atoi(c.Param("inviteID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invite ID"})
+		return
+	}
+
+	invite, err := tc.teamEmailInviteRepo.GetEmailInviteByID(uint(inviteID))
+	if err != nil || invite.TeamID != uint(teamID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		return
+	}
+
+	revokedInvite, err := tc.teamEmailInviteRepo.RespondToEmailInvite(invite.ID, models.TeamEmailInviteRevoked, time.Now())
+	if errors.Is(err, repository.ErrEmailInviteNotPending) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invitation"})
+		logger.Errorf("Failed to revoke email invite: " + err.Error())
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), invite.TeamID, models.AuditEmailInviteRevoke, models.AuditTargetEmailInvite, invite.ID, invite, revokedInvite)
+
+	c.JSON(http.StatusOK, revokedInvite)
+}
+
+// ListJoinRules retrieves the join rules of a team, with the number of emails in each roster.
+func (tc *TeamController) ListJoinRules(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	rules, err := tc.teamJoinRuleRepo.GetJoinRulesByTeamID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve join rules"})
+		logger.Errorf("Failed to retrieve join rules: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
 // --- Can be done by team super admin ---
 
 // HandoverTeamSuperAdmin hands over the super admin position to another team admin.
@@ -616,6 +1169,8 @@ func (tc *TeamController) HandoverTeamSuperAdmin(c *gin.Context) {
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), team.ID, models.AuditTeamHandover, models.AuditTargetTeam, team.ID, team, updatedTeam)
+
 	// Get the new super admin
 	// newSuperAdminUser, err := tc.userRepo.GetUserByID(newSuperAdmin.NewSuperAdminID)
 	// if err != nil {
@@ -661,6 +1216,12 @@ func (tc *TeamController) KickTeamMember(c *gin.Context) {
 		return
 	}
 
+	// Only the super admin can kick admins
+	if teamMember.Role == models.AdminRole && !isSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin-kick", "message": "Only the super admin can kick admins of the team."})
+		return
+	}
+
 	// Get the user being kicked
 	user, err := tc.userRepo.GetUserByID(teamMember.UserID)
 	if err != nil {
@@ -683,6 +1244,8 @@ func (tc *TeamController) KickTeamMember(c *gin.Context) {
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), team.ID, models.AuditMemberKick, models.AuditTargetMember, user.ID, teamMember, nil)
+
 	// Email the user
 	email.SendKickNotifToUser(user.Email, user.Name, team.Name)
 
@@ -712,30 +1275,77 @@ func (tc *TeamController) PromoteOrDemoteTeamMember(c *gin.Context) {
 		return
 	}
 
-	// Get the promote flag from the query params
-	promote := c.Query("promote")
+	if teamMember.Role == models.SuperAdminRole {
+		c.JSON(http.StatusForbidden, gin.H{"error": "super-admin-role", "message": "You cannot change the role of the super admin. Please handover the team super admin position instead."})
+		return
+	}
 
-	if promote != "true" && promote != "false" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid promote flag", "message": "You can set the promote flag to true or false."})
+	// the team member set by the authorization middleware
+	currentMember, _ := c.Get("teamMember")
+	actor, ok := currentMember.(*models.TeamMember)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get the current team member"})
+		return
+	}
+	if actor.UserID == teamMember.UserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "own-role", "message": "You cannot change your own role."})
 		return
 	}
 
-	// Update the team member's role
-	var updatedTeamMember models.TeamMember
-	if promote == "true" {
-		updatedTeamMember, err = tc.teamMemberRepo.UpdateTeamMemberRole(teamMember.ID, models.AdminRole)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team member"})
+	// Get the new role, a role of the team from the role query param, or admin/member from the promote flag
+	role := c.Query("role")
+	if role == "" {
+		promote := c.Query("promote")
+
+		if promote != "true" && promote != "false" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid promote flag", "message": "You can set the promote flag to true or false."})
 			return
 		}
-	} else {
-		updatedTeamMember, err = tc.teamMemberRepo.UpdateTeamMemberRole(teamMember.ID, models.MemberRole)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team member"})
+
+		role = models.MemberRole
+		if promote == "true" {
+			role = models.AdminRole
+		}
+	} else if role == models.SuperAdminRole {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role", "message": "Please handover the team super admin position instead."})
+		return
+	} else if _, ok := models.BuiltInRolePermissions(role); !ok {
+		if _, err := tc.teamRoleRepo.GetTeamRoleByName(uint(teamID), role); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role", "message": "The team has no role with that name."})
 			return
 		}
 	}
 
+	// Only the super admin can make or unmake admins
+	if (role == models.AdminRole || teamMember.Role == models.AdminRole) && !isSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin-role", "message": "Only the super admin can change who is an admin of the team."})
+		return
+	}
+
+	// Members can only give and take away roles whose permissions they have themselves
+	var permissions [3][]string
+	for i, r := range []string{actor.Role, teamMember.Role, role} {
+		if permissions[i], err = tc.teamRoleRepo.GetPermissions(uint(teamID), r); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve role"})
+			logger.Errorf("Failed to retrieve role: " + err.Error())
+			return
+		}
+	}
+	actorPermissions, currentPermissions, newPermissions := permissions[0], permissions[1], permissions[2]
+	if !models.HasPermissions(actorPermissions, currentPermissions) || !models.HasPermissions(actorPermissions, newPermissions) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "role-permissions", "message": "You can only give or take away roles whose permissions you have yourself."})
+		return
+	}
+
+	// Update the team member's role
+	updatedTeamMember, err := tc.teamMemberRepo.UpdateTeamMemberRole(teamMember.ID, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team member"})
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), teamMember.TeamID, models.AuditMemberRoleChange, models.AuditTargetMember, teamMember.UserID, teamMember, updatedTeamMember)
+
 	// Get the user being promoted/demoted
 	// user, err := tc.userRepo.GetUserByID(teamMember.UserID)
 	// if err != nil {
@@ -756,12 +1366,14 @@ func (tc *TeamController) PromoteOrDemoteTeamMember(c *gin.Context) {
 	c.JSON(http.StatusOK, updatedTeamMember)
 }
 
-// UpdateTeam updates a team's name and description.
+// UpdateTeam updates a team's name, description and attendance policy.
 func (tc *TeamController) UpdateTeam(c *gin.Context) {
 	// Bind the JSON request to a TeamUpdateRequest struct
 	var teamUpdateRequest struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		Name                  string                   `json:"name"`
+		Description           string                   `json:"description"`
+		AttendancePolicy      *models.AttendancePolicy `json:"attendancePolicy"`
+		RequireAdminTwoFactor *bool                    `json:"requireAdminTwoFactor"`
 	}
 	if err := c.ShouldBindJSON(&teamUpdateRequest); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
@@ -781,6 +1393,7 @@ func (tc *TeamController) UpdateTeam(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
 		return
 	}
+	before := team
 
 	if teamUpdateRequest.Name != "" {
 		team.Name = teamUpdateRequest.Name
@@ -788,6 +1401,29 @@ func (tc *TeamController) UpdateTeam(c *gin.Context) {
 	if teamUpdateRequest.Description != "" {
 		team.Description = teamUpdateRequest.Description
 	}
+	if teamUpdateRequest.AttendancePolicy != nil {
+		if err := teamUpdateRequest.AttendancePolicy.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		team.AttendancePolicy = *teamUpdateRequest.AttendancePolicy
+	}
+	if teamUpdateRequest.RequireAdminTwoFactor != nil {
+		if *teamUpdateRequest.RequireAdminTwoFactor && !team.RequireAdminTwoFactor {
+			// requiring it without having it would lock the admin out
+			user, _ := c.Get("user")
+			enabled, err := tc.twoFactorRepo.IsTwoFactorEnabled(user.(*models.User).ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team"})
+				return
+			}
+			if !enabled {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "two-factor-required", "message": "Enable two-factor authentication on your account first."})
+				return
+			}
+		}
+		team.RequireAdminTwoFactor = *teamUpdateRequest.RequireAdminTwoFactor
+	}
 
 	// Save the updated team
 	updatedTeam, err := tc.teamRepo.UpdateTeam(team)
@@ -796,6 +1432,8 @@ func (tc *TeamController) UpdateTeam(c *gin.Context) {
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), updatedTeam.ID, models.AuditTeamUpdate, models.AuditTargetTeam, updatedTeam.ID, before, updatedTeam)
+
 	// Respond with the updated team details
 	c.JSON(http.StatusOK, updatedTeam)
 }
@@ -817,6 +1455,7 @@ func (tc *TeamController) RegenerateInviteCode(c *gin.Context) {
 	}
 
 	// Regenerate the invite code
+	before := currteam
 	currteam.Invite = team.GenerateInviteCode()
 
 	// Save the updated team
@@ -826,6 +1465,323 @@ func (tc *TeamController) RegenerateInviteCode(c *gin.Context) {
 		return
 	}
 
+	tc.auditLogService.Record(auditActor(c), updatedTeam.ID, models.AuditTeamInviteRenew, models.AuditTargetTeam, updatedTeam.ID, before, updatedTeam)
+
 	// Respond with the updated team details
 	c.JSON(http.StatusOK, updatedTeam)
 }
+
+// DeleteTeam deletes a team with its members, meetings and attendance, and lets the members know.
+// With ?archive=true the team is archived instead: it becomes read-only, and its members keep access to past meetings and attendance.
+func (tc *TeamController) DeleteTeam(c *gin.Context) {
+	// Get the team ID from the route parameter
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	archive, err := strconv.ParseBool(c.DefaultQuery("archive", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid archive, must be true or false"})
+		return
+	}
+
+	// Get the team
+	team, err := tc.teamRepo.GetTeamByID(uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	if archive && team.Archived() {
+		c.JSON(http.StatusConflict, gin.H{"error": "team-archived", "message": "Team is already archived."})
+		return
+	}
+
+	// Collect the emails of the other members before they are deleted
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+	teamMembers, err := tc.teamMemberRepo.GetTeamMembersByTeamID(team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve team members"})
+		return
+	}
+	var memberEmails []string
+	for _, teamMember := range teamMembers {
+		if teamMember.UserID == user.ID {
+			continue
+		}
+		member, err := tc.userRepo.GetUserByID(teamMember.UserID)
+		if err != nil {
+			logger.Warnf("Failed to retrieve member %d of team %d to notify: %v", teamMember.UserID, team.ID, err)
+			continue
+		}
+		memberEmails = append(memberEmails, member.Email)
+	}
+
+	if archive {
+		archivedTeam, err := tc.teamRepo.ArchiveTeam(team.ID, time.Now())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to archive team"})
+			logger.Errorf("Failed to archive team: " + err.Error())
+			return
+		}
+		tc.auditLogService.Record(auditActor(c), team.ID, models.AuditTeamArchive, models.AuditTargetTeam, team.ID, team, archivedTeam)
+
+		if err := email.SendTeamArchivedNotifToMembers(memberEmails, team.Name); err != nil {
+			logger.Errorf("Failed to notify members of archived team %d: %v", team.ID, err)
+		}
+
+		c.JSON(http.StatusOK, archivedTeam)
+		return
+	}
+
+	if err := tc.teamRepo.DeleteTeamCascade(team.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete team"})
+		logger.Errorf("Failed to delete team: " + err.Error())
+		return
+	}
+	tc.auditLogService.Record(auditActor(c), team.ID, models.AuditTeamDelete, models.AuditTargetTeam, team.ID, team, nil)
+
+	if err := email.SendTeamDeletedNotifToMembers(memberEmails, team.Name); err != nil {
+		logger.Errorf("Failed to notify members of deleted team %d: %v", team.ID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Team deleted successfully."})
+}
+
+// CreateEmailDomainJoinRule adds a join rule to a team that auto-approves users whose verified email is at a domain.
+func (tc *TeamController) CreateEmailDomainJoinRule(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var ruleRequest struct {
+		Domain string `json:"domain" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Invalid input."})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+
+	rule, err := tc.teamJoinRuleRepo.CreateJoinRule(models.TeamJoinRule{
+		TeamID:      uint(teamID),
+		Type:        models.JoinRuleEmailDomain,
+		Domain:      ruleRequest.Domain,
+		CreatedByID: user.ID,
+	}, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Failed to create join rule"})
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), rule.TeamID, models.AuditJoinRuleCreate, models.AuditTargetJoinRule, rule.ID, nil, rule)
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// CreateRosterJoinRule adds a join rule to a team that auto-approves users whose verified email is in the roster
+// uploaded as the multipart form field "file", a CSV of emails in its first column or in a column named email.
+func (tc *TeamController) CreateRosterJoinRule(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Roster file is required", "error": err.Error()})
+		return
+	}
+	if fileHeader.Size > maxRosterFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Roster file is too large"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Failed to read roster file", "error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	roster, err := team.ParseRoster(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid roster file", "error": err.Error()})
+		return
+	}
+
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+
+	rule, err := tc.teamJoinRuleRepo.CreateJoinRule(models.TeamJoinRule{
+		TeamID:      uint(teamID),
+		Type:        models.JoinRuleRoster,
+		CreatedByID: user.ID,
+	}, roster)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create join rule"})
+		logger.Errorf("Failed to create roster join rule: " + err.Error())
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), rule.TeamID, models.AuditJoinRuleCreate, models.AuditTargetJoinRule, rule.ID, nil, rule)
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// DeleteJoinRule removes a join rule from a team. Requests it already approved are kept.
+func (tc *TeamController) DeleteJoinRule(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+	ruleID, err := strconv.Atoi(c.Param("ruleID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule ID"})
+		return
+	}
+
+	rule, err := tc.teamJoinRuleRepo.GetJoinRuleByID(uint(ruleID))
+	if err != nil || rule.TeamID != uint(teamID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Join rule not found"})
+		return
+	}
+
+	if err := tc.teamJoinRuleRepo.DeleteJoinRule(rule.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete join rule"})
+		logger.Errorf("Failed to delete join rule: " + err.Error())
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), rule.TeamID, models.AuditJoinRuleDelete, models.AuditTargetJoinRule, rule.ID, rule, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Join rule deleted successfully."})
+}
+
+// CreateTeamRole defines a role of the team with a set of permissions, given on top of those of members.
+func (tc *TeamController) CreateTeamRole(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return
+	}
+
+	var roleRequest struct {
+		Name        string   `json:"name" binding:"required"`
+		Permissions []string `json:"permissions" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&roleRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Invalid input."})
+		return
+	}
+
+	if _, err := tc.teamRoleRepo.GetTeamRoleByName(uint(teamID), roleRequest.Name); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "The team already has a role with that name"})
+		return
+	}
+
+	role, err := tc.teamRoleRepo.CreateTeamRole(models.TeamRole{
+		TeamID:      uint(teamID),
+		Name:        roleRequest.Name,
+		Permissions: roleRequest.Permissions,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Failed to create role"})
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), role.TeamID, models.AuditRoleCreate, models.AuditTargetRole, role.ID, nil, role)
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateTeamRole replaces the permissions of a role of the team. Members with the role have the new permissions right away.
+func (tc *TeamController) UpdateTeamRole(c *gin.Context) {
+	role, ok := tc.getTeamRole(c)
+	if !ok {
+		return
+	}
+
+	var roleRequest struct {
+		Permissions []string `json:"permissions" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&roleRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Invalid input."})
+		return
+	}
+
+	before := role
+	role.Permissions = roleRequest.Permissions
+	updatedRole, err := tc.teamRoleRepo.UpdateTeamRole(role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Failed to update role"})
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), role.TeamID, models.AuditRoleUpdate, models.AuditTargetRole, role.ID, before, updatedRole)
+
+	c.JSON(http.StatusOK, updatedRole)
+}
+
+// DeleteTeamRole removes a role of the team that no member has.
+func (tc *TeamController) DeleteTeamRole(c *gin.Context) {
+	role, ok := tc.getTeamRole(c)
+	if !ok {
+		return
+	}
+
+	members, err := tc.teamMemberRepo.GetTeamMembersByTeamAndRole(role.TeamID, role.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve team members"})
+		return
+	}
+	if len(members) > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "role-in-use", "message": "Members still have this role. Please give them another role first."})
+		return
+	}
+
+	if err := tc.teamRoleRepo.DeleteTeamRole(role.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete role"})
+		logger.Errorf("Failed to delete role: " + err.Error())
+		return
+	}
+
+	tc.auditLogService.Record(auditActor(c), role.TeamID, models.AuditRoleDelete, models.AuditTargetRole, role.ID, role, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully."})
+}
+
+// getTeamRole gets the role of the roleID route parameter, responding with an error if it is not a role of the teamID team.
+func (tc *TeamController) getTeamRole(c *gin.Context) (models.TeamRole, bool) {
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team ID"})
+		return models.TeamRole{}, false
+	}
+	roleID, err := strconv.Atoi(c.Param("roleID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return models.TeamRole{}, false
+	}
+
+	role, err := tc.teamRoleRepo.GetTeamRoleByID(uint(roleID))
+	if err != nil || role.TeamID != uint(teamID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return models.TeamRole{}, false
+	}
+	return role, true
+}
+
+// isSuperAdmin reports whether the team member set by the authorization middleware is the super admin of the team.
+func isSuperAdmin(c *gin.Context) bool {
+	teamMember, ok := c.Get("teamMember")
+	return ok && teamMember.(*models.TeamMember).Role == models.SuperAdminRole
+}
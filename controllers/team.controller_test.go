@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// teamControllerRouter serves handler at path as the team member of user actorID, as set by the authorization middleware.
+func teamControllerRouter(method, path string, actorID uint, handler gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Handle(method, path, func(c *gin.Context) {
+		user := &models.User{}
+		user.ID = actorID
+		c.Set("user", user)
+		var teamMember models.TeamMember
+		database.DB.First(&teamMember, "team_id = ? AND user_id = ?", c.Param("teamID"), actorID)
+		c.Set("teamMember", &teamMember)
+	}, handler)
+	return r
+}
+
+// test PromoteOrDemoteTeamMember
+func TestTeamController_PromoteOrDemoteTeamMember(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Team{}, &models.TeamMember{}, &models.TeamRole{}, &models.AuditLog{})
+	database.DB = db
+
+	db.Create(&models.Team{Name: "team", SuperAdminID: 1})
+	db.Create(&models.TeamRole{TeamID: 1, Name: "manager", Permissions: []string{models.PermissionMembersManage, models.PermissionMeetingCreate}})
+	db.Create(&models.TeamRole{TeamID: 1, Name: "organiser", Permissions: []string{models.PermissionMeetingCreate}})
+	db.Create(&models.TeamRole{TeamID: 1, Name: "moderator", Permissions: []string{models.PermissionMeetingCreate, models.PermissionMeetingDelete}})
+	// user 1 is the super admin, 2 and 3 managers, 4 a member and 5 a moderator
+	for userID, role := range map[uint]string{1: models.SuperAdminRole, 2: "manager", 3: "manager", 4: models.MemberRole, 5: "moderator"} {
+		db.Create(&models.TeamMember{TeamID: 1, UserID: userID, Role: role})
+	}
+
+	tests := []struct {
+		name     string
+		actorID  uint
+		memberID uint
+		query    string
+		want     int
+		wantRole string // role of the member after the request
+	}{
+		{"own role", 2, 2, "role=moderator", http.StatusForbidden, "manager"},
+		{"super admin role", 2, 1, "role=organiser", http.StatusForbidden, models.SuperAdminRole},
+		{"role with more permissions", 2, 4, "role=moderator", http.StatusForbidden, models.MemberRole},
+		{"member with more permissions", 2, 5, "role=member", http.StatusForbidden, "moderator"},
+		{"admin by manager", 2, 4, "promote=true", http.StatusForbidden, models.MemberRole},
+		{"unknown role", 2, 4, "role=owner", http.StatusBadRequest, models.MemberRole},
+		{"role with fewer permissions", 2, 4, "role=organiser", http.StatusOK, "organiser"},
+		{"role with the same permissions", 2, 3, "role=organiser", http.StatusOK, "organiser"},
+		{"role by super admin", 1, 5, "role=manager", http.StatusOK, "manager"},
+		{"admin by super admin", 1, 4, "promote=true", http.StatusOK, models.AdminRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := teamControllerRouter("PATCH", "/team/:teamID/members/:memberID", tt.actorID, NewTeamController().PromoteOrDemoteTeamMember)
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/team/1/members/%d?%s", tt.memberID, tt.query), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+
+			var teamMember models.TeamMember
+			db.First(&teamMember, "team_id = ? AND user_id = ?", 1, tt.memberID)
+			assert.Equal(t, tt.wantRole, teamMember.Role)
+		})
+	}
+}
+
+// test CreateTeamRole, UpdateTeamRole and DeleteTeamRole
+func TestTeamController_TeamRoles(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Team{}, &models.TeamMember{}, &models.TeamRole{}, &models.AuditLog{})
+	database.DB = db
+
+	db.Create(&models.Team{Name: "team", SuperAdminID: 1})
+	db.Create(&models.Team{Name: "other", SuperAdminID: 2})
+	db.Create(&models.TeamMember{TeamID: 1, UserID: 1, Role: models.SuperAdminRole})
+	db.Create(&models.TeamRole{TeamID: 1, Name: "organiser", Permissions: []string{models.PermissionMeetingCreate}})
+	db.Create(&models.TeamRole{TeamID: 1, Name: "moderator", Permissions: []string{models.PermissionMeetingDelete}})
+	db.Create(&models.TeamRole{TeamID: 2, Name: "organiser", Permissions: []string{models.PermissionMeetingCreate}})
+	db.Create(&models.TeamMember{TeamID: 1, UserID: 2, Role: "organiser"})
+
+	teamController := NewTeamController()
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		user := &models.User{}
+		user.ID = 1
+		c.Set("user", user)
+	})
+	r.POST("/team/:teamID/roles", teamController.CreateTeamRole)
+	r.PATCH("/team/:teamID/roles/:roleID", teamController.UpdateTeamRole)
+	r.DELETE("/team/:teamID/roles/:roleID", teamController.DeleteTeamRole)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"create", "POST", "/team/1/roles", `{"name": "treasurer", "permissions": ["reports.view"]}`, http.StatusCreated},
+		{"create taken name", "POST", "/team/1/roles", `{"name": "organiser", "permissions": ["reports.view"]}`, http.StatusConflict},
+		{"create built in name", "POST", "/team/1/roles", `{"name": "admin", "permissions": ["reports.view"]}`, http.StatusBadRequest},
+		{"create unknown permission", "POST", "/team/1/roles", `{"name": "owner", "permissions": ["team.own"]}`, http.StatusBadRequest},
+		{"update", "PATCH", "/team/1/roles/1", `{"permissions": ["meeting.create", "meeting.update"]}`, http.StatusOK},
+		{"update role of another team", "PATCH", "/team/1/roles/3", `{"permissions": ["meeting.update"]}`, http.StatusNotFound},
+		{"delete role in use", "DELETE", "/team/1/roles/1", "", http.StatusConflict},
+		{"delete", "DELETE", "/team/1/roles/2", "", http.StatusOK},
+	}
+	// reserved permissions cannot be given to roles
+	for _, permission := range []string{models.PermissionTeamDelete, models.PermissionTeamHandover, models.PermissionRolesManage, models.PermissionAuditView} {
+		tests = append(tests, []struct {
+			name   string
+			method string
+			path   string
+			body   string
+			want   int
+		}{
+			{"create with " + permission, "POST", "/team/1/roles", `{"name": "deputy", "permissions": ["` + permission + `"]}`, http.StatusBadRequest},
+			{"update with " + permission, "PATCH", "/team/1/roles/1", `{"permissions": ["` + permission + `"]}`, http.StatusBadRequest},
+		}...)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+
+	var role models.TeamRole
+	db.First(&role, 1)
+	assert.Equal(t, []string{models.PermissionMeetingCreate, models.PermissionMeetingUpdate}, role.Permissions)
+	var count int64
+	db.Model(&models.TeamRole{}).Where("name = ?", "deputy").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/auth"
+	"github.com/GDGVIT/attendance-app-backend/utils/email"
+	"github.com/GDGVIT/attendance-app-backend/utils/token"
+	"github.com/GDGVIT/attendance-app-backend/utils/totp"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// completeLogin logs in a user who proved who they are, unless they have two-factor authentication enabled,
+// in which case it responds with a challenge token to exchange with their second factor at VerifyTwoFactor.
+func (uc *UserController) completeLogin(c *gin.Context, user models.User) {
+	enabled, err := uc.twoFactorRepo.IsTwoFactorEnabled(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
+		logger.Errorf("Failed to check two-factor authentication: " + err.Error())
+		return
+	}
+	if !enabled {
+		uc.startSession(c, user)
+		return
+	}
+
+	challengeToken, err := token.GenerateChallengeToken(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in."})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"twoFactorRequired": true, "challengeToken": challengeToken})
+}
+
+// VerifyTwoFactor completes logging in with two-factor authentication, exchanging the challenge token of Login
+// and a code from the authenticator app, or a recovery code, for a session.
+func (uc *UserController) VerifyTwoFactor(c *gin.Context) {
+	var verifyData struct {
+		ChallengeToken string `json:"challengeToken" binding:"required"`
+		Code           string `json:"code"`
+		RecoveryCode   string `json:"recoveryCode"`
+	}
+	if err := c.ShouldBindJSON(&verifyData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	userID, err := token.ValidateChallengeToken(verifyData.ChallengeToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "challenge-expired", "message": "Please log in again."})
+		return
+	}
+
+	user, err := uc.userRepo.GetUserByID(userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "challenge-expired", "message": "Please log in again."})
+		return
+	}
+
+	// codes are guessed under the same throttle as passwords
+	accountThrottle := auth.AccountThrottle(user.Email)
+	ipThrottle := auth.IPThrottle(c.ClientIP())
+	if uc.throttled(c, accountThrottle, ipThrottle) {
+		return
+	}
+
+	if err := auth.CheckSecondFactor(uc.twoFactorRepo, userID, verifyData.Code, verifyData.RecoveryCode, time.Now()); err != nil {
+		if uc.recordFailure(accountThrottle, ipThrottle) {
+			uc.sendLockoutMail(user)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "two-factor-invalid", "message": "The two-factor code is not correct."})
+		return
+	}
+	uc.resetThrottles(accountThrottle)
+
+	uc.startSession(c, user)
+}
+
+// GetTwoFactorStatus tells the current user whether two-factor authentication is enabled, and how many recovery codes they have left.
+func (uc *UserController) GetTwoFactorStatus(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	enabled, err := uc.twoFactorRepo.IsTwoFactorEnabled(currentUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve two-factor authentication"})
+		return
+	}
+	recoveryCodesLeft, err := uc.twoFactorRepo.CountUnusedRecoveryCodes(currentUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"enabled": enabled, "recoveryCodesLeft": recoveryCodesLeft})
+}
+
+// EnrollTwoFactor starts enabling two-factor authentication for the current user with a new secret.
+// The otpauth URI is shown as a QR code for the authenticator app, which is confirmed with a code at ConfirmTwoFactor.
+func (uc *UserController) EnrollTwoFactor(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enroll two-factor authentication"})
+		return
+	}
+
+	_, err = uc.twoFactorRepo.SaveTwoFactorSecret(currentUser.ID, secret)
+	if errors.Is(err, repository.ErrTwoFactorEnabled) {
+		c.JSON(http.StatusConflict, gin.H{"error": "two-factor-enabled", "message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enroll two-factor authentication"})
+		logger.Errorf("Failed to save two-factor secret: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret": secret,
+		"uri":    totp.URI(secret, auth.TwoFactorIssuer(), currentUser.Email, auth.TwoFactorOptions()),
+	})
+}
+
+// ConfirmTwoFactor enables two-factor authentication once the user proves their authenticator app works with a code.
+// Responds with the recovery codes, which are not shown again.
+func (uc *UserController) ConfirmTwoFactor(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	var confirmData struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&confirmData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	twoFactor, err := uc.twoFactorRepo.GetTwoFactorByUserID(currentUser.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not-enrolling", "message": "Enroll in two-factor authentication first."})
+		return
+	}
+	if twoFactor.Enabled() {
+		c.JSON(http.StatusConflict, gin.H{"error": "two-factor-enabled", "message": repository.ErrTwoFactorEnabled.Error()})
+		return
+	}
+
+	now := time.Now()
+	if !totp.ValidateCode(confirmData.Code, twoFactor.Secret, now, auth.TwoFactorOptions()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "two-factor-invalid", "message": "The two-factor code is not correct."})
+		return
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable two-factor authentication"})
+		return
+	}
+	err = uc.twoFactorRepo.ConfirmTwoFactor(currentUser.ID, hashes, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusConflict, gin.H{"error": "two-factor-enabled", "message": repository.ErrTwoFactorEnabled.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable two-factor authentication"})
+		logger.Errorf("Failed to confirm two-factor authentication: " + err.Error())
+		return
+	}
+
+	email.GenericSendMail("Two-Factor Authentication Enabled", "Two-factor authentication has been enabled on your account. If this was not you, reset your password right away.", currentUser.Email, currentUser.Name)
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": recoveryCodes})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user, used or not, given a code from their authenticator app.
+func (uc *UserController) RegenerateRecoveryCodes(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	var regenerateData struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&regenerateData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	if err := auth.CheckSecondFactor(uc.twoFactorRepo, currentUser.ID, regenerateData.Code, "", time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "two-factor-invalid", "message": "The two-factor code is not correct."})
+		return
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to regenerate recovery codes"})
+		return
+	}
+	if err := uc.twoFactorRepo.ReplaceRecoveryCodes(currentUser.ID, hashes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to regenerate recovery codes"})
+		logger.Errorf("Failed to replace recovery codes: " + err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": recoveryCodes})
+}
+
+// DisableTwoFactor turns off two-factor authentication for the current user, given a code from their authenticator app or a recovery code.
+func (uc *UserController) DisableTwoFactor(c *gin.Context) {
+	user, _ := c.Get("user")
+	currentUser := user.(*models.User)
+
+	var disableData struct {
+		Code         string `json:"code"`
+		RecoveryCode string `json:"recoveryCode"`
+	}
+	if err := c.ShouldBindJSON(&disableData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "Improper JSON."})
+		return
+	}
+
+	if err := auth.CheckSecondFactor(uc.twoFactorRepo, currentUser.ID, disableData.Code, disableData.RecoveryCode, time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "two-factor-invalid", "message": "The two-factor code is not correct."})
+		return
+	}
+
+	if err := uc.twoFactorRepo.DeleteTwoFactor(currentUser.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable two-factor authentication"})
+		logger.Errorf("Failed to delete two-factor authentication: " + err.Error())
+		return
+	}
+
+	email.GenericSendMail("Two-Factor Authentication Disabled", "Two-factor authentication has been disabled on your account. If this was not you, reset your password right away.", currentUser.Email, currentUser.Name)
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled."})
+}
+
+// newRecoveryCodes returns new recovery codes with the hashes they are stored under.
+func newRecoveryCodes() ([]string, []string, error) {
+	recoveryCodes, err := auth.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = auth.HashRecoveryCode(code)
+	}
+	return recoveryCodes, hashes, nil
+}
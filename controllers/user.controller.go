@@ -1,10 +1,15 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
 	"github.com/gin-gonic/gin"
 )
 
@@ -164,3 +169,127 @@ func (uc *UserController) GetMyRequests(c *gin.Context) {
 	// Respond with the requests
 	c.JSON(http.StatusOK, requestsWithTeams)
 }
+
+// GetMyInvites returns the pending invitations of the authenticated user to teams.
+func (uc *UserController) GetMyInvites(c *gin.Context) {
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+
+	invites, err := uc.teamEmailInviteRepo.GetPendingEmailInvitesByEmail(user.Email)
+	if err != nil {
+		logger.Errorf("Failed to get invitations for user %d: "+err.Error(), user.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+		return
+	}
+
+	invitesWithTeams := make([]models.TeamEmailInviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		team, err := uc.teamRepo.GetTeamByID(invite.TeamID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+			return
+		}
+		invitesWithTeams = append(invitesWithTeams, models.TeamEmailInviteResponse{Invite: invite, Team: team})
+	}
+
+	c.JSON(http.StatusOK, invitesWithTeams)
+}
+
+// AcceptInvite accepts an invitation of the authenticated user to a team, joining it with the role of the invitation.
+func (uc *UserController) AcceptInvite(c *gin.Context) {
+	user, invite, ok := uc.getMyInvite(c)
+	if !ok {
+		return
+	}
+
+	invite, err := uc.acceptInvite(invite, user.ID)
+	if errors.Is(err, errInvitedTeamArchived) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "team-archived", "message": "This team has been archived and cannot be joined."})
+		return
+	}
+	if errors.Is(err, repository.ErrEmailInviteNotPending) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Errorf("Failed to accept invitation %d: "+err.Error(), invite.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept invitation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invite)
+}
+
+// DeclineInvite declines an invitation of the authenticated user to a team.
+func (uc *UserController) DeclineInvite(c *gin.Context) {
+	_, invite, ok := uc.getMyInvite(c)
+	if !ok {
+		return
+	}
+
+	invite, err := uc.teamEmailInviteRepo.RespondToEmailInvite(invite.ID, models.TeamEmailInviteDeclined, time.Now())
+	if errors.Is(err, repository.ErrEmailInviteNotPending) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		logger.Errorf("Failed to decline invitation: " + err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decline invitation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invite)
+}
+
+// getMyInvite gets the invitation of the inviteID route parameter, responding with an error if it is not for the authenticated user.
+func (uc *UserController) getMyInvite(c *gin.Context) (*models.User, models.TeamEmailInvite, bool) {
+	currentUser, _ := c.Get("user")
+	user := currentUser.(*models.User)
+
+	inviteID, err := strconv.Atoi(c.Param("inviteID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invite ID"})
+		return nil, models.TeamEmailInvite{}, false
+	}
+
+	invite, err := uc.teamEmailInviteRepo.GetEmailInviteByID(uint(inviteID))
+	if err != nil || !strings.EqualFold(invite.Email, user.Email) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		return nil, models.TeamEmailInvite{}, false
+	}
+	return user, invite, true
+}
+
+var errInvitedTeamArchived = errors.New("team has been archived")
+
+// acceptInvite accepts an invitation to a team and adds the user to it, unless they already are a member.
+func (uc *UserController) acceptInvite(invite models.TeamEmailInvite, userID uint) (models.TeamEmailInvite, error) {
+	team, err := uc.teamRepo.GetTeamByID(invite.TeamID)
+	if err != nil {
+		return invite, err
+	}
+	if team.Archived() {
+		return invite, errInvitedTeamArchived
+	}
+	_, err = uc.teamMemberRepo.GetTeamMemberByID(team.ID, userID)
+	return uc.teamEmailInviteRepo.AcceptEmailInvite(invite, userID, time.Now(), err != nil)
+}
+
+// joinInvitedTeams accepts the pending invitations of a newly verified email. Failures are only logged, the verification stands.
+func (uc *UserController) joinInvitedTeams(email string) {
+	user, err := uc.userRepo.GetUserByEmail(email)
+	if err != nil {
+		logger.Errorf("Failed to get user %s to accept invitations: %v", email, err)
+		return
+	}
+	invites, err := uc.teamEmailInviteRepo.GetPendingEmailInvitesByEmail(email)
+	if err != nil {
+		logger.Errorf("Failed to get invitations of %s: %v", email, err)
+		return
+	}
+	for _, invite := range invites {
+		if _, err := uc.acceptInvite(invite, user.ID); err != nil {
+			logger.Errorf("Failed to accept invitation %d of %s: %v", invite.ID, email, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+)
+
+// Job is run by the Scheduler with the time of the tick.
+type Job func(now time.Time) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler runs jobs in the background at fixed intervals. It keeps no state of its own,
+// jobs read what is due from the database so nothing is lost on restart, and must be safe to run on several replicas at once.
+type Scheduler struct {
+	entries []entry
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a Scheduler without jobs.
+func New() *Scheduler {
+	return &Scheduler{stop: make(chan struct{})}
+}
+
+// Every registers job to be run every interval once the Scheduler is started.
+func (s *Scheduler) Every(interval time.Duration, name string, job Job) {
+	s.entries = append(s.entries, entry{name, interval, job})
+}
+
+// Start runs every job once right away, then at its interval, until Stop is called.
+func (s *Scheduler) Start() {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			ticker := time.NewTicker(e.interval)
+			defer ticker.Stop()
+
+			run(e, time.Now())
+			for {
+				select {
+				case now := <-ticker.C:
+					run(e, now)
+				case <-s.stop:
+					return
+				}
+			}
+		}(e)
+	}
+}
+
+// Stop stops the jobs, waiting for running ones to finish.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func run(e entry, now time.Time) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("Scheduled job %s panicked: %v", e.name, r)
+		}
+	}()
+	if err := e.job(now); err != nil {
+		logger.Errorf("Scheduled job %s failed: %v", e.name, err)
+	}
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs, failures int32
+
+	s := New()
+	s.Every(10*time.Millisecond, "count", func(now time.Time) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+	s.Every(10*time.Millisecond, "fail", func(now time.Time) error {
+		atomic.AddInt32(&failures, 1)
+		return errors.New("failed")
+	})
+	s.Start()
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	stopped := atomic.LoadInt32(&runs)
+	if stopped < 3 {
+		t.Errorf("Expected the job to run at least 3 times, ran %d times", stopped)
+	}
+	if atomic.LoadInt32(&failures) < 3 {
+		t.Errorf("Expected a failing job to keep running, ran %d times", atomic.LoadInt32(&failures))
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if atomic.LoadInt32(&runs) != stopped {
+		t.Errorf("Expected no runs after Stop")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/migrations"
 	"github.com/GDGVIT/attendance-app-backend/routers"
+	"github.com/GDGVIT/attendance-app-backend/utils/token"
 	"github.com/spf13/viper"
 )
 
@@ -24,6 +25,10 @@ func main() {
 
 	config.InitialiseOAuthGoogle()
 
+	if err := token.LoadKeys(); err != nil {
+		logger.Fatalf("token LoadKeys() error: %s", err)
+	}
+
 	DSN := config.DbConfiguration()
 
 	if err := database.DbConnection(DSN); err != nil {
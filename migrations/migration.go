@@ -1,8 +1,13 @@
 package migrations
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/otp"
+	"gorm.io/gorm"
 )
 
 // Migrate Add list of model add for migrations
@@ -10,9 +15,6 @@ import (
 func Migrate() {
 	var migrationModels = []interface{}{
 		&models.User{},
-		&models.VerificationEntry{},
-		&models.ForgotPassword{},
-		&models.DeletionConfirmation{},
 		&models.PasswordAuth{},
 		&models.AuthProvider{},
 		&models.Team{},
@@ -20,12 +22,62 @@ func Migrate() {
 		&models.TeamEntryRequest{},
 		&models.Meeting{},
 		&models.MeetingAttendance{},
+		&models.MeetingSeries{},
+		&models.LeaveRequest{},
+		&models.TeamInviteLink{},
+		&models.TeamInviteUse{},
+		&models.TeamEmailInvite{},
+		&models.TeamJoinRule{},
+		&models.TeamRosterEntry{},
+		&models.TeamRole{},
+		&models.AuditLog{},
+		&models.Session{},
+		&models.TwoFactor{},
+		&models.RecoveryCode{},
+		&models.AuthThrottle{},
+		&models.OneTimeCode{},
+		&models.OAuthState{},
 	}
 	err := database.DB.AutoMigrate(migrationModels...)
 	if err != nil {
+		logger.Errorf("Error migrating models: %v", err)
 		return
 	}
 
+	if err := migratePlaintextCodes(database.DB, time.Now()); err != nil {
+		logger.Errorf("Error migrating plaintext one-time codes: %v", err)
+	}
+
 	// Remove the 'Password' field from the 'users' table
 	// database.DB.Migrator().DropColumn(&models.User{}, "password")
 }
+
+// migratePlaintextCodes replaces the plaintext code tables by the hashed one_time_codes. Pending verification codes are moved over,
+// valid for a full lifespan from now, so the links already emailed to unverified users keep working. Forgot password and
+// deletion codes only lasted minutes, and are dropped. Does nothing once the tables are gone.
+func migratePlaintextCodes(db *gorm.DB, now time.Time) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasTable("verification_entries") {
+			var entries []struct {
+				Email string
+				OTP   string
+			}
+			if err := tx.Table("verification_entries").Select("email", "otp").Where("deleted_at IS NULL").Find(&entries).Error; err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				oneTimeCode := models.OneTimeCode{Purpose: models.OneTimeCodeVerification, Email: entry.Email, ExpiresAt: now.Add(otp.Lifespan(models.OneTimeCodeVerification))}
+				if err := oneTimeCode.SetCode(entry.OTP); err != nil {
+					return err
+				}
+				if err := tx.Create(&oneTimeCode).Error; err != nil {
+					return err
+				}
+			}
+			if len(entries) > 0 {
+				logger.Infof("Moved %d pending verification codes to one_time_codes", len(entries))
+			}
+		}
+		return tx.Migrator().DropTable("verification_entries", "forgot_passwords", "deletion_confirmations")
+	})
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/otp"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"gorm.io/gorm"
+)
+
+func TestMigratePlaintextCodes(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.OneTimeCode{})
+	database.DB = db
+
+	// the tables of the plaintext codes, as they were
+	type VerificationEntry struct {
+		gorm.Model
+		Email string `gorm:"unique"`
+		OTP   string
+	}
+	type ForgotPassword struct {
+		gorm.Model
+		Email string `gorm:"unique"`
+		OTP   string
+	}
+	if err := db.AutoMigrate(&VerificationEntry{}, &ForgotPassword{}); err != nil {
+		t.Fatalf("Failed to create the plaintext code tables: %v", err)
+	}
+	db.Create(&VerificationEntry{Email: "alice@example.com", OTP: "123456"})
+	db.Create(&ForgotPassword{Email: "alice@example.com", OTP: "654321"})
+
+	now := time.Now()
+	if err := migratePlaintextCodes(db, now); err != nil {
+		t.Fatalf("migratePlaintextCodes returned an error: %v", err)
+	}
+	for _, table := range []string{"verification_entries", "forgot_passwords", "deletion_confirmations"} {
+		if db.Migrator().HasTable(table) {
+			t.Errorf("Expected table %s to be dropped", table)
+		}
+	}
+
+	// the emailed verification code still works, forgot password codes are gone
+	oneTimeCodeRepo := repository.NewOneTimeCodeRepository()
+	if err := otp.Verify(oneTimeCodeRepo, models.OneTimeCodeForgot, "alice@example.com", "654321", now); err == nil {
+		t.Errorf("Expected the forgot password code to be dropped")
+	}
+	if err := otp.Verify(oneTimeCodeRepo, models.OneTimeCodeVerification, "alice@example.com", "123456", now.Add(time.Hour)); err != nil {
+		t.Errorf("Expected the pending verification code to be moved, got %v", err)
+	}
+
+	// once the tables are gone there is nothing to do
+	if err := migratePlaintextCodes(db, now); err != nil {
+		t.Errorf("Expected a second run to do nothing, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/export"
+	"github.com/golang/mock/gomock"
+)
+
+// MockAttendanceExportService is a mock implementation of AttendanceExportServiceInterface.
+type MockAttendanceExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendanceExportServiceMockRecorder
+}
+
+// NewMockAttendanceExportService creates a new mock service.
+func NewMockAttendanceExportService(ctrl *gomock.Controller) *MockAttendanceExportService {
+	mock := &MockAttendanceExportService{ctrl: ctrl}
+	mock.recorder = &MockAttendanceExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockAttendanceExportService) EXPECT() *MockAttendanceExportServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMeetingRegister mocks the GetMeetingRegister method.
+func (m *MockAttendanceExportService) GetMeetingRegister(meetingID, teamID uint) (models.AttendanceRegister, error) {
+	ret := m.ctrl.Call(m, "GetMeetingRegister", meetingID, teamID)
+	ret0, _ := ret[0].(models.AttendanceRegister)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamRegister mocks the GetTeamRegister method.
+func (m *MockAttendanceExportService) GetTeamRegister(teamID uint, from, to time.Time) (models.AttendanceRegister, error) {
+	ret := m.ctrl.Call(m, "GetTeamRegister", teamID, from, to)
+	ret0, _ := ret[0].(models.AttendanceRegister)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteRegister mocks the WriteRegister method.
+func (m *MockAttendanceExportService) WriteRegister(register models.AttendanceRegister, w export.Writer) error {
+	ret := m.ctrl.Call(m, "WriteRegister", register, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MockAttendanceExportServiceMockRecorder is a mock recorder for MockAttendanceExportService.
+type MockAttendanceExportServiceMockRecorder struct {
+	mock *MockAttendanceExportService
+}
+
+// GetMeetingRegister mocks the GetMeetingRegister method.
+func (mr *MockAttendanceExportServiceMockRecorder) GetMeetingRegister(meetingID, teamID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingRegister", reflect.TypeOf((*MockAttendanceExportService)(nil).GetMeetingRegister), meetingID, teamID)
+}
+
+// GetTeamRegister mocks the GetTeamRegister method.
+func (mr *MockAttendanceExportServiceMockRecorder) GetTeamRegister(teamID, from, to interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamRegister", reflect.TypeOf((*MockAttendanceExportService)(nil).GetTeamRegister), teamID, from, to)
+}
+
+// WriteRegister mocks the WriteRegister method.
+func (mr *MockAttendanceExportServiceMockRecorder) WriteRegister(register, w interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteRegister", reflect.TypeOf((*MockAttendanceExportService)(nil).WriteRegister), register, w)
+}
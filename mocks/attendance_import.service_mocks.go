@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+)
+
+// MockAttendanceImportService is a mock implementation of AttendanceImportServiceInterface.
+type MockAttendanceImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendanceImportServiceMockRecorder
+}
+
+// NewMockAttendanceImportService creates a new mock service.
+func NewMockAttendanceImportService(ctrl *gomock.Controller) *MockAttendanceImportService {
+	mock := &MockAttendanceImportService{ctrl: ctrl}
+	mock.recorder = &MockAttendanceImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockAttendanceImportService) EXPECT() *MockAttendanceImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportAttendance mocks the ImportAttendance method.
+func (m *MockAttendanceImportService) ImportAttendance(actor models.AuditActor, teamID uint, file io.Reader, dryRun bool, now time.Time) (models.AttendanceImportReport, error) {
+	ret := m.ctrl.Call(m, "ImportAttendance", actor, teamID, file, dryRun, now)
+	ret0, _ := ret[0].(models.AttendanceImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MockAttendanceImportServiceMockRecorder is a mock recorder for MockAttendanceImportService.
+type MockAttendanceImportServiceMockRecorder struct {
+	mock *MockAttendanceImportService
+}
+
+// ImportAttendance mocks the ImportAttendance method.
+func (mr *MockAttendanceImportServiceMockRecorder) ImportAttendance(actor, teamID, file, dryRun, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAttendance", reflect.TypeOf((*MockAttendanceImportService)(nil).ImportAttendance), actor, teamID, file, dryRun, now)
+}
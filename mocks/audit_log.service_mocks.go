@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"reflect"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+)
+
+// MockAuditLogService is a mock implementation of AuditLogServiceInterface.
+type MockAuditLogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogServiceMockRecorder
+}
+
+// NewMockAuditLogService creates a new mock service.
+func NewMockAuditLogService(ctrl *gomock.Controller) *MockAuditLogService {
+	mock := &MockAuditLogService{ctrl: ctrl}
+	mock.recorder = &MockAuditLogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockAuditLogService) EXPECT() *MockAuditLogServiceMockRecorder {
+	return m.recorder
+}
+
+// Record mocks the Record method.
+func (m *MockAuditLogService) Record(actor models.AuditActor, teamID uint, action, targetType string, targetID uint, before, after interface{}) {
+	m.ctrl.Call(m, "Record", actor, teamID, action, targetType, targetID, before, after)
+}
+
+// GetTeamAuditLogs mocks the GetTeamAuditLogs method.
+func (m *MockAuditLogService) GetTeamAuditLogs(filter models.AuditLogFilter) (models.AuditLogPage, error) {
+	ret := m.ctrl.Call(m, "GetTeamAuditLogs", filter)
+	ret0, _ := ret[0].(models.AuditLogPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MockAuditLogServiceMockRecorder is a mock recorder for MockAuditLogService.
+type MockAuditLogServiceMockRecorder struct {
+	mock *MockAuditLogService
+}
+
+// Record mocks the Record method.
+func (mr *MockAuditLogServiceMockRecorder) Record(actor, teamID, action, targetType, targetID, before, after interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditLogService)(nil).Record), actor, teamID, action, targetType, targetID, before, after)
+}
+
+// GetTeamAuditLogs mocks the GetTeamAuditLogs method.
+func (mr *MockAuditLogServiceMockRecorder) GetTeamAuditLogs(filter interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamAuditLogs", reflect.TypeOf((*MockAuditLogService)(nil).GetTeamAuditLogs), filter)
+}
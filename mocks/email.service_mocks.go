@@ -30,6 +30,20 @@ func (m *MockEmailService) SendMeetingNotification(teamID uint, meeting models.M
 	return ret0
 }
 
+// SendMeetingUpdateNotification mocks the SendMeetingUpdateNotification method.
+func (m *MockEmailService) SendMeetingUpdateNotification(teamID uint, meeting models.Meeting, changes []string) error {
+	ret := m.ctrl.Call(m, "SendMeetingUpdateNotification", teamID, meeting, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMeetingSeriesNotification mocks the SendMeetingSeriesNotification method.
+func (m *MockEmailService) SendMeetingSeriesNotification(teamID uint, series models.MeetingSeries) error {
+	ret := m.ctrl.Call(m, "SendMeetingSeriesNotification", teamID, series)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // GenericSendMail mocks the GenericSendMail method.
 func (m *MockEmailService) GenericSendMail(subject string, content string, toEmail string, userName string) error {
 	ret := m.ctrl.Call(m, "GenericSendMail", subject, content, toEmail, userName)
@@ -47,6 +61,16 @@ func (m *MockEmailServiceMockRecorder) SendMeetingNotification(teamID uint, meet
 	return m.mock.ctrl.RecordCall(m.mock, "SendMeetingNotification", teamID, meeting)
 }
 
+// SendMeetingUpdateNotification mocks the SendMeetingUpdateNotification method.
+func (m *MockEmailServiceMockRecorder) SendMeetingUpdateNotification(teamID uint, meeting models.Meeting, changes []string) *gomock.Call {
+	return m.mock.ctrl.RecordCall(m.mock, "SendMeetingUpdateNotification", teamID, meeting, changes)
+}
+
+// SendMeetingSeriesNotification mocks the SendMeetingSeriesNotification method.
+func (m *MockEmailServiceMockRecorder) SendMeetingSeriesNotification(teamID uint, series interface{}) *gomock.Call {
+	return m.mock.ctrl.RecordCall(m.mock, "SendMeetingSeriesNotification", teamID, series)
+}
+
 // GenericSendMail mocks the GenericSendMail method.
 func (m *MockEmailServiceMockRecorder) GenericSendMail(subject string, content string, toEmail string, userName string) *gomock.Call {
 	return m.mock.ctrl.RecordCall(m.mock, "GenericSendMail", subject, content, toEmail, userName)
@@ -0,0 +1,160 @@
+package mocks
+
+import (
+	"reflect"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+)
+
+// MockLeaveRequestRepository is a mock implementation of LeaveRequestRepositoryInterface.
+type MockLeaveRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaveRequestRepositoryMockRecorder
+}
+
+// NewMockLeaveRequestRepository creates a new mock repository.
+func NewMockLeaveRequestRepository(ctrl *gomock.Controller) *MockLeaveRequestRepository {
+	mock := &MockLeaveRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockLeaveRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockLeaveRequestRepository) EXPECT() *MockLeaveRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLeaveRequest mocks the CreateLeaveRequest method.
+func (m *MockLeaveRequestRepository) CreateLeaveRequest(request models.LeaveRequest) (models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "CreateLeaveRequest", request)
+	ret0, _ := ret[0].(models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveRequestByID mocks the GetLeaveRequestByID method.
+func (m *MockLeaveRequestRepository) GetLeaveRequestByID(id uint) (models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetLeaveRequestByID", id)
+	ret0, _ := ret[0].(models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveRequestByMeetingIDAndUserID mocks the GetLeaveRequestByMeetingIDAndUserID method.
+func (m *MockLeaveRequestRepository) GetLeaveRequestByMeetingIDAndUserID(meetingID, userID uint) (models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetLeaveRequestByMeetingIDAndUserID", meetingID, userID)
+	ret0, _ := ret[0].(models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveRequestsByTeamID mocks the GetLeaveRequestsByTeamID method.
+func (m *MockLeaveRequestRepository) GetLeaveRequestsByTeamID(teamID uint, status string, meetingID uint) ([]models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetLeaveRequestsByTeamID", teamID, status, meetingID)
+	ret0, _ := ret[0].([]models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveRequestsByTeamIDAndUserID mocks the GetLeaveRequestsByTeamIDAndUserID method.
+func (m *MockLeaveRequestRepository) GetLeaveRequestsByTeamIDAndUserID(teamID, userID uint) ([]models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetLeaveRequestsByTeamIDAndUserID", teamID, userID)
+	ret0, _ := ret[0].([]models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedLeaveRequestsByMeetingID mocks the GetApprovedLeaveRequestsByMeetingID method.
+func (m *MockLeaveRequestRepository) GetApprovedLeaveRequestsByMeetingID(meetingID uint) ([]models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetApprovedLeaveRequestsByMeetingID", meetingID)
+	ret0, _ := ret[0].([]models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedLeaveRequestsByMeetingIDs mocks the GetApprovedLeaveRequestsByMeetingIDs method.
+func (m *MockLeaveRequestRepository) GetApprovedLeaveRequestsByMeetingIDs(meetingIDs []uint) ([]models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetApprovedLeaveRequestsByMeetingIDs", meetingIDs)
+	ret0, _ := ret[0].([]models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedLeaveRequestsByUserID mocks the GetApprovedLeaveRequestsByUserID method.
+func (m *MockLeaveRequestRepository) GetApprovedLeaveRequestsByUserID(userID uint) ([]models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetApprovedLeaveRequestsByUserID", userID)
+	ret0, _ := ret[0].([]models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLeaveRequest mocks the UpdateLeaveRequest method.
+func (m *MockLeaveRequestRepository) UpdateLeaveRequest(request models.LeaveRequest) (models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "UpdateLeaveRequest", request)
+	ret0, _ := ret[0].(models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLeaveRequestByID mocks the DeleteLeaveRequestByID method.
+func (m *MockLeaveRequestRepository) DeleteLeaveRequestByID(id uint) error {
+	ret := m.ctrl.Call(m, "DeleteLeaveRequestByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MockLeaveRequestRepositoryMockRecorder is a mock recorder for MockLeaveRequestRepository.
+type MockLeaveRequestRepositoryMockRecorder struct {
+	mock *MockLeaveRequestRepository
+}
+
+// CreateLeaveRequest mocks the CreateLeaveRequest method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) CreateLeaveRequest(request interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLeaveRequest", reflect.TypeOf((*MockLeaveRequestRepository)(nil).CreateLeaveRequest), request)
+}
+
+// GetLeaveRequestByID mocks the GetLeaveRequestByID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetLeaveRequestByID(id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveRequestByID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetLeaveRequestByID), id)
+}
+
+// GetLeaveRequestByMeetingIDAndUserID mocks the GetLeaveRequestByMeetingIDAndUserID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetLeaveRequestByMeetingIDAndUserID(meetingID, userID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveRequestByMeetingIDAndUserID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetLeaveRequestByMeetingIDAndUserID), meetingID, userID)
+}
+
+// GetLeaveRequestsByTeamID mocks the GetLeaveRequestsByTeamID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetLeaveRequestsByTeamID(teamID, status, meetingID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveRequestsByTeamID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetLeaveRequestsByTeamID), teamID, status, meetingID)
+}
+
+// GetLeaveRequestsByTeamIDAndUserID mocks the GetLeaveRequestsByTeamIDAndUserID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetLeaveRequestsByTeamIDAndUserID(teamID, userID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveRequestsByTeamIDAndUserID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetLeaveRequestsByTeamIDAndUserID), teamID, userID)
+}
+
+// GetApprovedLeaveRequestsByMeetingID mocks the GetApprovedLeaveRequestsByMeetingID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetApprovedLeaveRequestsByMeetingID(meetingID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedLeaveRequestsByMeetingID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetApprovedLeaveRequestsByMeetingID), meetingID)
+}
+
+// GetApprovedLeaveRequestsByMeetingIDs mocks the GetApprovedLeaveRequestsByMeetingIDs method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetApprovedLeaveRequestsByMeetingIDs(meetingIDs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedLeaveRequestsByMeetingIDs", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetApprovedLeaveRequestsByMeetingIDs), meetingIDs)
+}
+
+// GetApprovedLeaveRequestsByUserID mocks the GetApprovedLeaveRequestsByUserID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) GetApprovedLeaveRequestsByUserID(userID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedLeaveRequestsByUserID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).GetApprovedLeaveRequestsByUserID), userID)
+}
+
+// UpdateLeaveRequest mocks the UpdateLeaveRequest method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) UpdateLeaveRequest(request interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLeaveRequest", reflect.TypeOf((*MockLeaveRequestRepository)(nil).UpdateLeaveRequest), request)
+}
+
+// DeleteLeaveRequestByID mocks the DeleteLeaveRequestByID method.
+func (mr *MockLeaveRequestRepositoryMockRecorder) DeleteLeaveRequestByID(id interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLeaveRequestByID", reflect.TypeOf((*MockLeaveRequestRepository)(nil).DeleteLeaveRequestByID), id)
+}
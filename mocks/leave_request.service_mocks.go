@@ -0,0 +1,96 @@
+package mocks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+)
+
+// MockLeaveRequestService is a mock implementation of LeaveRequestServiceInterface.
+type MockLeaveRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaveRequestServiceMockRecorder
+}
+
+// NewMockLeaveRequestService creates a new mock service.
+func NewMockLeaveRequestService(ctrl *gomock.Controller) *MockLeaveRequestService {
+	mock := &MockLeaveRequestService{ctrl: ctrl}
+	mock.recorder = &MockLeaveRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockLeaveRequestService) EXPECT() *MockLeaveRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// SubmitLeaveRequest mocks the SubmitLeaveRequest method.
+func (m *MockLeaveRequestService) SubmitLeaveRequest(teamID, meetingID, userID uint, reason string) (models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "SubmitLeaveRequest", teamID, meetingID, userID, reason)
+	ret0, _ := ret[0].(models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawLeaveRequest mocks the WithdrawLeaveRequest method.
+func (m *MockLeaveRequestService) WithdrawLeaveRequest(teamID, meetingID, userID uint) error {
+	ret := m.ctrl.Call(m, "WithdrawLeaveRequest", teamID, meetingID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserLeaveRequests mocks the GetUserLeaveRequests method.
+func (m *MockLeaveRequestService) GetUserLeaveRequests(teamID, userID uint) ([]models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "GetUserLeaveRequests", teamID, userID)
+	ret0, _ := ret[0].([]models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaveRequests mocks the GetLeaveRequests method.
+func (m *MockLeaveRequestService) GetLeaveRequests(teamID uint, status string, meetingID uint) ([]models.LeaveRequestResponse, error) {
+	ret := m.ctrl.Call(m, "GetLeaveRequests", teamID, status, meetingID)
+	ret0, _ := ret[0].([]models.LeaveRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewLeaveRequest mocks the ReviewLeaveRequest method.
+func (m *MockLeaveRequestService) ReviewLeaveRequest(actor models.AuditActor, requestID, teamID uint, status, note string, now time.Time) (models.LeaveRequest, error) {
+	ret := m.ctrl.Call(m, "ReviewLeaveRequest", actor, requestID, teamID, status, note, now)
+	ret0, _ := ret[0].(models.LeaveRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MockLeaveRequestServiceMockRecorder is a mock recorder for MockLeaveRequestService.
+type MockLeaveRequestServiceMockRecorder struct {
+	mock *MockLeaveRequestService
+}
+
+// SubmitLeaveRequest mocks the SubmitLeaveRequest method.
+func (mr *MockLeaveRequestServiceMockRecorder) SubmitLeaveRequest(teamID, meetingID, userID, reason interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitLeaveRequest", reflect.TypeOf((*MockLeaveRequestService)(nil).SubmitLeaveRequest), teamID, meetingID, userID, reason)
+}
+
+// WithdrawLeaveRequest mocks the WithdrawLeaveRequest method.
+func (mr *MockLeaveRequestServiceMockRecorder) WithdrawLeaveRequest(teamID, meetingID, userID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawLeaveRequest", reflect.TypeOf((*MockLeaveRequestService)(nil).WithdrawLeaveRequest), teamID, meetingID, userID)
+}
+
+// GetUserLeaveRequests mocks the GetUserLeaveRequests method.
+func (mr *MockLeaveRequestServiceMockRecorder) GetUserLeaveRequests(teamID, userID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLeaveRequests", reflect.TypeOf((*MockLeaveRequestService)(nil).GetUserLeaveRequests), teamID, userID)
+}
+
+// GetLeaveRequests mocks the GetLeaveRequests method.
+func (mr *MockLeaveRequestServiceMockRecorder) GetLeaveRequests(teamID, status, meetingID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaveRequests", reflect.TypeOf((*MockLeaveRequestService)(nil).GetLeaveRequests), teamID, status, meetingID)
+}
+
+// ReviewLeaveRequest mocks the ReviewLeaveRequest method.
+func (mr *MockLeaveRequestServiceMockRecorder) ReviewLeaveRequest(actor, requestID, teamID, status, note, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewLeaveRequest", reflect.TypeOf((*MockLeaveRequestService)(nil).ReviewLeaveRequest), actor, requestID, teamID, status, note, now)
+}
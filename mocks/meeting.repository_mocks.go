@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/golang/mock/gomock"
@@ -79,6 +80,46 @@ func (m *MockMeetingRepository) GetMeetingsByTeamIDAndMeetingOver(teamID uint, m
 	return ret0, ret1
 }
 
+// GetMeetingsWithPendingTransitions mocks the GetMeetingsWithPendingTransitions method.
+func (m *MockMeetingRepository) GetMeetingsWithPendingTransitions(now time.Time) ([]models.Meeting, error) {
+	ret := m.ctrl.Call(m, "GetMeetingsWithPendingTransitions", now)
+	ret0, _ := ret[0].([]models.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionMeeting mocks the TransitionMeeting method.
+func (m *MockMeetingRepository) TransitionMeeting(meeting, before models.Meeting) (bool, error) {
+	ret := m.ctrl.Call(m, "TransitionMeeting", meeting, before)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureAttendanceSecret mocks the EnsureAttendanceSecret method.
+func (m *MockMeetingRepository) EnsureAttendanceSecret(meetingID uint, secret string) (string, error) {
+	ret := m.ctrl.Call(m, "EnsureAttendanceSecret", meetingID, secret)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetingsBySeriesID mocks the GetMeetingsBySeriesID method.
+func (m *MockMeetingRepository) GetMeetingsBySeriesID(seriesID uint) ([]models.Meeting, error) {
+	ret := m.ctrl.Call(m, "GetMeetingsBySeriesID", seriesID)
+	ret0, _ := ret[0].([]models.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStartedMeetingsByTeamIDBetween mocks the GetStartedMeetingsByTeamIDBetween method.
+func (m *MockMeetingRepository) GetStartedMeetingsByTeamIDBetween(teamID uint, from, to time.Time) ([]models.Meeting, error) {
+	ret := m.ctrl.Call(m, "GetStartedMeetingsByTeamIDBetween", teamID, from, to)
+	ret0, _ := ret[0].([]models.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // GetMeetingAttendanceByMeetingID mocks the GetMeetingAttendanceByMeetingID method.
 func (m *MockMeetingRepository) GetMeetingAttendanceByMeetingID(meetingID uint) ([]models.MeetingAttendance, error) {
 	ret := m.ctrl.Call(m, "GetMeetingAttendanceByMeetingID", meetingID)
@@ -111,13 +152,43 @@ func (m *MockMeetingRepository) GetMeetingAttendancesByUserID(userID uint) ([]mo
 	return ret0, ret1
 }
 
+// GetMeetingAttendancesByMeetingIDs mocks the GetMeetingAttendancesByMeetingIDs method.
+func (m *MockMeetingRepository) GetMeetingAttendancesByMeetingIDs(meetingIDs []uint) ([]models.MeetingAttendance, error) {
+	ret := m.ctrl.Call(m, "GetMeetingAttendancesByMeetingIDs", meetingIDs)
+	ret0, _ := ret[0].([]models.MeetingAttendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMeetingAttendance mocks the UpdateMeetingAttendance method.
+func (m *MockMeetingRepository) UpdateMeetingAttendance(attendance models.MeetingAttendance) (models.MeetingAttendance, error) {
+	ret := m.ctrl.Call(m, "UpdateMeetingAttendance", attendance)
+	ret0, _ := ret[0].(models.MeetingAttendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMeetingAttendance mocks the DeleteMeetingAttendance method.
+func (m *MockMeetingRepository) DeleteMeetingAttendance(attendance models.MeetingAttendance) error {
+	ret := m.ctrl.Call(m, "DeleteMeetingAttendance", attendance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportAttendance mocks the ImportAttendance method.
+func (m *MockMeetingRepository) ImportAttendance(meetings []*models.Meeting, records []models.ImportedAttendance) error {
+	ret := m.ctrl.Call(m, "ImportAttendance", meetings, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // MockMeetingRepositoryMockRecorder is a mock recorder for MockMeetingRepository.
 type MockMeetingRepositoryMockRecorder struct {
 	mock *MockMeetingRepository
 }
 
 // CreateMeeting mocks the CreateMeeting method.
-func (mr *MockMeetingRepositoryMockRecorder) CreateMeeting(meeting models.Meeting) *gomock.Call {
+func (mr *MockMeetingRepositoryMockRecorder) CreateMeeting(meeting interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeeting", reflect.TypeOf((*MockMeetingRepository)(nil).CreateMeeting), meeting)
 }
 
@@ -127,7 +198,7 @@ func (mr *MockMeetingRepositoryMockRecorder) GetMeetingByID(id uint) *gomock.Cal
 }
 
 // UpdateMeeting mocks the UpdateMeeting method.
-func (mr *MockMeetingRepositoryMockRecorder) UpdateMeeting(meeting models.Meeting) *gomock.Call {
+func (mr *MockMeetingRepositoryMockRecorder) UpdateMeeting(meeting interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMeeting", reflect.TypeOf((*MockMeetingRepository)(nil).UpdateMeeting), meeting)
 }
 
@@ -151,6 +222,26 @@ func (mr *MockMeetingRepositoryMockRecorder) GetMeetingsByTeamIDAndMeetingOver(t
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingsByTeamIDAndMeetingOver", reflect.TypeOf((*MockMeetingRepository)(nil).GetMeetingsByTeamIDAndMeetingOver), teamID, meetingOver)
 }
 
+// GetMeetingsWithPendingTransitions mocks the GetMeetingsWithPendingTransitions method.
+func (mr *MockMeetingRepositoryMockRecorder) GetMeetingsWithPendingTransitions(now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingsWithPendingTransitions", reflect.TypeOf((*MockMeetingRepository)(nil).GetMeetingsWithPendingTransitions), now)
+}
+
+// EnsureAttendanceSecret mocks the EnsureAttendanceSecret method.
+func (mr *MockMeetingRepositoryMockRecorder) EnsureAttendanceSecret(meetingID, secret interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureAttendanceSecret", reflect.TypeOf((*MockMeetingRepository)(nil).EnsureAttendanceSecret), meetingID, secret)
+}
+
+// TransitionMeeting mocks the TransitionMeeting method.
+func (mr *MockMeetingRepositoryMockRecorder) TransitionMeeting(meeting, before interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionMeeting", reflect.TypeOf((*MockMeetingRepository)(nil).TransitionMeeting), meeting, before)
+}
+
+// GetMeetingsBySeriesID mocks the GetMeetingsBySeriesID method.
+func (mr *MockMeetingRepositoryMockRecorder) GetMeetingsBySeriesID(seriesID uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingsBySeriesID", reflect.TypeOf((*MockMeetingRepository)(nil).GetMeetingsBySeriesID), seriesID)
+}
+
 // GetMeetingAttendanceByMeetingID mocks the GetMeetingAttendanceByMeetingID method.
 func (mr *MockMeetingRepositoryMockRecorder) GetMeetingAttendanceByMeetingID(meetingID uint) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingAttendanceByMeetingID", reflect.TypeOf((*MockMeetingRepository)(nil).GetMeetingAttendanceByMeetingID), meetingID)
@@ -170,3 +261,28 @@ func (mr *MockMeetingRepositoryMockRecorder) GetMeetingAttendanceByUserIDAndMeet
 func (mr *MockMeetingRepositoryMockRecorder) GetMeetingAttendancesByUserID(userID uint) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingAttendancesByUserID", reflect.TypeOf((*MockMeetingRepository)(nil).GetMeetingAttendancesByUserID), userID)
 }
+
+// UpdateMeetingAttendance mocks the UpdateMeetingAttendance method.
+func (mr *MockMeetingRepositoryMockRecorder) UpdateMeetingAttendance(attendance models.MeetingAttendance) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMeetingAttendance", reflect.TypeOf((*MockMeetingRepository)(nil).UpdateMeetingAttendance), attendance)
+}
+
+// DeleteMeetingAttendance mocks the DeleteMeetingAttendance method.
+func (mr *MockMeetingRepositoryMockRecorder) DeleteMeetingAttendance(attendance models.MeetingAttendance) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeetingAttendance", reflect.TypeOf((*MockMeetingRepository)(nil).DeleteMeetingAttendance), attendance)
+}
+
+// GetStartedMeetingsByTeamIDBetween mocks the GetStartedMeetingsByTeamIDBetween method.
+func (mr *MockMeetingRepositoryMockRecorder) GetStartedMeetingsByTeamIDBetween(teamID, from, to interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartedMeetingsByTeamIDBetween", reflect.TypeOf((*MockMeetingRepository)(nil).GetStartedMeetingsByTeamIDBetween), teamID, from, to)
+}
+
+// GetMeetingAttendancesByMeetingIDs mocks the GetMeetingAttendancesByMeetingIDs method.
+func (mr *MockMeetingRepositoryMockRecorder) GetMeetingAttendancesByMeetingIDs(meetingIDs interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingAttendancesByMeetingIDs", reflect.TypeOf((*MockMeetingRepository)(nil).GetMeetingAttendancesByMeetingIDs), meetingIDs)
+}
+
+// ImportAttendance mocks the ImportAttendance method.
+func (mr *MockMeetingRepositoryMockRecorder) ImportAttendance(meetings, records interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAttendance", reflect.TypeOf((*MockMeetingRepository)(nil).ImportAttendance), meetings, records)
+}
@@ -27,8 +27,8 @@ func (m *MockMeetingService) EXPECT() *MockMeetingServiceMockRecorder {
 }
 
 // CreateMeeting mocks the CreateMeeting method.
-func (m *MockMeetingService) CreateMeeting(teamID uint, title, description, venue string, location models.Location, startTime time.Time) (models.Meeting, error) {
-	ret := m.ctrl.Call(m, "CreateMeeting", teamID, title, description, venue, location, startTime)
+func (m *MockMeetingService) CreateMeeting(actor models.AuditActor, teamID uint, title, description, venue string, location models.Location, geofence models.Geofence, schedule models.MeetingSchedule, policy models.AttendancePolicy, startTime time.Time) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "CreateMeeting", actor, teamID, title, description, venue, location, geofence, schedule, policy, startTime)
 	ret0, _ := ret[0].(models.Meeting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -50,50 +50,73 @@ func (m *MockMeetingService) GetMeetingByID(id uint, teamid uint) (models.Meetin
 	return ret0, ret1
 }
 
+// UpdateMeeting mocks the UpdateMeeting method.
+func (m *MockMeetingService) UpdateMeeting(actor models.AuditActor, meetingID, teamID uint, update models.MeetingUpdate) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "UpdateMeeting", actor, meetingID, teamID, update)
+	ret0, _ := ret[0].(models.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // StartMeeting mocks the StartMeeting method.
-func (m *MockMeetingService) StartMeeting(meetingID uint, teamid uint) (models.Meeting, error) {
-	ret := m.ctrl.Call(m, "StartMeeting", meetingID, teamid)
+func (m *MockMeetingService) StartMeeting(actor models.AuditActor, meetingID uint, teamid uint) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "StartMeeting", actor, meetingID, teamid)
 	ret0, _ := ret[0].(models.Meeting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EndMeeting mocks the EndMeeting method.
-func (m *MockMeetingService) EndMeeting(meetingID, teamid uint) (models.Meeting, error) {
-	ret := m.ctrl.Call(m, "EndMeeting", meetingID, teamid)
+func (m *MockMeetingService) EndMeeting(actor models.AuditActor, meetingID, teamid uint) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "EndMeeting", actor, meetingID, teamid)
 	ret0, _ := ret[0].(models.Meeting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StartAttendance mocks the StartAttendance method.
-func (m *MockMeetingService) StartAttendance(meetingID, teamid uint) (models.Meeting, error) {
-	ret := m.ctrl.Call(m, "StartAttendance", meetingID, teamid)
+func (m *MockMeetingService) StartAttendance(actor models.AuditActor, meetingID, teamid uint) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "StartAttendance", actor, meetingID, teamid)
 	ret0, _ := ret[0].(models.Meeting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EndAttendance mocks the EndAttendance method.
-func (m *MockMeetingService) EndAttendance(meetingID, teamid uint) (models.Meeting, error) {
-	ret := m.ctrl.Call(m, "EndAttendance", meetingID, teamid)
+func (m *MockMeetingService) EndAttendance(actor models.AuditActor, meetingID, teamid uint) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "EndAttendance", actor, meetingID, teamid)
 	ret0, _ := ret[0].(models.Meeting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// RunScheduledTransitions mocks the RunScheduledTransitions method.
+func (m *MockMeetingService) RunScheduledTransitions(now time.Time) error {
+	ret := m.ctrl.Call(m, "RunScheduledTransitions", now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // DeleteMeetingByID mocks the DeleteMeetingByID method.
-func (m *MockMeetingService) DeleteMeetingByID(meetingID uint, teamid uint) error {
-	ret := m.ctrl.Call(m, "DeleteMeetingByID", meetingID, teamid)
+func (m *MockMeetingService) DeleteMeetingByID(actor models.AuditActor, meetingID uint, teamid uint) error {
+	ret := m.ctrl.Call(m, "DeleteMeetingByID", actor, meetingID, teamid)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// GetAttendanceCode mocks the GetAttendanceCode method.
+func (m *MockMeetingService) GetAttendanceCode(meetingID, teamID uint, now time.Time) (models.AttendanceCodeResponse, error) {
+	ret := m.ctrl.Call(m, "GetAttendanceCode", meetingID, teamID, now)
+	ret0, _ := ret[0].(models.AttendanceCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // MarkAttendanceForUserInMeeting mocks the MarkAttendanceForUserInMeeting method.
-func (m *MockMeetingService) MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamid uint) (bool, error) {
-	ret := m.ctrl.Call(m, "MarkAttendanceForUserInMeeting", userID, meetingID, attendanceTime, teamid)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[0].(error)
+func (m *MockMeetingService) MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamid uint, code string, position *models.ReportedLocation) (models.MeetingAttendance, error) {
+	ret := m.ctrl.Call(m, "MarkAttendanceForUserInMeeting", userID, meetingID, attendanceTime, teamid, code, position)
+	ret0, _ := ret[0].(models.MeetingAttendance)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -105,6 +128,14 @@ func (m *MockMeetingService) GetAttendanceForMeeting(meetingID, teamID uint) ([]
 	return ret0, ret1
 }
 
+// GetMeetingSummary mocks the GetMeetingSummary method.
+func (m *MockMeetingService) GetMeetingSummary(meetingID, teamID uint) (models.MeetingSummaryResponse, error) {
+	ret := m.ctrl.Call(m, "GetMeetingSummary", meetingID, teamID)
+	ret0, _ := ret[0].(models.MeetingSummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpcomingUserMeetings mocks the UpcomingUserMeetings method.
 func (m *MockMeetingService) UpcomingUserMeetings(userID uint) ([]models.UserUpcomingMeetingsListResponse, error) {
 	ret := m.ctrl.Call(m, "UpcomingUserMeetings", userID)
@@ -121,14 +152,29 @@ func (m *MockMeetingService) GetFullUserAttendanceRecord(userID uint) ([]models.
 	return ret0, ret1
 }
 
+// OverrideAttendance mocks the OverrideAttendance method.
+func (m *MockMeetingService) OverrideAttendance(actor models.AuditActor, userID, meetingID, teamID uint, onTime bool, reason string, now time.Time) (models.MeetingAttendance, error) {
+	ret := m.ctrl.Call(m, "OverrideAttendance", actor, userID, meetingID, teamID, onTime, reason, now)
+	ret0, _ := ret[0].(models.MeetingAttendance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveAttendance mocks the RemoveAttendance method.
+func (m *MockMeetingService) RemoveAttendance(actor models.AuditActor, userID, meetingID, teamID uint, reason string, now time.Time) error {
+	ret := m.ctrl.Call(m, "RemoveAttendance", actor, userID, meetingID, teamID, reason, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // MockMeetingServiceMockRecorder is a mock recorder for MockMeetingService.
 type MockMeetingServiceMockRecorder struct {
 	mock *MockMeetingService
 }
 
 // CreateMeeting mocks the CreateMeeting method.
-func (mr *MockMeetingServiceMockRecorder) CreateMeeting(teamID uint, title, description, venue string, location models.Location, startTime time.Time) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeeting", reflect.TypeOf((*MockMeetingService)(nil).CreateMeeting), teamID, title, description, venue, location, startTime)
+func (mr *MockMeetingServiceMockRecorder) CreateMeeting(actor interface{}, teamID uint, title, description, venue string, location models.Location, geofence models.Geofence, schedule models.MeetingSchedule, policy models.AttendancePolicy, startTime time.Time) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeeting", reflect.TypeOf((*MockMeetingService)(nil).CreateMeeting), actor, teamID, title, description, venue, location, geofence, schedule, policy, startTime)
 }
 
 // GetMeetingsByTeamID mocks the GetMeetingsByTeamID method.
@@ -140,34 +186,49 @@ func (mr *MockMeetingServiceMockRecorder) GetMeetingByID(id, teamid uint) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingByID", reflect.TypeOf((*MockMeetingService)(nil).GetMeetingByID), id, teamid)
 }
 
+// UpdateMeeting mocks the UpdateMeeting method.
+func (mr *MockMeetingServiceMockRecorder) UpdateMeeting(actor interface{}, meetingID, teamID uint, update interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMeeting", reflect.TypeOf((*MockMeetingService)(nil).UpdateMeeting), actor, meetingID, teamID, update)
+}
+
 // StartMeeting mocks the StartMeeting method.
-func (mr *MockMeetingServiceMockRecorder) StartMeeting(meetingID, teamid uint) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMeeting", reflect.TypeOf((*MockMeetingService)(nil).StartMeeting), meetingID, teamid)
+func (mr *MockMeetingServiceMockRecorder) StartMeeting(actor interface{}, meetingID, teamid uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMeeting", reflect.TypeOf((*MockMeetingService)(nil).StartMeeting), actor, meetingID, teamid)
 }
 
 // EndMeeting mocks the EndMeeting method.
-func (mr *MockMeetingServiceMockRecorder) EndMeeting(meetingID, teamid uint) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndMeeting", reflect.TypeOf((*MockMeetingService)(nil).EndMeeting), meetingID, teamid)
+func (mr *MockMeetingServiceMockRecorder) EndMeeting(actor interface{}, meetingID, teamid uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndMeeting", reflect.TypeOf((*MockMeetingService)(nil).EndMeeting), actor, meetingID, teamid)
 }
 
 // StartAttendance mocks the StartAttendance method.
-func (mr *MockMeetingServiceMockRecorder) StartAttendance(meetingID, teamid uint) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAttendance", reflect.TypeOf((*MockMeetingService)(nil).StartAttendance), meetingID, teamid)
+func (mr *MockMeetingServiceMockRecorder) StartAttendance(actor interface{}, meetingID, teamid uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAttendance", reflect.TypeOf((*MockMeetingService)(nil).StartAttendance), actor, meetingID, teamid)
 }
 
 // EndAttendance mocks the EndAttendance method.
-func (mr *MockMeetingServiceMockRecorder) EndAttendance(meetingID, teamid uint) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndAttendance", reflect.TypeOf((*MockMeetingService)(nil).EndAttendance), meetingID, teamid)
+func (mr *MockMeetingServiceMockRecorder) EndAttendance(actor interface{}, meetingID, teamid uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndAttendance", reflect.TypeOf((*MockMeetingService)(nil).EndAttendance), actor, meetingID, teamid)
+}
+
+// RunScheduledTransitions mocks the RunScheduledTransitions method.
+func (mr *MockMeetingServiceMockRecorder) RunScheduledTransitions(now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduledTransitions", reflect.TypeOf((*MockMeetingService)(nil).RunScheduledTransitions), now)
 }
 
 // DeleteMeetingByID mocks the DeleteMeetingByID method.
-func (mr *MockMeetingServiceMockRecorder) DeleteMeetingByID(meetingID, teamid uint) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeetingByID", reflect.TypeOf((*MockMeetingService)(nil).DeleteMeetingByID), meetingID, teamid)
+func (mr *MockMeetingServiceMockRecorder) DeleteMeetingByID(actor interface{}, meetingID, teamid uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeetingByID", reflect.TypeOf((*MockMeetingService)(nil).DeleteMeetingByID), actor, meetingID, teamid)
+}
+
+// GetAttendanceCode mocks the GetAttendanceCode method.
+func (mr *MockMeetingServiceMockRecorder) GetAttendanceCode(meetingID, teamID uint, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttendanceCode", reflect.TypeOf((*MockMeetingService)(nil).GetAttendanceCode), meetingID, teamID, now)
 }
 
 // MarkAttendanceForUserInMeeting mocks the MarkAttendanceForUserInMeeting method.
-func (mr *MockMeetingServiceMockRecorder) MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamid uint) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAttendanceForUserInMeeting", reflect.TypeOf((*MockMeetingService)(nil).MarkAttendanceForUserInMeeting), userID, meetingID, attendanceTime, teamid)
+func (mr *MockMeetingServiceMockRecorder) MarkAttendanceForUserInMeeting(userID, meetingID, attendanceTime, teamid, code, position interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAttendanceForUserInMeeting", reflect.TypeOf((*MockMeetingService)(nil).MarkAttendanceForUserInMeeting), userID, meetingID, attendanceTime, teamid, code, position)
 }
 
 // GetAttendanceForMeeting mocks the GetAttendanceForMeeting method.
@@ -175,6 +236,11 @@ func (mr *MockMeetingServiceMockRecorder) GetAttendanceForMeeting(meetingID, tea
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttendanceForMeeting", reflect.TypeOf((*MockMeetingService)(nil).GetAttendanceForMeeting), meetingID, teamID)
 }
 
+// GetMeetingSummary mocks the GetMeetingSummary method.
+func (mr *MockMeetingServiceMockRecorder) GetMeetingSummary(meetingID, teamID uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingSummary", reflect.TypeOf((*MockMeetingService)(nil).GetMeetingSummary), meetingID, teamID)
+}
+
 // UpcomingUserMeetings mocks the UpcomingUserMeetings method.
 func (mr *MockMeetingServiceMockRecorder) UpcomingUserMeetings(userID uint) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpcomingUserMeetings", reflect.TypeOf((*MockMeetingService)(nil).UpcomingUserMeetings), userID)
@@ -184,3 +250,13 @@ func (mr *MockMeetingServiceMockRecorder) UpcomingUserMeetings(userID uint) *gom
 func (mr *MockMeetingServiceMockRecorder) GetFullUserAttendanceRecord(userID uint) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullUserAttendanceRecord", reflect.TypeOf((*MockMeetingService)(nil).GetFullUserAttendanceRecord), userID)
 }
+
+// OverrideAttendance mocks the OverrideAttendance method.
+func (mr *MockMeetingServiceMockRecorder) OverrideAttendance(actor interface{}, userID, meetingID, teamID uint, onTime bool, reason string, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideAttendance", reflect.TypeOf((*MockMeetingService)(nil).OverrideAttendance), actor, userID, meetingID, teamID, onTime, reason, now)
+}
+
+// RemoveAttendance mocks the RemoveAttendance method.
+func (mr *MockMeetingServiceMockRecorder) RemoveAttendance(actor interface{}, userID, meetingID, teamID uint, reason string, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAttendance", reflect.TypeOf((*MockMeetingService)(nil).RemoveAttendance), actor, userID, meetingID, teamID, reason, now)
+}
@@ -0,0 +1,122 @@
+package mocks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+)
+
+// MockMeetingSeriesRepository is a mock implementation of MeetingSeriesRepositoryInterface.
+type MockMeetingSeriesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMeetingSeriesRepositoryMockRecorder
+}
+
+// NewMockMeetingSeriesRepository creates a new mock repository.
+func NewMockMeetingSeriesRepository(ctrl *gomock.Controller) *MockMeetingSeriesRepository {
+	mock := &MockMeetingSeriesRepository{ctrl: ctrl}
+	mock.recorder = &MockMeetingSeriesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockMeetingSeriesRepository) EXPECT() *MockMeetingSeriesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMeetingSeries mocks the CreateMeetingSeries method.
+func (m *MockMeetingSeriesRepository) CreateMeetingSeries(series models.MeetingSeries) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "CreateMeetingSeries", series)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetingSeriesByID mocks the GetMeetingSeriesByID method.
+func (m *MockMeetingSeriesRepository) GetMeetingSeriesByID(id uint) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "GetMeetingSeriesByID", id)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetingSeriesByTeamID mocks the GetMeetingSeriesByTeamID method.
+func (m *MockMeetingSeriesRepository) GetMeetingSeriesByTeamID(teamID uint) ([]models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "GetMeetingSeriesByTeamID", teamID)
+	ret0, _ := ret[0].([]models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetingSeriesMaterializedBefore mocks the GetMeetingSeriesMaterializedBefore method.
+func (m *MockMeetingSeriesRepository) GetMeetingSeriesMaterializedBefore(horizon time.Time) ([]models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "GetMeetingSeriesMaterializedBefore", horizon)
+	ret0, _ := ret[0].([]models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMeetingSeries mocks the UpdateMeetingSeries method.
+func (m *MockMeetingSeriesRepository) UpdateMeetingSeries(series models.MeetingSeries) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "UpdateMeetingSeries", series)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitMeetingSeries mocks the SplitMeetingSeries method.
+func (m *MockMeetingSeriesRepository) SplitMeetingSeries(series, following models.MeetingSeries, from time.Time) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "SplitMeetingSeries", series, following, from)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMeetingSeries mocks the DeleteMeetingSeries method.
+func (m *MockMeetingSeriesRepository) DeleteMeetingSeries(series models.MeetingSeries, from time.Time) error {
+	ret := m.ctrl.Call(m, "DeleteMeetingSeries", series, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MockMeetingSeriesRepositoryMockRecorder is a mock recorder for MockMeetingSeriesRepository.
+type MockMeetingSeriesRepositoryMockRecorder struct {
+	mock *MockMeetingSeriesRepository
+}
+
+// CreateMeetingSeries mocks the CreateMeetingSeries method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) CreateMeetingSeries(series interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeetingSeries", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).CreateMeetingSeries), series)
+}
+
+// GetMeetingSeriesByID mocks the GetMeetingSeriesByID method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) GetMeetingSeriesByID(id uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingSeriesByID", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).GetMeetingSeriesByID), id)
+}
+
+// GetMeetingSeriesByTeamID mocks the GetMeetingSeriesByTeamID method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) GetMeetingSeriesByTeamID(teamID uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingSeriesByTeamID", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).GetMeetingSeriesByTeamID), teamID)
+}
+
+// GetMeetingSeriesMaterializedBefore mocks the GetMeetingSeriesMaterializedBefore method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) GetMeetingSeriesMaterializedBefore(horizon interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingSeriesMaterializedBefore", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).GetMeetingSeriesMaterializedBefore), horizon)
+}
+
+// UpdateMeetingSeries mocks the UpdateMeetingSeries method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) UpdateMeetingSeries(series interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMeetingSeries", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).UpdateMeetingSeries), series)
+}
+
+// SplitMeetingSeries mocks the SplitMeetingSeries method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) SplitMeetingSeries(series, following, from interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitMeetingSeries", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).SplitMeetingSeries), series, following, from)
+}
+
+// DeleteMeetingSeries mocks the DeleteMeetingSeries method.
+func (mr *MockMeetingSeriesRepositoryMockRecorder) DeleteMeetingSeries(series, from interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeetingSeries", reflect.TypeOf((*MockMeetingSeriesRepository)(nil).DeleteMeetingSeries), series, from)
+}
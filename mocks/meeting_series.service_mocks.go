@@ -0,0 +1,121 @@
+package mocks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+)
+
+// MockMeetingSeriesService is a mock implementation of MeetingSeriesServiceInterface.
+type MockMeetingSeriesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMeetingSeriesServiceMockRecorder
+}
+
+// NewMockMeetingSeriesService creates a new mock service.
+func NewMockMeetingSeriesService(ctrl *gomock.Controller) *MockMeetingSeriesService {
+	mock := &MockMeetingSeriesService{ctrl: ctrl}
+	mock.recorder = &MockMeetingSeriesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows expected calls to be set.
+func (m *MockMeetingSeriesService) EXPECT() *MockMeetingSeriesServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateMeetingSeries mocks the CreateMeetingSeries method.
+func (m *MockMeetingSeriesService) CreateMeetingSeries(actor models.AuditActor, series models.MeetingSeries, now time.Time) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "CreateMeetingSeries", actor, series, now)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetingSeriesByID mocks the GetMeetingSeriesByID method.
+func (m *MockMeetingSeriesService) GetMeetingSeriesByID(seriesID, teamID uint) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "GetMeetingSeriesByID", seriesID, teamID)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeetingSeriesByTeamID mocks the GetMeetingSeriesByTeamID method.
+func (m *MockMeetingSeriesService) GetMeetingSeriesByTeamID(teamID uint) ([]models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "GetMeetingSeriesByTeamID", teamID)
+	ret0, _ := ret[0].([]models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOccurrence mocks the UpdateOccurrence method.
+func (m *MockMeetingSeriesService) UpdateOccurrence(actor models.AuditActor, seriesID, meetingID, teamID uint, update models.MeetingUpdate) (models.Meeting, error) {
+	ret := m.ctrl.Call(m, "UpdateOccurrence", actor, seriesID, meetingID, teamID, update)
+	ret0, _ := ret[0].(models.Meeting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFollowingOccurrences mocks the UpdateFollowingOccurrences method.
+func (m *MockMeetingSeriesService) UpdateFollowingOccurrences(actor models.AuditActor, seriesID, meetingID, teamID uint, update models.MeetingUpdate, now time.Time) (models.MeetingSeries, error) {
+	ret := m.ctrl.Call(m, "UpdateFollowingOccurrences", actor, seriesID, meetingID, teamID, update, now)
+	ret0, _ := ret[0].(models.MeetingSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMeetingSeries mocks the DeleteMeetingSeries method.
+func (m *MockMeetingSeriesService) DeleteMeetingSeries(actor models.AuditActor, seriesID, teamID uint, now time.Time) error {
+	ret := m.ctrl.Call(m, "DeleteMeetingSeries", actor, seriesID, teamID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaterializeMeetingSeries mocks the MaterializeMeetingSeries method.
+func (m *MockMeetingSeriesService) MaterializeMeetingSeries(now time.Time) error {
+	ret := m.ctrl.Call(m, "MaterializeMeetingSeries", now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MockMeetingSeriesServiceMockRecorder is a mock recorder for MockMeetingSeriesService.
+type MockMeetingSeriesServiceMockRecorder struct {
+	mock *MockMeetingSeriesService
+}
+
+// CreateMeetingSeries mocks the CreateMeetingSeries method.
+func (mr *MockMeetingSeriesServiceMockRecorder) CreateMeetingSeries(actor, series, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeetingSeries", reflect.TypeOf((*MockMeetingSeriesService)(nil).CreateMeetingSeries), actor, series, now)
+}
+
+// GetMeetingSeriesByID mocks the GetMeetingSeriesByID method.
+func (mr *MockMeetingSeriesServiceMockRecorder) GetMeetingSeriesByID(seriesID, teamID uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingSeriesByID", reflect.TypeOf((*MockMeetingSeriesService)(nil).GetMeetingSeriesByID), seriesID, teamID)
+}
+
+// GetMeetingSeriesByTeamID mocks the GetMeetingSeriesByTeamID method.
+func (mr *MockMeetingSeriesServiceMockRecorder) GetMeetingSeriesByTeamID(teamID uint) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeetingSeriesByTeamID", reflect.TypeOf((*MockMeetingSeriesService)(nil).GetMeetingSeriesByTeamID), teamID)
+}
+
+// UpdateOccurrence mocks the UpdateOccurrence method.
+func (mr *MockMeetingSeriesServiceMockRecorder) UpdateOccurrence(actor interface{}, seriesID, meetingID, teamID uint, update interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOccurrence", reflect.TypeOf((*MockMeetingSeriesService)(nil).UpdateOccurrence), actor, seriesID, meetingID, teamID, update)
+}
+
+// UpdateFollowingOccurrences mocks the UpdateFollowingOccurrences method.
+func (mr *MockMeetingSeriesServiceMockRecorder) UpdateFollowingOccurrences(actor interface{}, seriesID, meetingID, teamID uint, update, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFollowingOccurrences", reflect.TypeOf((*MockMeetingSeriesService)(nil).UpdateFollowingOccurrences), actor, seriesID, meetingID, teamID, update, now)
+}
+
+// DeleteMeetingSeries mocks the DeleteMeetingSeries method.
+func (mr *MockMeetingSeriesServiceMockRecorder) DeleteMeetingSeries(actor interface{}, seriesID, teamID uint, now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeetingSeries", reflect.TypeOf((*MockMeetingSeriesService)(nil).DeleteMeetingSeries), actor, seriesID, teamID, now)
+}
+
+// MaterializeMeetingSeries mocks the MaterializeMeetingSeries method.
+func (mr *MockMeetingSeriesServiceMockRecorder) MaterializeMeetingSeries(now interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeMeetingSeries", reflect.TypeOf((*MockMeetingSeriesService)(nil).MaterializeMeetingSeries), now)
+}
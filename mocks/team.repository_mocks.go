@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/golang/mock/gomock"
 )
@@ -62,6 +64,21 @@ func (m *MockTeamRepository) DeleteTeamByID(id uint) error {
 	return ret0
 }
 
+// DeleteTeamCascade mocks the DeleteTeamCascade method.
+func (m *MockTeamRepository) DeleteTeamCascade(id uint) error {
+	ret := m.ctrl.Call(m, "DeleteTeamCascade", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveTeam mocks the ArchiveTeam method.
+func (m *MockTeamRepository) ArchiveTeam(id uint, at time.Time) (models.Team, error) {
+	ret := m.ctrl.Call(m, "ArchiveTeam", id, at)
+	ret0, _ := ret[0].(models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // GetUnprotectedTeams mocks the GetUnprotectedTeams method.
 func (m *MockTeamRepository) GetUnprotectedTeams() ([]models.Team, error) {
 	ret := m.ctrl.Call(m, "GetUnprotectedTeams")
@@ -108,6 +125,16 @@ func (m *MockTeamRepositoryMockRecorder) DeleteTeamByID(id uint) *gomock.Call {
 	return m.mock.ctrl.RecordCall(m.mock, "DeleteTeamByID", id)
 }
 
+// DeleteTeamCascade mocks the DeleteTeamCascade method.
+func (m *MockTeamRepositoryMockRecorder) DeleteTeamCascade(id uint) *gomock.Call {
+	return m.mock.ctrl.RecordCall(m.mock, "DeleteTeamCascade", id)
+}
+
+// ArchiveTeam mocks the ArchiveTeam method.
+func (m *MockTeamRepositoryMockRecorder) ArchiveTeam(id uint, at interface{}) *gomock.Call {
+	return m.mock.ctrl.RecordCall(m.mock, "ArchiveTeam", id, at)
+}
+
 // GetUnprotectedTeams mocks the GetUnprotectedTeams method.
 func (m *MockTeamRepositoryMockRecorder) GetUnprotectedTeams() *gomock.Call {
 	return m.mock.ctrl.RecordCall(m.mock, "GetUnprotectedTeams")
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/golang/mock/gomock"
 )
@@ -62,6 +64,21 @@ func (m *MockTeamMemberRepository) GetTeamMembersByTeamID(teamID uint) ([]models
 	return ret0, ret1
 }
 
+// GetTeamMembersByTeamIDAsOf mocks the GetTeamMembersByTeamIDAsOf method.
+func (m *MockTeamMemberRepository) GetTeamMembersByTeamIDAsOf(teamID uint, at time.Time) ([]models.TeamMember, error) {
+	ret := m.ctrl.Call(m, "GetTeamMembersByTeamIDAsOf", teamID, at)
+	ret0, _ := ret[0].([]models.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EachTeamMemberBetween mocks the EachTeamMemberBetween method.
+func (m *MockTeamMemberRepository) EachTeamMemberBetween(teamID uint, from, to time.Time, meetingIDs []uint, fn func(member models.TeamMemberUser) error) error {
+	ret := m.ctrl.Call(m, "EachTeamMemberBetween", teamID, from, to, meetingIDs, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // GetTeamMembersByUserID mocks the GetTeamMembersByUserID method.
 func (m *MockTeamMemberRepository) GetTeamMembersByUserID(userID uint) ([]models.TeamMember, error) {
 	ret := m.ctrl.Call(m, "GetTeamMembersByUserID", userID)
@@ -139,6 +156,16 @@ func (m *MockTeamMemberRepositoryMockRecorder) GetTeamMembersByTeamID(teamID uin
 	return m.mock.ctrl.RecordCall(m.mock, "GetTeamMembersByTeamID", teamID)
 }
 
+// GetTeamMembersByTeamIDAsOf mocks the GetTeamMembersByTeamIDAsOf method.
+func (m *MockTeamMemberRepositoryMockRecorder) GetTeamMembersByTeamIDAsOf(teamID uint, at interface{}) *gomock.Call {
+	return m.mock.ctrl.RecordCall(m.mock, "GetTeamMembersByTeamIDAsOf", teamID, at)
+}
+
+// EachTeamMemberBetween mocks the EachTeamMemberBetween method.
+func (m *MockTeamMemberRepositoryMockRecorder) EachTeamMemberBetween(teamID uint, from, to, meetingIDs, fn interface{}) *gomock.Call {
+	return m.mock.ctrl.RecordCall(m.mock, "EachTeamMemberBetween", teamID, from, to, meetingIDs, fn)
+}
+
 // GetTeamMembersByUserID mocks the GetTeamMembersByUserID method.
 func (m *MockTeamMemberRepositoryMockRecorder) GetTeamMembersByUserID(userID uint) *gomock.Call {
 	return m.mock.ctrl.RecordCall(m.mock, "GetTeamMembersByUserID", userID)
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditTeamCreate         = "team.create"
+	AuditTeamUpdate         = "team.update"
+	AuditTeamDelete         = "team.delete"
+	AuditTeamArchive        = "team.archive"
+	AuditTeamInviteRenew    = "team.invite_regenerate"
+	AuditTeamHandover       = "team.handover"
+	AuditMemberJoin         = "member.join"
+	AuditMemberLeave        = "member.leave"
+	AuditMemberKick         = "member.kick"
+	AuditMemberRoleChange   = "member.role_change"
+	AuditRequestCreate      = "request.create"
+	AuditRequestReview      = "request.review"
+	AuditInviteLinkCreate   = "invite_link.create"
+	AuditInviteLinkRevoke   = "invite_link.revoke"
+	AuditEmailInviteCreate  = "email_invite.create"
+	AuditEmailInviteRevoke  = "email_invite.revoke"
+	AuditJoinRuleCreate     = "join_rule.create"
+	AuditJoinRuleDelete     = "join_rule.delete"
+	AuditRoleCreate         = "role.create"
+	AuditRoleUpdate         = "role.update"
+	AuditRoleDelete         = "role.delete"
+	AuditMeetingCreate      = "meeting.create"
+	AuditMeetingUpdate      = "meeting.update"
+	AuditMeetingStart       = "meeting.start"
+	AuditMeetingEnd         = "meeting.end"
+	AuditMeetingDelete      = "meeting.delete"
+	AuditAttendanceStart    = "attendance.start"
+	AuditAttendanceEnd      = "attendance.end"
+	AuditAttendanceOverride = "attendance.override"
+	AuditAttendanceRemove   = "attendance.remove"
+	AuditAttendanceImport   = "attendance.import"
+	AuditSeriesCreate       = "meeting_series.create"
+	AuditSeriesUpdate       = "meeting_series.update"
+	AuditSeriesDelete       = "meeting_series.delete"
+	AuditLeaveReview        = "leave.review"
+)
+
+// Types of the targets of audited actions.
+const (
+	AuditTargetTeam        = "team"
+	AuditTargetMember      = "member"
+	AuditTargetRequest     = "request"
+	AuditTargetInviteLink  = "invite_link"
+	AuditTargetEmailInvite = "email_invite"
+	AuditTargetJoinRule    = "join_rule"
+	AuditTargetRole        = "role"
+	AuditTargetMeeting     = "meeting"
+	AuditTargetAttendance  = "attendance"
+	AuditTargetSeries      = "meeting_series"
+	AuditTargetLeave       = "leave_request"
+)
+
+// AuditActor is who did an audited action, and from where. A zero UserID is the app itself, such as scheduled meeting transitions.
+type AuditActor struct {
+	UserID uint
+	IP     string
+}
+
+// ErrAuditLogAppendOnly is returned when changing or deleting an audit log entry.
+var ErrAuditLogAppendOnly = errors.New("audit log entries cannot be changed or deleted")
+
+// AuditLog records an administrative action on a team. Entries are only ever added, and are kept after the team is deleted.
+type AuditLog struct {
+	ID         uint      `gorm:"primarykey"`
+	CreatedAt  time.Time `gorm:"index"`
+	TeamID     uint      `gorm:"not null;index"`
+	ActorID    uint      `gorm:"not null;index"`
+	IP         string    `gorm:"size:45"`
+	Action     string    `gorm:"size:50;not null;index"`
+	TargetType string    `gorm:"size:30"`
+	TargetID   uint
+	Before     interface{} `gorm:"serializer:json"` // snapshot of the target before the action, nil if created by it
+	After      interface{} `gorm:"serializer:json"` // snapshot of the target after the action, nil if deleted by it
+}
+
+// gorm on update hook to keep the log append-only
+func (l *AuditLog) BeforeUpdate(tx *gorm.DB) error {
+	return ErrAuditLogAppendOnly
+}
+
+// gorm on delete hook to keep the log append-only
+func (l *AuditLog) BeforeDelete(tx *gorm.DB) error {
+	return ErrAuditLogAppendOnly
+}
+
+// AuditLogFilter selects a page of the audit log of a team. Zero values match any.
+type AuditLogFilter struct {
+	TeamID   uint
+	ActorID  *uint
+	Action   string // an action, or a prefix such as "member." for every action on members
+	From     *time.Time
+	To       *time.Time
+	Page     int // from 1
+	PageSize int
+}
+
+type AuditLogPage struct {
+	Entries  []AuditLog
+	Total    int64
+	Page     int
+	PageSize int
+}
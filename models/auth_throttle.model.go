@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+// AuthThrottle counts the recent failed attempts of one key, such as an account, an IP address or an OTP,
+// at logging in or guessing a code. It is kept in the database so every replica sees the same counts.
+type AuthThrottle struct {
+	ID            uint `gorm:"primarykey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Key           string    `gorm:"column:throttle_key;size:255;not null;uniqueIndex"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"index"`
+	BlockedUntil  time.Time // no attempts are allowed before, after backing off or a lockout
+}
+
+// RetryAfter returns how long until the key can be tried again, 0 if it can be now.
+func (t *AuthThrottle) RetryAfter(now time.Time) time.Duration {
+	if now.Before(t.BlockedUntil) {
+		return t.BlockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// ThrottlePolicy decides how long a key is blocked after a failed attempt.
+type ThrottlePolicy struct {
+	FreeFailures    int           // failures allowed before backing off
+	BaseDelay       time.Duration // wait after the first failure past FreeFailures, doubled on each one after
+	MaxDelay        time.Duration // longest wait while backing off
+	LockoutFailures int           // failures that lock the key out, 0 to never lock it out
+	LockoutDuration time.Duration
+	Window          time.Duration // failures are forgotten after this long without one
+}
+
+// Fail returns the throttle after another failure at now, and whether it just locked out.
+func (p ThrottlePolicy) Fail(t AuthThrottle, now time.Time) (AuthThrottle, bool) {
+	if now.Sub(t.LastFailureAt) > p.Window {
+		t.Failures = 0
+	}
+	t.Failures++
+	t.LastFailureAt = now
+
+	if p.LockoutFailures > 0 && t.Failures >= p.LockoutFailures {
+		t.BlockedUntil = now.Add(p.LockoutDuration)
+		return t, t.Failures == p.LockoutFailures
+	}
+	if t.Failures > p.FreeFailures && p.BaseDelay > 0 {
+		delay := p.MaxDelay
+		if doublings := t.Failures - p.FreeFailures - 1; doublings < 30 && p.BaseDelay<<doublings < p.MaxDelay {
+			delay = p.BaseDelay << doublings
+		}
+		t.BlockedUntil = now.Add(delay)
+	}
+	return t, false
+}
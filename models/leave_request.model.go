@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LeaveRequestPending  = "pending"
+	LeaveRequestApproved = "approved"
+	LeaveRequestRejected = "rejected"
+)
+
+// LeaveRequest is a team member asking to be excused from a meeting, before or after it.
+// Members with an approved request who did not attend are excused instead of absent.
+type LeaveRequest struct {
+	gorm.Model
+	TeamID       uint   `gorm:"not null"`
+	MeetingID    uint   `gorm:"not null;index"`
+	UserID       uint   `gorm:"not null;index"`
+	Reason       string `gorm:"size:500;not null"`
+	Status       string `gorm:"not null;default:'pending'"` // 'pending', 'approved', 'rejected'
+	ReviewedByID uint   // admin who approved or rejected the request
+	ReviewedAt   *time.Time
+	ReviewNote   string `gorm:"size:500"`
+}
+
+type LeaveRequestResponse struct {
+	Request LeaveRequest
+	User    User
+}
@@ -14,22 +14,114 @@ type Location struct {
 	Altitude  float64
 }
 
+// Geofence restricts where members can mark attendance from, relative to the meeting Location.
+// A zero Radius disables the check, a zero AltitudeTolerance skips the altitude check (useful for multi-floor buildings when set).
+type Geofence struct {
+	Radius            float64 // in metres, great-circle distance from Location
+	AltitudeTolerance float64 // in metres, above or below Location.Altitude
+}
+
+// ReportedLocation is the position a member's device reports while marking attendance.
+type ReportedLocation struct {
+	Location
+	Accuracy float64 // in metres, as reported by the device, required for meetings with a geofence
+}
+
+// States of the scheduled transitions of a meeting, in the order they happen.
+const (
+	ScheduleStatePending         = ""                 // nothing done yet
+	ScheduleStateStarted         = "started"          // meeting (and attendance, if scheduled) started at StartTime
+	ScheduleStateAttendanceEnded = "attendance_ended" // attendance window closed
+	ScheduleStateEnded           = "ended"            // meeting ended after its duration
+)
+
+// MeetingSchedule makes the meeting start, take attendance and end on its own, instead of by the manual PATCH routes.
+// Manual transitions still work alongside it. AttendanceMinutes and DurationMinutes are counted from StartTime and need AutoStart.
+type MeetingSchedule struct {
+	AutoStart         bool   // start the meeting at StartTime
+	AttendanceMinutes uint   // if set, attendance opens at StartTime and closes this many minutes later
+	DurationMinutes   uint   // if set, the meeting ends this many minutes after StartTime
+	State             string `gorm:"size:20;default:''" json:"-"` // last scheduled transition done, one of the ScheduleState constants
+}
+
+// Statuses of a MeetingAttendance, decided by the AttendancePolicy when it is marked.
+const (
+	AttendanceStatusOnTime = "on_time"
+	AttendanceStatusLate   = "late"
+	AttendanceStatusAbsent = "absent" // marked too late to count, kept for the record
+)
+
+// AttendanceStatusExcused is the status listed for a meeting a member did not attend with an approved leave request. It is never stored.
+const AttendanceStatusExcused = "excused"
+
+// AttendancePolicy decides how late a member can mark attendance, counted from when attendance started.
+// Teams have a default policy, which a meeting can override by enabling its own.
+// Without an enabled policy, attendance marked while it is open is on time and late after it closed.
+type AttendancePolicy struct {
+	Enabled      bool
+	GraceMinutes uint // on time if marked within this many minutes, while attendance is open
+	LateMinutes  uint // late if marked within this many minutes, absent after. 0 allows late attendance until the meeting ends
+}
+
+// Validate checks that the late window does not end before the grace period.
+func (p AttendancePolicy) Validate() error {
+	if p.LateMinutes > 0 && p.LateMinutes < p.GraceMinutes {
+		return errors.New("attendance policy late minutes cannot be less than grace minutes")
+	}
+	return nil
+}
+
+// Evaluate returns the status of attendance marked at markedAt for attendance that started at attendanceStart, and how many whole minutes after the start it was marked.
+// Minutes late are 0 for attendance on time.
+func (p AttendancePolicy) Evaluate(attendanceStart, markedAt time.Time, attendanceOpen bool) (string, uint) {
+	var minutes uint
+	if markedAt.After(attendanceStart) {
+		minutes = uint(markedAt.Sub(attendanceStart) / time.Minute)
+	}
+
+	if !p.Enabled {
+		if attendanceOpen {
+			return AttendanceStatusOnTime, 0
+		}
+		return AttendanceStatusLate, minutes
+	}
+
+	elapsed := markedAt.Sub(attendanceStart)
+	switch {
+	case attendanceOpen && elapsed <= time.Duration(p.GraceMinutes)*time.Minute:
+		return AttendanceStatusOnTime, 0
+	case p.LateMinutes == 0 || elapsed <= time.Duration(p.LateMinutes)*time.Minute:
+		return AttendanceStatusLate, minutes
+	default:
+		return AttendanceStatusAbsent, minutes
+	}
+}
+
 // Members can start marking attendance after meeting has been started (MeetingPeriod = true), and attendance is open (AttendancePeriod = true). Their attendance will be OnTime = true.
 // If they mark attendance after attendance closed (AttendancePeriod = false), but while meeting still ongoing (MeetingPeriod = True), their attendance will be OnTime = false.
+// With an AttendancePolicy, how long after AttendanceStartedAt they mark decides if they are on time, late or absent instead.
 // They cannot mark attendance after meeting has ended (MeetingOver = true), which is set when MeetingPeriod = true -> false.
 // A meeting can only be deleted if MeetingPeriod = false and AttendancePeriod = false and MeetingOver = false. I.e., meeting hasn't started yet.
 type Meeting struct {
 	gorm.Model
-	TeamID           uint      `gorm:"not null"`
-	Title            string    `gorm:"size:255;not null"`
-	Description      string    `gorm:"size:255;not null"`
-	Venue            string    `gorm:"size:255;not null"`
-	Location         Location  `gorm:"embedded"`
-	StartTime        time.Time `gorm:"not null"` // Unix timestamp, for info purposes only. Attendance will start on manual start.
-	MeetingPeriod    bool      `gorm:"default:false"`
-	AttendancePeriod bool      `gorm:"default:false"` // Members can mark attendance while true. Can only be started after meeting has started. Is ended alongside meeting end if not ended before.
-	MeetingOver      bool      `gorm:"default:false"` // Will not show meeting on dashboard if true, can be seen in some history tab
-	AttendanceOver   bool      `gorm:"default:false"`
+	TeamID              uint             `gorm:"not null"`
+	Title               string           `gorm:"size:255;not null"`
+	Description         string           `gorm:"size:255;not null"`
+	Venue               string           `gorm:"size:255;not null"`
+	Location            Location         `gorm:"embedded"`
+	Geofence            Geofence         `gorm:"embedded;embeddedPrefix:geofence_"`
+	StartTime           time.Time        `gorm:"not null"` // Unix timestamp. Attendance will start on manual start, or at StartTime if Schedule.AutoStart.
+	MeetingPeriod       bool             `gorm:"default:false"`
+	AttendancePeriod    bool             `gorm:"default:false"` // Members can mark attendance while true. Can only be started after meeting has started. Is ended alongside meeting end if not ended before.
+	MeetingOver         bool             `gorm:"default:false"` // Will not show meeting on dashboard if true, can be seen in some history tab
+	AttendanceOver      bool             `gorm:"default:false"`
+	AttendanceSecret    string           `gorm:"size:64" json:"-"` // Generated on attendance start, used to derive the rotating attendance codes. Never sent to clients.
+	AttendanceStartedAt *time.Time       // when attendance was last started, nil if it never was
+	AttendancePolicy    AttendancePolicy `gorm:"embedded;embeddedPrefix:policy_"` // overrides the team's policy when enabled
+	Schedule            MeetingSchedule  `gorm:"embedded;embeddedPrefix:schedule_"`
+	SeriesID            uint             `gorm:"index:idx_meeting_series_occurrence,unique,where:series_id <> 0"` // MeetingSeries this meeting is an occurrence of, 0 if it is a one-off meeting
+	OccurrenceTime      time.Time        `gorm:"index:idx_meeting_series_occurrence,unique,where:series_id <> 0"` // StartTime the series scheduled this occurrence for, kept when the occurrence is edited
+	Imported            bool             `gorm:"default:false"`                                                   // created by an attendance import, in the past and already over
 }
 
 // add isvalid check to model to check if venue, title, description are not empty strings or missing
@@ -40,24 +132,107 @@ func (m *Meeting) BeforeCreate(tx *gorm.DB) error {
 	if m.TeamID == 0 {
 		return gorm.ErrInvalidData
 	}
-	if m.Venue == "" || m.Title == "" || m.Description == "" {
-		return errors.New("meeting venue, title or description cannot be empty")
+	if err := m.ValidateDetails(); err != nil {
+		return err
+	}
+	if m.Imported {
+		// historical meetings are created as they ended
+		if m.StartTime.After(time.Now()) || !m.MeetingOver || !m.AttendanceOver || m.MeetingPeriod || m.AttendancePeriod {
+			return errors.New("imported meetings must be in the past and over")
+		}
+		return nil
 	}
 	if m.StartTime.Before(time.Now()) {
 		return errors.New("meeting start time cannot be in the past")
 	}
-	if m.MeetingPeriod || m.AttendancePeriod || m.MeetingOver || m.AttendanceOver {
+	if m.MeetingPeriod || m.AttendancePeriod || m.MeetingOver || m.AttendanceOver || m.Schedule.State != ScheduleStatePending {
 		return errors.New("meeting cannot be created with any of the periods set to true")
 	}
 	return nil
 }
 
+// ValidateDetails checks the fields that can be edited after creation, used on create and update.
+func (m *Meeting) ValidateDetails() error {
+	if m.Venue == "" || m.Title == "" || m.Description == "" {
+		return errors.New("meeting venue, title or description cannot be empty")
+	}
+	if m.Geofence.Radius < 0 || m.Geofence.AltitudeTolerance < 0 {
+		return errors.New("meeting geofence radius and altitude tolerance cannot be negative")
+	}
+	if !m.Schedule.AutoStart && (m.Schedule.AttendanceMinutes > 0 || m.Schedule.DurationMinutes > 0) {
+		return errors.New("meeting attendance window and duration can only be scheduled along with auto start")
+	}
+	if m.Schedule.DurationMinutes > 0 && m.Schedule.AttendanceMinutes > m.Schedule.DurationMinutes {
+		return errors.New("meeting attendance window cannot be longer than the meeting")
+	}
+	return m.AttendancePolicy.Validate()
+}
+
+// NextScheduledTransition returns the next scheduled transition of the meeting and when it is due, false if there is none left.
+func (m *Meeting) NextScheduledTransition() (string, time.Time, bool) {
+	if !m.Schedule.AutoStart {
+		return "", time.Time{}, false
+	}
+	attendanceEnd := m.StartTime.Add(time.Duration(m.Schedule.AttendanceMinutes) * time.Minute)
+	end := m.StartTime.Add(time.Duration(m.Schedule.DurationMinutes) * time.Minute)
+
+	switch m.Schedule.State {
+	case ScheduleStatePending:
+		return ScheduleStateStarted, m.StartTime, true
+	case ScheduleStateStarted:
+		if m.Schedule.AttendanceMinutes > 0 {
+			return ScheduleStateAttendanceEnded, attendanceEnd, true
+		}
+		if m.Schedule.DurationMinutes > 0 {
+			return ScheduleStateEnded, end, true
+		}
+	case ScheduleStateAttendanceEnded:
+		if m.Schedule.DurationMinutes > 0 {
+			return ScheduleStateEnded, end, true
+		}
+	}
+	return "", time.Time{}, false
+}
+
+// MeetingUpdate holds the meeting details to change, nil fields are left as they are.
+// Once a meeting has started (MeetingPeriod = true) or is over, only Title and Description can change.
+// The State of a changed Schedule is kept.
+type MeetingUpdate struct {
+	Title            *string           `json:"title"`
+	Description      *string           `json:"description"`
+	Venue            *string           `json:"venue"`
+	Location         *Location         `json:"location"`
+	Geofence         *Geofence         `json:"geofence"`
+	StartTime        *time.Time        `json:"startTime"`
+	Schedule         *MeetingSchedule  `json:"schedule"`
+	AttendancePolicy *AttendancePolicy `json:"attendancePolicy"`
+}
+
 type MeetingAttendance struct {
 	gorm.Model
-	UserID             uint      `gorm:"primaryKey;not null"`
-	MeetingID          uint      `gorm:"primaryKey;not null"`
-	AttendanceMarkedAt time.Time `gorm:"not null"`
-	OnTime             bool
+	UserID             uint       `gorm:"primaryKey;not null"`
+	MeetingID          uint       `gorm:"primaryKey;not null"`
+	AttendanceMarkedAt time.Time  `gorm:"not null"`
+	OnTime             bool       // true only if Status is on time, kept for clients that do not know Status
+	Status             string     `gorm:"size:20"` // one of the AttendanceStatus constants, empty for records marked before statuses existed
+	MinutesLate        uint       // whole minutes after attendance started that it was marked, 0 if on time
+	MarkedLocation     Location   `gorm:"embedded;embeddedPrefix:marked_"` // position submitted by the member, kept for audits
+	Accuracy           float64    // accuracy of MarkedLocation in metres
+	Distance           float64    // computed distance in metres from the meeting location
+	OverriddenByID     uint       // admin who last manually changed or removed this record, 0 if only marked by the member
+	OverrideReason     string     `gorm:"size:255"`
+	OverriddenAt       *time.Time // when the record was last manually changed or removed
+}
+
+// AttendanceStatus returns the Status of the record, falling back to OnTime for records without one.
+func (ma *MeetingAttendance) AttendanceStatus() string {
+	if ma.Status != "" {
+		return ma.Status
+	}
+	if ma.OnTime {
+		return AttendanceStatusOnTime
+	}
+	return AttendanceStatusLate
 }
 
 func (ma *MeetingAttendance) BeforeCreate(tx *gorm.DB) error {
@@ -75,11 +250,82 @@ type MeetingAttendanceListResponse struct {
 	MeetingID          uint
 	AttendanceMarkedAt time.Time
 	OnTime             bool
+	Status             string
+	MinutesLate        uint
+	MarkedLocation     Location
+	Accuracy           float64
+	Distance           float64
+	OverriddenByID     uint
+	OverrideReason     string
+	OverriddenAt       *time.Time
 	User               User
 	MeetingName        string
 	TeamName           string
 }
 
+// AttendanceCodeResponse is the current rotating attendance code of a meeting, for admins to display (e.g. as a QR code).
+type AttendanceCodeResponse struct {
+	Code      string
+	Period    int // seconds after which the code rotates
+	ExpiresAt time.Time
+}
+
+// MeetingSummaryResponse splits the members of a team as of a meeting by how they attended it.
+// Absent and Excused only list members who had joined the team by the meeting's StartTime, or whose absence was recorded.
+// Members who marked attendance too late for the attendance policy are Absent, not Present.
+// Members who did not attend but had a leave request approved are Excused instead of Absent.
+type MeetingSummaryResponse struct {
+	MeetingID    uint
+	MeetingName  string
+	TeamName     string
+	Expected     int // members of the team as of the meeting, and those with attendance recorded
+	Present      []MeetingAttendanceListResponse
+	OnTime       []MeetingAttendanceListResponse
+	Late         []MeetingAttendanceListResponse
+	Absent       []User
+	Excused      []User
+	PresentCount int
+	OnTimeCount  int
+	LateCount    int
+	AbsentCount  int
+	ExcusedCount int
+}
+
+// ImportedAttendance is an attendance record to import, in Meeting, an existing meeting or one created by the same import.
+type ImportedAttendance struct {
+	Attendance MeetingAttendance
+	Meeting    *Meeting
+}
+
+// AttendanceImportRow is the outcome of importing a row of an attendance CSV.
+type AttendanceImportRow struct {
+	Row     int // line of the file, the header is line 1
+	Email   string
+	Meeting string
+	Date    string
+	Status  string
+	Error   string // empty if the row can be imported
+}
+
+// AttendanceImportReport is the outcome of an attendance import, or of checking one in a dry run.
+// Nothing is imported unless every row is valid.
+type AttendanceImportReport struct {
+	DryRun          bool
+	Imported        bool
+	Rows            []AttendanceImportRow
+	ErrorCount      int
+	MeetingsCreated int // meetings the file has attendance for that did not exist, created in the past
+	AttendanceAdded int // absent rows included
+}
+
+// AttendanceRegister is what an attendance export covers, the meetings of a team as columns and its members during them as rows.
+// Members are read while the register is written.
+type AttendanceRegister struct {
+	TeamID   uint
+	TeamName string
+	Meetings []Meeting // ordered by StartTime
+}
+
 type UserUpcomingMeetingsListResponse struct {
 	Meeting Meeting
 	Team    Team
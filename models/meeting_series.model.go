@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/utils/rrule"
+	"gorm.io/gorm"
+)
+
+// Scopes of an edit to a meeting that is part of a series.
+const (
+	SeriesEditThis      = "this"      // only the chosen occurrence
+	SeriesEditFollowing = "following" // the chosen occurrence and all after it
+)
+
+// MeetingSeries is a recurring meeting. Its occurrences are materialized as Meeting rows (with SeriesID set) a rolling horizon ahead.
+// Occurrences are computed from StartTime and RRule, skipping the dates in ExDates.
+type MeetingSeries struct {
+	gorm.Model
+	TeamID            uint             `gorm:"not null"`
+	Title             string           `gorm:"size:255;not null"`
+	Description       string           `gorm:"size:255;not null"`
+	Venue             string           `gorm:"size:255;not null"`
+	Location          Location         `gorm:"embedded"`
+	Geofence          Geofence         `gorm:"embedded;embeddedPrefix:geofence_"`
+	Schedule          MeetingSchedule  `gorm:"embedded;embeddedPrefix:schedule_"` // copied to every meeting, State is unused
+	AttendancePolicy  AttendancePolicy `gorm:"embedded;embeddedPrefix:policy_"`   // copied to every meeting
+	StartTime         time.Time        `gorm:"not null"`                          // first occurrence, later occurrences keep its time of day
+	RRule             string           `gorm:"size:255;not null"`                 // e.g. FREQ=WEEKLY;BYDAY=TU,TH;COUNT=20
+	ExDates           string           `gorm:"size:1024"`                         // comma separated dates (YYYY-MM-DD) without an occurrence
+	MaterializedUntil time.Time        // occurrences up to this time have been created as meetings
+}
+
+func (ms *MeetingSeries) BeforeCreate(tx *gorm.DB) error {
+	if ms.TeamID == 0 {
+		return gorm.ErrInvalidData
+	}
+	meeting := Meeting{Title: ms.Title, Description: ms.Description, Venue: ms.Venue, Geofence: ms.Geofence, Schedule: ms.Schedule, AttendancePolicy: ms.AttendancePolicy}
+	if err := meeting.ValidateDetails(); err != nil {
+		return err
+	}
+	if ms.StartTime.IsZero() {
+		return errors.New("meeting series start time cannot be empty")
+	}
+	if _, err := rrule.Parse(ms.RRule); err != nil {
+		return err
+	}
+	for _, date := range ms.ExceptionDates() {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return errors.New("meeting series exception dates must be formatted as YYYY-MM-DD")
+		}
+	}
+	return nil
+}
+
+// ExceptionDates returns the dates in ExDates.
+func (ms *MeetingSeries) ExceptionDates() []string {
+	if ms.ExDates == "" {
+		return nil
+	}
+	return strings.Split(ms.ExDates, ",")
+}
+
+// IsException reports whether there is no occurrence on the date of t, in the time zone of the series StartTime.
+func (ms *MeetingSeries) IsException(t time.Time) bool {
+	date := t.In(ms.StartTime.Location()).Format("2006-01-02")
+	for _, exDate := range ms.ExceptionDates() {
+		if exDate == date {
+			return true
+		}
+	}
+	return false
+}
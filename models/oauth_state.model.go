@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// OAuthState is issued when a Google login starts and used up when it finishes, so the authorization code
+// must come from a login this server started. Only the hash of the state is stored.
+type OAuthState struct {
+	ID            uint `gorm:"primarykey"`
+	CreatedAt     time.Time
+	StateHash     string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Nonce         string     `gorm:"size:64;not null"` // the ID token must carry it, so it cannot be replayed from another login
+	CodeChallenge string     `gorm:"size:128"`         // S256 PKCE challenge of the app's verifier, empty for the web login
+	ExpiresAt     time.Time  `gorm:"index"`
+	ConsumedAt    *time.Time // set once used
+}
+
+// Mobile reports whether the state was issued to an app, which exchanges the code itself with a PKCE verifier.
+func (s *OAuthState) Mobile() bool {
+	return s.CodeChallenge != ""
+}
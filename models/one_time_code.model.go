@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Purposes of one-time codes, a code only works for the purpose it was sent for.
+const (
+	OneTimeCodeVerification = "verification" // verifying the email of a new account
+	OneTimeCodeForgot       = "forgot"       // setting a new password after forgetting it
+	OneTimeCodeDeletion     = "deletion"     // confirming the deletion of an account
+	OneTimeCodeLogin        = "login"        // logging in with a magic link instead of a password
+)
+
+// OneTimeCode is a code sent by email to prove the user can read it. Only its hash is stored,
+// and it stops working once used, once expired, or after too many wrong attempts.
+type OneTimeCode struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Purpose    string     `gorm:"size:20;not null;index:idx_one_time_code_email"`
+	Email      string     `gorm:"size:255;not null;index:idx_one_time_code_email"`
+	CodeHash   string     `gorm:"size:255;not null" json:"-"`
+	ExpiresAt  time.Time  `gorm:"index"`
+	Attempts   int        `gorm:"not null;default:0"` // wrong codes tried
+	ConsumedAt *time.Time // set once used
+}
+
+// SetCode stores the hash of the code.
+func (o *OneTimeCode) SetCode(code string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	o.CodeHash = string(hash)
+	return nil
+}
+
+// Matches reports whether code is the code of the one-time code.
+func (o *OneTimeCode) Matches(code string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(o.CodeHash), []byte(code)) == nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// Session is a login of a user on a device. Its short-lived access tokens are renewed with a refresh token,
+// which is replaced on every use, and it ends when it expires unused or is revoked by logging out or changing the password.
+type Session struct {
+	ID                uint `gorm:"primarykey"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	UserID            uint       `gorm:"not null;index"`
+	RefreshTokenHash  string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	PreviousTokenHash string     `gorm:"size:64;index" json:"-"` // refresh token before the last rotation, using it again means it was stolen
+	Device            string     `gorm:"size:255"`               // user agent of the last refresh
+	IP                string     `gorm:"size:45"`                // address of the last refresh
+	LastSeenAt        time.Time  // login or last refresh
+	ExpiresAt         time.Time  `gorm:"index"` // pushed back on every refresh
+	RevokedAt         *time.Time `gorm:"index"`
+}
+
+// Active reports whether the session can still be used at now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SessionTokens are issued on login and on every refresh of a session.
+type SessionTokens struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"` // seconds the access token is valid for
+}
+
+type SessionResponse struct {
+	Session Session
+	Current bool // the session of the request
+}
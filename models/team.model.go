@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/utils/team"
 	"gorm.io/gorm"
 )
@@ -11,8 +13,16 @@ type Team struct {
 	Description  string
 	SuperAdminID uint // Foreign key to the user who is the super admin of this team
 	// Meetings    []Meeting
-	Protected bool   `gorm:"default:false"`   // If true, then users will need to be approved by the super admin to join this team
-	Invite    string `gorm:"unique;not null"` // Invite code for this team, length 10
+	Protected             bool             `gorm:"default:false"`                   // If true, then users will need to be approved by the super admin to join this team
+	Invite                string           `gorm:"unique;not null"`                 // Invite code for this team, length 10
+	AttendancePolicy      AttendancePolicy `gorm:"embedded;embeddedPrefix:policy_"` // default for meetings of this team that do not enable their own
+	ArchivedAt            *time.Time       // set when the team is archived instead of deleted, archived teams are read-only
+	RequireAdminTwoFactor bool             `gorm:"default:false"` // If true, then members with a role beyond member need two-factor authentication for admin actions
+}
+
+// Archived reports whether the team has been archived.
+func (t *Team) Archived() bool {
+	return t.ArchivedAt != nil
 }
 
 // gorm on create hook to generate invite code if not provided
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Statuses of a TeamEmailInvite.
+const (
+	TeamEmailInvitePending  = "pending"
+	TeamEmailInviteAccepted = "accepted"
+	TeamEmailInviteDeclined = "declined"
+	TeamEmailInviteRevoked  = "revoked"
+)
+
+// TeamEmailInvite invites an email address to a team. Users with the email can accept or decline it,
+// and an email that has no account yet joins the team once it signs up and verifies.
+type TeamEmailInvite struct {
+	gorm.Model
+	TeamID      uint       `gorm:"not null;index"`
+	Email       string     `gorm:"size:255;not null;index"`
+	InvitedByID uint       `gorm:"not null"`
+	Role        string     `gorm:"size:20;not null;default:'member'"` // role given on accepting, member or admin
+	Status      string     `gorm:"size:20;not null;default:'pending'"`
+	RespondedAt *time.Time // when accepted, declined or revoked
+}
+
+// TeamEmailInviteResult is the outcome of inviting one of the emails of an invite request.
+type TeamEmailInviteResult struct {
+	Email  string
+	Status string // invited, already_member, already_invited or invalid
+	Invite *TeamEmailInvite
+}
+
+type TeamEmailInviteResponse struct {
+	Invite TeamEmailInvite
+	Team   Team
+}
@@ -10,7 +10,18 @@ const (
 
 type TeamEntryRequest struct {
 	gorm.Model
-	TeamID uint   `gorm:"not null"`
-	UserID uint   `gorm:"not null"`
-	Status string `gorm:"not null;default:'pending'"` // 'pending', 'approved', 'rejected'
+	TeamID     uint   `gorm:"not null"`
+	UserID     uint   `gorm:"not null"`
+	Status     string `gorm:"not null;default:'pending'"` // 'pending', 'approved', 'rejected'
+	Role       string `gorm:"size:20"`                    // role given on approval, member if empty, set by the invite link used
+	JoinRuleID uint   // the join rule that auto-approved the request, 0 if reviewed by an admin
+}
+
+// TeamEntryRequestReviewResult is the outcome of one request of a bulk review.
+type TeamEntryRequestReviewResult struct {
+	RequestID uint
+	UserID    uint
+	Updated   bool
+	Status    string // status of the request after the review
+	Error     string // why the request was not updated
 }
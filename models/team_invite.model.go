@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/utils/team"
+	"gorm.io/gorm"
+)
+
+// TeamInviteLink is one of many invite links of a team, next to the team's permanent Invite code.
+// Each link can expire, be limited to a number of uses and be revoked on its own.
+type TeamInviteLink struct {
+	gorm.Model
+	TeamID         uint       `gorm:"not null;index"`
+	Code           string     `gorm:"size:32;unique;not null"`
+	CreatedByID    uint       `gorm:"not null"`
+	ExpiresAt      *time.Time // never expires if nil
+	MaxUses        uint       // unlimited if 0
+	Uses           uint       `gorm:"not null;default:0"`
+	Role           string     `gorm:"size:20;not null;default:'member'"` // role of members who join with the link, member or admin
+	BypassApproval bool       `gorm:"default:false"`                     // join protected teams without an entry request
+	RevokedAt      *time.Time
+}
+
+// gorm on create hook to generate the link code if not provided
+func (l *TeamInviteLink) BeforeCreate(tx *gorm.DB) error {
+	if l.Code == "" {
+		code, err := team.GenerateInviteLinkCode()
+		if err != nil {
+			return err
+		}
+		l.Code = code
+	}
+	if l.Role == "" {
+		l.Role = MemberRole
+	}
+	return l.Validate()
+}
+
+// Validate checks the role of the link.
+func (l *TeamInviteLink) Validate() error {
+	if l.Role != MemberRole && l.Role != AdminRole {
+		return errors.New("invite link role must be member or admin")
+	}
+	return nil
+}
+
+// Usable returns why the link cannot be used at now, or nil if it can.
+func (l *TeamInviteLink) Usable(now time.Time) error {
+	if l.RevokedAt != nil {
+		return errors.New("invite link has been revoked")
+	}
+	if l.ExpiresAt != nil && !now.Before(*l.ExpiresAt) {
+		return errors.New("invite link has expired")
+	}
+	if l.MaxUses > 0 && l.Uses >= l.MaxUses {
+		return errors.New("invite link has been used up")
+	}
+	return nil
+}
+
+// Outcomes of using an invite link.
+const (
+	TeamInviteJoined    = "joined"    // became a member
+	TeamInviteRequested = "requested" // made an entry request to a protected team
+)
+
+// TeamInviteUse records who used which invite link.
+type TeamInviteUse struct {
+	gorm.Model
+	InviteLinkID uint   `gorm:"not null;index"`
+	TeamID       uint   `gorm:"not null"`
+	UserID       uint   `gorm:"not null"`
+	Outcome      string `gorm:"size:20;not null"`
+}
+
+type TeamInviteUseResponse struct {
+	Use  TeamInviteUse
+	User User
+}
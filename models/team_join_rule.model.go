@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Types of TeamJoinRule.
+const (
+	JoinRuleEmailDomain = "email_domain" // users whose verified email is at Domain
+	JoinRuleRoster      = "roster"       // users whose verified email is in the rule's TeamRosterEntries
+)
+
+// TeamJoinRule auto-approves the entry requests of matching users to a protected team.
+type TeamJoinRule struct {
+	gorm.Model
+	TeamID      uint   `gorm:"not null;index"`
+	Type        string `gorm:"size:20;not null"`
+	Domain      string `gorm:"size:255"` // without the @, for email_domain rules
+	CreatedByID uint   `gorm:"not null"`
+	RosterSize  int    `gorm:"-"` // number of emails of a roster rule, filled in when listed
+}
+
+// gorm on create hook to normalise the domain before checking the rule
+func (r *TeamJoinRule) BeforeCreate(tx *gorm.DB) error {
+	r.Domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(r.Domain), "@"))
+	return r.Validate()
+}
+
+// Validate checks the type of the rule, and that email_domain rules have a domain.
+func (r *TeamJoinRule) Validate() error {
+	switch r.Type {
+	case JoinRuleEmailDomain:
+		if r.Domain == "" || strings.ContainsAny(r.Domain, "@ ") || !strings.Contains(r.Domain, ".") {
+			return errors.New("email domain rule needs a domain such as example.com")
+		}
+	case JoinRuleRoster:
+	default:
+		return errors.New("join rule type must be email_domain or roster")
+	}
+	return nil
+}
+
+// MatchesDomain reports whether the email is at the domain of an email_domain rule.
+func (r *TeamJoinRule) MatchesDomain(email string) bool {
+	return r.Type == JoinRuleEmailDomain && strings.HasSuffix(strings.ToLower(email), "@"+r.Domain)
+}
+
+// TeamRosterEntry is an email listed in the roster of a TeamJoinRule.
+type TeamRosterEntry struct {
+	gorm.Model
+	JoinRuleID uint   `gorm:"not null;index"`
+	Email      string `gorm:"size:255;not null;index"`
+}
@@ -14,3 +14,10 @@ type TeamMember struct {
 	UserID uint `gorm:"primaryKey"`
 	Role   string
 }
+
+// TeamMemberUser is a membership of a team with the name and email of its user.
+type TeamMemberUser struct {
+	TeamMember
+	Name  string
+	Email string
+}
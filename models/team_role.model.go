@@ -0,0 +1,155 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+
+	"gorm.io/gorm"
+)
+
+// Permissions that can be required of the role of a team member.
+const (
+	PermissionTeamView           = "team.view"
+	PermissionTeamUpdate         = "team.update"
+	PermissionTeamDelete         = "team.delete"
+	PermissionTeamHandover       = "team.handover"
+	PermissionRolesManage        = "roles.manage"
+	PermissionMembersManage      = "members.manage"
+	PermissionMembersKick        = "members.kick"
+	PermissionRequestsReview     = "requests.review"
+	PermissionInvitesManage      = "invites.manage"
+	PermissionJoinRulesManage    = "join_rules.manage"
+	PermissionMeetingView        = "meeting.view"
+	PermissionMeetingCreate      = "meeting.create"
+	PermissionMeetingUpdate      = "meeting.update"
+	PermissionMeetingDelete      = "meeting.delete"
+	PermissionMeetingRun         = "meeting.run"
+	PermissionAttendanceStart    = "attendance.start"
+	PermissionAttendanceMark     = "attendance.mark"
+	PermissionAttendanceOverride = "attendance.override"
+	PermissionAttendanceImport   = "attendance.import"
+	PermissionReportsView        = "reports.view"
+	PermissionLeaveSubmit        = "leave.submit"
+	PermissionLeaveReview        = "leave.review"
+	PermissionAuditView          = "audit.view"
+)
+
+// AllPermissions lists every permission, the permissions of the super admin.
+var AllPermissions = []string{
+	PermissionTeamView, PermissionTeamUpdate, PermissionTeamDelete, PermissionTeamHandover,
+	PermissionRolesManage, PermissionMembersManage, PermissionMembersKick,
+	PermissionRequestsReview, PermissionInvitesManage, PermissionJoinRulesManage,
+	PermissionMeetingView, PermissionMeetingCreate, PermissionMeetingUpdate, PermissionMeetingDelete, PermissionMeetingRun,
+	PermissionAttendanceStart, PermissionAttendanceMark, PermissionAttendanceOverride, PermissionAttendanceImport,
+	PermissionReportsView, PermissionLeaveSubmit, PermissionLeaveReview, PermissionAuditView,
+}
+
+// memberPermissions are the permissions of members, which every custom role also has.
+var memberPermissions = []string{
+	PermissionTeamView, PermissionMeetingView, PermissionAttendanceMark, PermissionLeaveSubmit,
+}
+
+var adminPermissions = append(append([]string{}, memberPermissions...),
+	PermissionRequestsReview, PermissionInvitesManage, PermissionMeetingRun,
+	PermissionAttendanceStart, PermissionAttendanceOverride, PermissionReportsView, PermissionLeaveReview,
+)
+
+// reservedPermissions can only be had by the super admin, never by custom roles.
+var reservedPermissions = map[string]bool{
+	PermissionTeamDelete:   true,
+	PermissionTeamHandover: true,
+	PermissionRolesManage:  true,
+	PermissionAuditView:    true,
+}
+
+// BuiltInRolePermissions returns the permissions of the super_admin, admin and member roles, and false for other roles.
+func BuiltInRolePermissions(role string) ([]string, bool) {
+	switch role {
+	case SuperAdminRole:
+		return AllPermissions, true
+	case AdminRole:
+		return adminPermissions, true
+	case MemberRole:
+		return memberPermissions, true
+	}
+	return nil, false
+}
+
+// HasPermission reports whether the permissions include permission.
+func HasPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermissions reports whether the permissions include every one of required.
+func HasPermissions(permissions, required []string) bool {
+	for _, permission := range required {
+		if !HasPermission(permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+var teamRoleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,29}$`)
+
+// TeamRole is a role defined by a team, given to members by its Name in TeamMember.Role.
+// It has the permissions of members, and its own Permissions.
+type TeamRole struct {
+	gorm.Model
+	TeamID      uint     `gorm:"not null;uniqueIndex:idx_team_role_name"`
+	Name        string   `gorm:"size:30;not null;uniqueIndex:idx_team_role_name"`
+	Permissions []string `gorm:"serializer:json"`
+}
+
+// gorm on save hook to check the role
+func (r *TeamRole) BeforeSave(tx *gorm.DB) error {
+	return r.Validate()
+}
+
+// Validate checks the name of the role, which cannot be one of the built in roles, and that its permissions exist and are not reserved.
+// Duplicate permissions are removed.
+func (r *TeamRole) Validate() error {
+	if !teamRoleNamePattern.MatchString(r.Name) {
+		return errors.New("role name must be 2 to 30 lowercase letters, digits or underscores, starting with a letter")
+	}
+	if _, ok := BuiltInRolePermissions(r.Name); ok {
+		return errors.New("role name is taken by a built in role")
+	}
+	seen := make(map[string]bool)
+	permissions := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		if !HasPermission(AllPermissions, permission) {
+			return errors.New("unknown permission " + permission)
+		}
+		if reservedPermissions[permission] {
+			return errors.New("permission " + permission + " is reserved for the super admin")
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	r.Permissions = permissions
+	return nil
+}
+
+// AdminPermission reports whether the permission is beyond those of members.
+func AdminPermission(permission string) bool {
+	return !HasPermission(memberPermissions, permission)
+}
+
+// EffectivePermissions returns the permissions of members of the role, those of members with the role's own.
+func (r *TeamRole) EffectivePermissions() []string {
+	permissions := append([]string{}, memberPermissions...)
+	for _, permission := range r.Permissions {
+		if !HasPermission(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
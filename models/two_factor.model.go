@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// TwoFactor is the TOTP second factor of a user. Once confirmed with a code from their authenticator app,
+// logging in also needs a code, or one of their RecoveryCodes.
+type TwoFactor struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	UserID       uint       `gorm:"not null;uniqueIndex"`
+	Secret       string     `gorm:"size:64;not null" json:"-"`
+	ConfirmedAt  *time.Time // nil until enrollment is confirmed, the second factor is not used before
+	LastUsedCode string     `gorm:"size:10" json:"-"` // not accepted again while it is still valid
+	LastUsedAt   *time.Time `json:"-"`
+}
+
+// Enabled reports whether logging in needs the second factor.
+func (t *TwoFactor) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// RecoveryCode is a single use code to log in without the authenticator app. Only its hash is stored.
+type RecoveryCode struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"size:64;not null" json:"-"`
+	UsedAt    *time.Time
+}
@@ -1,9 +0,0 @@
-package models
-
-import "gorm.io/gorm"
-
-type VerificationEntry struct {
-	gorm.Model
-	Email string `gorm:"unique"`
-	OTP   string
-}
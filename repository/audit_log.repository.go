@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository() *AuditLogRepository {
+	return &AuditLogRepository{database.DB}
+}
+
+type AuditLogRepositoryInterface interface {
+	CreateAuditLog(entry models.AuditLog) (models.AuditLog, error)
+	GetAuditLogs(filter models.AuditLogFilter) ([]models.AuditLog, int64, error)
+}
+
+// CreateAuditLog adds an entry to the audit log.
+func (alr *AuditLogRepository) CreateAuditLog(entry models.AuditLog) (models.AuditLog, error) {
+	if err := alr.db.Create(&entry).Error; err != nil {
+		return models.AuditLog{}, err
+	}
+	return entry, nil
+}
+
+// GetAuditLogs retrieves a page of the audit log of a team, newest first, with the number of entries matching the filter.
+func (alr *AuditLogRepository) GetAuditLogs(filter models.AuditLogFilter) ([]models.AuditLog, int64, error) {
+	query := alr.db.Model(&models.AuditLog{}).Where("team_id = ?", filter.TeamID)
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if strings.HasSuffix(filter.Action, ".") {
+		query = query.Where("action LIKE ?", filter.Action+"%")
+	} else if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var entries []models.AuditLog
+	if err := query.Order("created_at DESC, id DESC").Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
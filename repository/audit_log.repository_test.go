@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestAuditLogRepository_GetAuditLogs(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.AuditLog{})
+
+	repository := NewAuditLogRepository()
+	repository.db = db
+
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	entries := []models.AuditLog{
+		{TeamID: 1, ActorID: 9, Action: models.AuditMemberKick, TargetType: models.AuditTargetMember, TargetID: 2},
+		{TeamID: 1, ActorID: 9, Action: models.AuditMemberRoleChange, TargetType: models.AuditTargetMember, TargetID: 3},
+		{TeamID: 1, ActorID: 8, Action: models.AuditMeetingDelete, TargetType: models.AuditTargetMeeting, TargetID: 4},
+		{TeamID: 2, ActorID: 9, Action: models.AuditMemberKick, TargetType: models.AuditTargetMember, TargetID: 5},
+	}
+	for i, entry := range entries {
+		entry.CreatedAt = start.Add(time.Duration(i) * time.Hour)
+		if _, err := repository.CreateAuditLog(entry); err != nil {
+			t.Fatalf("Failed to create audit log entry: %v", err)
+		}
+	}
+
+	actorID := uint(9)
+	from := start.Add(30 * time.Minute)
+	testCases := []struct {
+		name    string
+		filter  models.AuditLogFilter
+		total   int64
+		targets []uint // in order
+	}{
+		{"whole team newest first", models.AuditLogFilter{TeamID: 1}, 3, []uint{4, 3, 2}},
+		{"actor", models.AuditLogFilter{TeamID: 1, ActorID: &actorID}, 2, []uint{3, 2}},
+		{"action", models.AuditLogFilter{TeamID: 1, Action: models.AuditMemberKick}, 1, []uint{2}},
+		{"action prefix", models.AuditLogFilter{TeamID: 1, Action: "member."}, 2, []uint{3, 2}},
+		{"from", models.AuditLogFilter{TeamID: 1, From: &from}, 2, []uint{4, 3}},
+		{"second page", models.AuditLogFilter{TeamID: 1, Page: 2, PageSize: 2}, 3, []uint{2}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.filter.Page == 0 {
+				tc.filter.Page, tc.filter.PageSize = 1, 50
+			}
+			found, total, err := repository.GetAuditLogs(tc.filter)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if total != tc.total {
+				t.Errorf("Expected %d entries in total, got %d", tc.total, total)
+			}
+			targets := make([]uint, len(found))
+			for i, entry := range found {
+				targets[i] = entry.TargetID
+			}
+			if len(targets) != len(tc.targets) {
+				t.Fatalf("Expected targets %v, got %v", tc.targets, targets)
+			}
+			for i := range targets {
+				if targets[i] != tc.targets[i] {
+					t.Fatalf("Expected targets %v, got %v", tc.targets, targets)
+				}
+			}
+		})
+	}
+}
+
+func TestAuditLogRepository_AppendOnly(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.AuditLog{})
+
+	repository := NewAuditLogRepository()
+	repository.db = db
+
+	entry, err := repository.CreateAuditLog(models.AuditLog{
+		TeamID:     1,
+		ActorID:    9,
+		IP:         "10.0.0.1",
+		Action:     models.AuditTeamUpdate,
+		TargetType: models.AuditTargetTeam,
+		TargetID:   1,
+		Before:     map[string]interface{}{"Name": "GDSC"},
+		After:      map[string]interface{}{"Name": "GDG"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	entry.Action = models.AuditTeamCreate
+	if err := db.Save(&entry).Error; !errors.Is(err, models.ErrAuditLogAppendOnly) {
+		t.Errorf("Expected updating an entry to fail, got: %v", err)
+	}
+	if err := db.Delete(&entry).Error; !errors.Is(err, models.ErrAuditLogAppendOnly) {
+		t.Errorf("Expected deleting an entry to fail, got: %v", err)
+	}
+
+	found, _, err := repository.GetAuditLogs(models.AuditLogFilter{TeamID: 1, Page: 1, PageSize: 50})
+	if err != nil || len(found) != 1 {
+		t.Fatalf("Expected the entry to be kept, got %v: %v", found, err)
+	}
+	if found[0].Action != models.AuditTeamUpdate {
+		t.Errorf("Expected the entry to be unchanged, got %s", found[0].Action)
+	}
+	if after, ok := found[0].After.(map[string]interface{}); !ok || after["Name"] != "GDG" {
+		t.Errorf("Expected the after snapshot to be stored, got %v", found[0].After)
+	}
+}
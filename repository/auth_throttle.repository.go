@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// authThrottleRetention is how long throttles are kept after their last failure, longer than the window of any policy.
+const authThrottleRetention = 7 * 24 * time.Hour
+
+type AuthThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthThrottleRepository() *AuthThrottleRepository {
+	return &AuthThrottleRepository{database.DB}
+}
+
+// AuthThrottleRepositoryInterface is the store of failed attempts, implemented on the database by AuthThrottleRepository.
+type AuthThrottleRepositoryInterface interface {
+	GetAuthThrottles(keys []string) ([]models.AuthThrottle, error)
+	RecordAuthFailure(key string, policy models.ThrottlePolicy, now time.Time) (models.AuthThrottle, bool, error)
+	ResetAuthThrottles(keys []string) error
+	DeleteStaleAuthThrottles(now time.Time) error
+}
+
+// GetAuthThrottles retrieves the throttles of the keys that had failures.
+func (ar *AuthThrottleRepository) GetAuthThrottles(keys []string) ([]models.AuthThrottle, error) {
+	var throttles []models.AuthThrottle
+	if err := ar.db.Where("throttle_key IN ?", keys).Find(&throttles).Error; err != nil {
+		return nil, err
+	}
+	return throttles, nil
+}
+
+// RecordAuthFailure counts a failure of the key under the policy, returning its throttle and whether it just locked out.
+// The throttle is locked while counting, so concurrent failures on other replicas are all counted.
+func (ar *AuthThrottleRepository) RecordAuthFailure(key string, policy models.ThrottlePolicy, now time.Time) (models.AuthThrottle, bool, error) {
+	var throttle models.AuthThrottle
+	var lockedOut bool
+	err := ar.db.Transaction(func(tx *gorm.DB) error {
+		// make sure the throttle exists so it can be locked
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.AuthThrottle{Key: key, LastFailureAt: now, BlockedUntil: now}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("throttle_key = ?", key).First(&throttle).Error; err != nil {
+			return err
+		}
+		throttle, lockedOut = policy.Fail(throttle, now)
+		return tx.Save(&throttle).Error
+	})
+	if err != nil {
+		return models.AuthThrottle{}, false, err
+	}
+	return throttle, lockedOut, nil
+}
+
+// ResetAuthThrottles forgets the failures of the keys, after a successful attempt.
+func (ar *AuthThrottleRepository) ResetAuthThrottles(keys []string) error {
+	return ar.db.Where("throttle_key IN ?", keys).Delete(&models.AuthThrottle{}).Error
+}
+
+// DeleteStaleAuthThrottles deletes throttles that are not blocked and had no failure for a week.
+func (ar *AuthThrottleRepository) DeleteStaleAuthThrottles(now time.Time) error {
+	before := now.Add(-authThrottleRetention)
+	return ar.db.Where("last_failure_at < ? AND blocked_until < ?", before, now).Delete(&models.AuthThrottle{}).Error
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestAuthThrottleRepository_RecordAuthFailure(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.AuthThrottle{})
+
+	repository := NewAuthThrottleRepository()
+	repository.db = db
+
+	policy := models.ThrottlePolicy{
+		FreeFailures:    2,
+		BaseDelay:       time.Second,
+		MaxDelay:        3 * time.Second,
+		LockoutFailures: 6,
+		LockoutDuration: time.Hour,
+		Window:          time.Hour,
+	}
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// free, free, 1s, 2s, capped at 3s, then locked out
+	expectedWaits := []time.Duration{0, 0, time.Second, 2 * time.Second, 3 * time.Second, time.Hour}
+	for i, expected := range expectedWaits {
+		throttle, lockedOut, err := repository.RecordAuthFailure("account:jane@example.com", policy, now)
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if throttle.Failures != i+1 || throttle.RetryAfter(now) != expected {
+			t.Errorf("Failure %d: expected to wait %v, got %d failures and %v", i+1, expected, throttle.Failures, throttle.RetryAfter(now))
+		}
+		if lockedOut != (i == len(expectedWaits)-1) {
+			t.Errorf("Failure %d: unexpected lockout %v", i+1, lockedOut)
+		}
+	}
+
+	// failures past the lockout stay locked out, without locking out again
+	throttle, lockedOut, _ := repository.RecordAuthFailure("account:jane@example.com", policy, now.Add(time.Hour))
+	if lockedOut || throttle.RetryAfter(now.Add(time.Hour)) != time.Hour {
+		t.Errorf("Expected the account to stay locked out, got %v and %v", lockedOut, throttle.RetryAfter(now.Add(time.Hour)))
+	}
+
+	// failures are forgotten after the window
+	later := now.Add(3 * time.Hour)
+	throttle, _, _ = repository.RecordAuthFailure("account:jane@example.com", policy, later)
+	if throttle.Failures != 1 || throttle.RetryAfter(later) != 0 {
+		t.Errorf("Expected the failures to be forgotten, got %+v", throttle)
+	}
+
+	throttles, err := repository.GetAuthThrottles([]string{"account:jane@example.com", "ip:10.0.0.1"})
+	if err != nil || len(throttles) != 1 {
+		t.Fatalf("Expected 1 throttle, got %v: %v", throttles, err)
+	}
+
+	if err := repository.ResetAuthThrottles([]string{"account:jane@example.com"}); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if throttles, _ := repository.GetAuthThrottles([]string{"account:jane@example.com"}); len(throttles) != 0 {
+		t.Errorf("Expected the throttle to be reset, got %v", throttles)
+	}
+}
+
+func TestAuthThrottleRepository_DeleteStaleAuthThrottles(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.AuthThrottle{})
+
+	repository := NewAuthThrottleRepository()
+	repository.db = db
+
+	policy := models.ThrottlePolicy{LockoutFailures: 1, LockoutDuration: 30 * 24 * time.Hour, Window: time.Hour}
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	repository.RecordAuthFailure("ip:10.0.0.1", models.ThrottlePolicy{Window: time.Hour}, now)
+	repository.RecordAuthFailure("account:jane@example.com", policy, now)
+	repository.RecordAuthFailure("ip:10.0.0.2", models.ThrottlePolicy{Window: time.Hour}, now.Add(7*24*time.Hour))
+
+	if err := repository.DeleteStaleAuthThrottles(now.Add(8 * 24 * time.Hour)); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	throttles, _ := repository.GetAuthThrottles([]string{"ip:10.0.0.1", "account:jane@example.com", "ip:10.0.0.2"})
+	if len(throttles) != 2 {
+		t.Errorf("Expected the stale throttle to be deleted, and the locked out and recent ones kept, got %v", throttles)
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+type LeaveRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveRequestRepository() *LeaveRequestRepository {
+	return &LeaveRequestRepository{database.DB}
+}
+
+type LeaveRequestRepositoryInterface interface {
+	CreateLeaveRequest(request models.LeaveRequest) (models.LeaveRequest, error)
+	GetLeaveRequestByID(id uint) (models.LeaveRequest, error)
+	GetLeaveRequestByMeetingIDAndUserID(meetingID, userID uint) (models.LeaveRequest, error)
+	GetLeaveRequestsByTeamID(teamID uint, status string, meetingID uint) ([]models.LeaveRequest, error)
+	GetLeaveRequestsByTeamIDAndUserID(teamID, userID uint) ([]models.LeaveRequest, error)
+	GetApprovedLeaveRequestsByMeetingID(meetingID uint) ([]models.LeaveRequest, error)
+	GetApprovedLeaveRequestsByMeetingIDs(meetingIDs []uint) ([]models.LeaveRequest, error)
+	GetApprovedLeaveRequestsByUserID(userID uint) ([]models.LeaveRequest, error)
+	UpdateLeaveRequest(request models.LeaveRequest) (models.LeaveRequest, error)
+	DeleteLeaveRequestByID(id uint) error
+}
+
+// CreateLeaveRequest creates a new leave request.
+func (lrr *LeaveRequestRepository) CreateLeaveRequest(request models.LeaveRequest) (models.LeaveRequest, error) {
+	if err := lrr.db.Create(&request).Error; err != nil {
+		return models.LeaveRequest{}, err
+	}
+	return request, nil
+}
+
+// GetLeaveRequestByID retrieves a leave request by its ID.
+func (lrr *LeaveRequestRepository) GetLeaveRequestByID(id uint) (models.LeaveRequest, error) {
+	var request models.LeaveRequest
+	if err := lrr.db.First(&request, id).Error; err != nil {
+		return models.LeaveRequest{}, err
+	}
+	return request, nil
+}
+
+// GetLeaveRequestByMeetingIDAndUserID retrieves the leave request of a user for a meeting.
+func (lrr *LeaveRequestRepository) GetLeaveRequestByMeetingIDAndUserID(meetingID, userID uint) (models.LeaveRequest, error) {
+	var request models.LeaveRequest
+	if err := lrr.db.First(&request, "meeting_id = ? AND user_id = ?", meetingID, userID).Error; err != nil {
+		return models.LeaveRequest{}, err
+	}
+	return request, nil
+}
+
+// GetLeaveRequestsByTeamID retrieves the leave requests of a team, newest first. An empty status or a zero meetingID matches any.
+func (lrr *LeaveRequestRepository) GetLeaveRequestsByTeamID(teamID uint, status string, meetingID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	query := lrr.db.Where("team_id = ?", teamID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if meetingID != 0 {
+		query = query.Where("meeting_id = ?", meetingID)
+	}
+	if err := query.Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetLeaveRequestsByTeamIDAndUserID retrieves the leave requests of a user in a team, newest first.
+func (lrr *LeaveRequestRepository) GetLeaveRequestsByTeamIDAndUserID(teamID, userID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	if err := lrr.db.Where("team_id = ? AND user_id = ?", teamID, userID).Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetApprovedLeaveRequestsByMeetingID retrieves the approved leave requests for a meeting.
+func (lrr *LeaveRequestRepository) GetApprovedLeaveRequestsByMeetingID(meetingID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	if err := lrr.db.Where("meeting_id = ? AND status = ?", meetingID, models.LeaveRequestApproved).Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetApprovedLeaveRequestsByMeetingIDs retrieves the approved leave requests of every user for the given meetings.
+func (lrr *LeaveRequestRepository) GetApprovedLeaveRequestsByMeetingIDs(meetingIDs []uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	if err := lrr.db.Where("meeting_id IN ? AND status = ?", meetingIDs, models.LeaveRequestApproved).Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetApprovedLeaveRequestsByUserID retrieves the approved leave requests of a user in every team.
+func (lrr *LeaveRequestRepository) GetApprovedLeaveRequestsByUserID(userID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	if err := lrr.db.Where("user_id = ? AND status = ?", userID, models.LeaveRequestApproved).Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// UpdateLeaveRequest updates an existing leave request.
+func (lrr *LeaveRequestRepository) UpdateLeaveRequest(request models.LeaveRequest) (models.LeaveRequest, error) {
+	if err := lrr.db.Save(&request).Error; err != nil {
+		return models.LeaveRequest{}, err
+	}
+	return request, nil
+}
+
+// DeleteLeaveRequestByID deletes a leave request by its ID.
+func (lrr *LeaveRequestRepository) DeleteLeaveRequestByID(id uint) error {
+	return lrr.db.Delete(&models.LeaveRequest{}, id).Error
+}
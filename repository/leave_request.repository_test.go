@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestLeaveRequestRepository_CreateAndGetLeaveRequest(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.LeaveRequest{})
+
+	repository := NewLeaveRequestRepository()
+	repository.db = db
+
+	createdRequest, err := repository.CreateLeaveRequest(models.LeaveRequest{TeamID: 1, MeetingID: 2, UserID: 3, Reason: "Exam"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if createdRequest.ID == 0 {
+		t.Errorf("Expected the request to be created with a valid ID")
+	}
+
+	request, err := repository.GetLeaveRequestByMeetingIDAndUserID(2, 3)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if request.ID != createdRequest.ID || request.Status != models.LeaveRequestPending {
+		t.Errorf("Expected the pending request %d, got %+v", createdRequest.ID, request)
+	}
+
+	request.Status = models.LeaveRequestApproved
+	request.ReviewedByID = 4
+	if _, err := repository.UpdateLeaveRequest(request); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	request, _ = repository.GetLeaveRequestByID(createdRequest.ID)
+	if request.Status != models.LeaveRequestApproved || request.ReviewedByID != 4 {
+		t.Errorf("Expected the request to be approved by 4, got %+v", request)
+	}
+
+	if err := repository.DeleteLeaveRequestByID(createdRequest.ID); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if _, err := repository.GetLeaveRequestByID(createdRequest.ID); err == nil {
+		t.Errorf("Expected the request to be deleted")
+	}
+}
+
+func TestLeaveRequestRepository_GetLeaveRequests(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.LeaveRequest{})
+
+	repository := NewLeaveRequestRepository()
+	repository.db = db
+
+	requests := []models.LeaveRequest{
+		{TeamID: 1, MeetingID: 1, UserID: 1, Reason: "Sick", Status: models.LeaveRequestApproved},
+		{TeamID: 1, MeetingID: 1, UserID: 2, Reason: "Exam", Status: models.LeaveRequestPending},
+		{TeamID: 1, MeetingID: 2, UserID: 1, Reason: "Travel", Status: models.LeaveRequestRejected},
+		{TeamID: 1, MeetingID: 3, UserID: 1, Reason: "Sick", Status: models.LeaveRequestApproved},
+		{TeamID: 2, MeetingID: 4, UserID: 1, Reason: "Sick", Status: models.LeaveRequestApproved},
+	}
+	for _, request := range requests {
+		if _, err := repository.CreateLeaveRequest(request); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		status   string
+		meeting  uint
+		expected int
+	}{
+		{"All", "", 0, 4},
+		{"By status", models.LeaveRequestApproved, 0, 2},
+		{"By meeting", "", 1, 2},
+		{"By status and meeting", models.LeaveRequestPending, 1, 1},
+	}
+	for _, test := range tests {
+		retrieved, err := repository.GetLeaveRequestsByTeamID(1, test.status, test.meeting)
+		if err != nil {
+			t.Errorf("%s: expected no error, but got: %v", test.name, err)
+		}
+		if len(retrieved) != test.expected {
+			t.Errorf("%s: expected %d requests, but got %d", test.name, test.expected, len(retrieved))
+		}
+	}
+
+	userRequests, err := repository.GetLeaveRequestsByTeamIDAndUserID(1, 1)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if len(userRequests) != 3 {
+		t.Errorf("Expected 3 requests of the user in the team, but got %d", len(userRequests))
+	}
+
+	approved, err := repository.GetApprovedLeaveRequestsByMeetingID(1)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if len(approved) != 1 || approved[0].UserID != 1 {
+		t.Errorf("Expected the approved request of user 1, but got %+v", approved)
+	}
+
+	approved, err = repository.GetApprovedLeaveRequestsByMeetingIDs([]uint{1, 2, 3})
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if len(approved) != 2 {
+		t.Errorf("Expected 2 approved requests, but got %d", len(approved))
+	}
+
+	approved, err = repository.GetApprovedLeaveRequestsByUserID(1)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if len(approved) != 3 {
+		t.Errorf("Expected 3 approved requests of the user in every team, but got %d", len(approved))
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/infra/database"
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"gorm.io/gorm"
@@ -21,11 +23,20 @@ type MeetingRepositoryInterface interface {
 	DeleteMeetingByID(id uint) error
 	GetMeetingsByTeamID(teamID uint) ([]models.Meeting, error)
 	GetMeetingsByTeamIDAndMeetingOver(teamID uint, meetingOver bool) ([]models.Meeting, error)
+	GetMeetingsBySeriesID(seriesID uint) ([]models.Meeting, error)
+	GetStartedMeetingsByTeamIDBetween(teamID uint, from, to time.Time) ([]models.Meeting, error)
+	GetMeetingsWithPendingTransitions(now time.Time) ([]models.Meeting, error)
+	TransitionMeeting(meeting, before models.Meeting) (bool, error)
+	EnsureAttendanceSecret(meetingID uint, secret string) (string, error)
 	AddMeetingAttendance(meetingAttendance models.MeetingAttendance) error
+	UpdateMeetingAttendance(meetingAttendance models.MeetingAttendance) (models.MeetingAttendance, error)
+	DeleteMeetingAttendance(meetingAttendance models.MeetingAttendance) error
+	ImportAttendance(meetings []*models.Meeting, records []models.ImportedAttendance) error
 	GetMeetingAttendanceByMeetingID(meetingID uint) ([]models.MeetingAttendance, error)
 	GetMeetingAttendanceByMeetingIDAndOnTime(meetingID uint, onTime bool) ([]models.MeetingAttendance, error)
 	GetMeetingAttendanceByUserIDAndMeetingID(userID, meetingID uint) (models.MeetingAttendance, error)
 	GetMeetingAttendancesByUserID(userID uint) ([]models.MeetingAttendance, error)
+	GetMeetingAttendancesByMeetingIDs(meetingIDs []uint) ([]models.MeetingAttendance, error)
 }
 
 // CreateMeeting creates a new meeting in the database.
@@ -77,6 +88,62 @@ func (mr *MeetingRepository) GetMeetingsByTeamIDAndMeetingOver(teamID uint, meet
 	return meetings, nil
 }
 
+// GetMeetingsBySeriesID fetches all occurrences of a meeting series, including deleted ones.
+func (mr *MeetingRepository) GetMeetingsBySeriesID(seriesID uint) ([]models.Meeting, error) {
+	var meetings []models.Meeting
+	if err := mr.db.Unscoped().Where("series_id = ?", seriesID).Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// GetStartedMeetingsByTeamIDBetween fetches the meetings of a team that started between from and to, ordered by StartTime.
+func (mr *MeetingRepository) GetStartedMeetingsByTeamIDBetween(teamID uint, from, to time.Time) ([]models.Meeting, error) {
+	var meetings []models.Meeting
+	if err := mr.db.Where("team_id = ? AND start_time BETWEEN ? AND ? AND (meeting_period = ? OR meeting_over = ?)", teamID, from, to, true, true).Order("start_time").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// GetMeetingsWithPendingTransitions fetches the scheduled meetings that have reached their StartTime and may have transitions left.
+func (mr *MeetingRepository) GetMeetingsWithPendingTransitions(now time.Time) ([]models.Meeting, error) {
+	var meetings []models.Meeting
+	if err := mr.db.Where("schedule_auto_start = ? AND meeting_over = ? AND schedule_state <> ? AND start_time <= ?", true, false, models.ScheduleStateEnded, now).Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// TransitionMeeting saves the periods and schedule state of a meeting, only if its schedule state and periods are still those of before.
+// Returns false if another replica already did the transition, or an admin started or ended the meeting since it was read.
+func (mr *MeetingRepository) TransitionMeeting(meeting, before models.Meeting) (bool, error) {
+	result := mr.db.Model(&models.Meeting{}).
+		Where("id = ? AND schedule_state = ? AND meeting_period = ? AND attendance_period = ? AND meeting_over = ? AND attendance_over = ?",
+			meeting.ID, before.Schedule.State, before.MeetingPeriod, before.AttendancePeriod, before.MeetingOver, before.AttendanceOver).
+		Select("meeting_period", "attendance_period", "meeting_over", "attendance_over", "attendance_secret", "attendance_started_at", "schedule_state").
+		Updates(&meeting)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// EnsureAttendanceSecret saves secret as the attendance secret of a meeting that has none, and returns the secret the meeting ends up with.
+// Concurrent callers all get the secret of whoever saved first.
+func (mr *MeetingRepository) EnsureAttendanceSecret(meetingID uint, secret string) (string, error) {
+	if err := mr.db.Model(&models.Meeting{}).
+		Where("id = ? AND (attendance_secret = '' OR attendance_secret IS NULL)", meetingID).
+		Update("attendance_secret", secret).Error; err != nil {
+		return "", err
+	}
+	var meeting models.Meeting
+	if err := mr.db.Select("attendance_secret").First(&meeting, meetingID).Error; err != nil {
+		return "", err
+	}
+	return meeting.AttendanceSecret, nil
+}
+
 // AddMeetingAttendance adds attendance record for meeting and user
 func (mr *MeetingRepository) AddMeetingAttendance(meetingAttendance models.MeetingAttendance) error {
 	if err := mr.db.Create(&meetingAttendance).Error; err != nil {
@@ -85,6 +152,43 @@ func (mr *MeetingRepository) AddMeetingAttendance(meetingAttendance models.Meeti
 	return nil
 }
 
+// UpdateMeetingAttendance updates an existing attendance record.
+func (mr *MeetingRepository) UpdateMeetingAttendance(meetingAttendance models.MeetingAttendance) (models.MeetingAttendance, error) {
+	if err := mr.db.Save(&meetingAttendance).Error; err != nil {
+		return models.MeetingAttendance{}, err
+	}
+	return meetingAttendance, nil
+}
+
+// DeleteMeetingAttendance saves the given attendance record and soft deletes it, so that who removed it stays on the row.
+func (mr *MeetingRepository) DeleteMeetingAttendance(meetingAttendance models.MeetingAttendance) error {
+	return mr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&meetingAttendance).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&meetingAttendance).Error
+	})
+}
+
+// ImportAttendance creates the meetings, then the attendance records in them, all or nothing.
+// The records point to their meeting, which gets its ID here if it is one of the new meetings.
+func (mr *MeetingRepository) ImportAttendance(meetings []*models.Meeting, records []models.ImportedAttendance) error {
+	return mr.db.Transaction(func(tx *gorm.DB) error {
+		for _, meeting := range meetings {
+			if err := tx.Create(meeting).Error; err != nil {
+				return err
+			}
+		}
+		for _, record := range records {
+			record.Attendance.MeetingID = record.Meeting.ID
+			if err := tx.Create(&record.Attendance).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // GetMeetingAttendanceByMeetingID fetches all attendance records for a meeting
 func (mr *MeetingRepository) GetMeetingAttendanceByMeetingID(meetingID uint) ([]models.MeetingAttendance, error) {
 	var meetingAttendance []models.MeetingAttendance
@@ -120,3 +224,12 @@ func (mr *MeetingRepository) GetMeetingAttendancesByUserID(userID uint) ([]model
 	}
 	return userAttendance, nil
 }
+
+// GetMeetingAttendancesByMeetingIDs fetches the attendance records of every user in the given meetings.
+func (mr *MeetingRepository) GetMeetingAttendancesByMeetingIDs(meetingIDs []uint) ([]models.MeetingAttendance, error) {
+	var meetingAttendance []models.MeetingAttendance
+	if err := mr.db.Where("meeting_id IN ?", meetingIDs).Find(&meetingAttendance).Error; err != nil {
+		return nil, err
+	}
+	return meetingAttendance, nil
+}
@@ -542,3 +542,313 @@ func TestMeetingRepository_GetMeetingAttendancesByUserID(t *testing.T) {
 		t.Errorf("GetMeetingAttendancesByUserID should have returned an empty slice")
 	}
 }
+
+// test UpdateMeetingAttendance and DeleteMeetingAttendance
+func TestMeetingRepository_UpdateAndDeleteMeetingAttendance(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Meeting{}, &models.MeetingAttendance{})
+
+	// Create the Meeting Repository with the test database
+	mr := NewMeetingRepository()
+	mr.db = db
+
+	// Create a test meeting attendance
+	err = mr.AddMeetingAttendance(models.MeetingAttendance{
+		MeetingID:          1,
+		UserID:             1,
+		AttendanceMarkedAt: time.Now(),
+		OnTime:             false,
+	})
+	if err != nil {
+		t.Errorf("AddMeetingAttendance returned an error: %v", err)
+	}
+
+	attendance, err := mr.GetMeetingAttendanceByUserIDAndMeetingID(1, 1)
+	if err != nil {
+		t.Fatalf("GetMeetingAttendanceByUserIDAndMeetingID returned an error: %v", err)
+	}
+
+	// Test UpdateMeetingAttendance function
+	now := time.Now()
+	attendance.OnTime = true
+	attendance.OverriddenByID = 2
+	attendance.OverrideReason = "phone died"
+	attendance.OverriddenAt = &now
+	_, err = mr.UpdateMeetingAttendance(attendance)
+	if err != nil {
+		t.Errorf("UpdateMeetingAttendance returned an error: %v", err)
+	}
+
+	updatedAttendance, _ := mr.GetMeetingAttendanceByUserIDAndMeetingID(1, 1)
+	if !updatedAttendance.OnTime || updatedAttendance.OverriddenByID != 2 || updatedAttendance.OverrideReason != "phone died" {
+		t.Errorf("Expected attendance to be overridden, got %+v", updatedAttendance)
+	}
+
+	// Test DeleteMeetingAttendance function
+	updatedAttendance.OverrideReason = "marked by proxy"
+	err = mr.DeleteMeetingAttendance(updatedAttendance)
+	if err != nil {
+		t.Errorf("DeleteMeetingAttendance returned an error: %v", err)
+	}
+
+	_, err = mr.GetMeetingAttendanceByUserIDAndMeetingID(1, 1)
+	if err == nil {
+		t.Errorf("GetMeetingAttendanceByUserIDAndMeetingID should have returned an error for a removed record")
+	}
+
+	// removed record is kept with who removed it
+	var removedAttendance models.MeetingAttendance
+	if err := db.Unscoped().Where("user_id = ? AND meeting_id = ?", 1, 1).First(&removedAttendance).Error; err != nil {
+		t.Fatalf("Expected removed record to be kept, got error: %v", err)
+	}
+	if removedAttendance.OverrideReason != "marked by proxy" {
+		t.Errorf("Expected removal reason to be kept, got %v", removedAttendance.OverrideReason)
+	}
+}
+
+// test GetMeetingsWithPendingTransitions and TransitionMeeting
+func TestMeetingRepository_ScheduledTransitions(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Meeting{})
+
+	// Create the Meeting Repository with the test database
+	mr := NewMeetingRepository()
+	mr.db = db
+
+	startTime := time.Now().Add(time.Hour)
+	scheduled, err := mr.CreateMeeting(models.Meeting{
+		TeamID:      1,
+		Title:       "Scheduled",
+		Description: "Starts on its own",
+		Venue:       "Room 1",
+		StartTime:   startTime,
+		Schedule:    models.MeetingSchedule{AutoStart: true, DurationMinutes: 60},
+	})
+	if err != nil {
+		t.Fatalf("CreateMeeting returned an error: %v", err)
+	}
+	_, err = mr.CreateMeeting(models.Meeting{
+		TeamID:      1,
+		Title:       "Manual",
+		Description: "Started by an admin",
+		Venue:       "Room 1",
+		StartTime:   startTime,
+	})
+	if err != nil {
+		t.Fatalf("CreateMeeting returned an error: %v", err)
+	}
+
+	// not due yet
+	pending, err := mr.GetMeetingsWithPendingTransitions(time.Now())
+	if err != nil {
+		t.Errorf("GetMeetingsWithPendingTransitions returned an error: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending meetings, got %d", len(pending))
+	}
+
+	pending, _ = mr.GetMeetingsWithPendingTransitions(startTime.Add(time.Minute))
+	if len(pending) != 1 || pending[0].ID != scheduled.ID {
+		t.Fatalf("Expected the scheduled meeting to be pending, got %d meetings", len(pending))
+	}
+
+	// only the first of two replicas does the transition
+	before := pending[0]
+	started := before
+	started.MeetingPeriod = true
+	started.Schedule.State = models.ScheduleStateStarted
+	done, err := mr.TransitionMeeting(started, before)
+	if err != nil || !done {
+		t.Errorf("Expected the transition to be done, got %v, %v", done, err)
+	}
+	done, err = mr.TransitionMeeting(started, before)
+	if err != nil || done {
+		t.Errorf("Expected the repeated transition to be skipped, got %v, %v", done, err)
+	}
+
+	// an admin ending the meeting by hand since it was read is not undone
+	mr.db.Model(&models.Meeting{}).Where("id = ?", scheduled.ID).Updates(map[string]interface{}{"meeting_period": false, "meeting_over": true})
+	attendanceEnded := started
+	attendanceEnded.AttendanceOver = true
+	attendanceEnded.Schedule.State = models.ScheduleStateAttendanceEnded
+	done, err = mr.TransitionMeeting(attendanceEnded, started)
+	if err != nil || done {
+		t.Errorf("Expected the transition to be skipped after the meeting was ended, got %v, %v", done, err)
+	}
+	if ended, _ := mr.GetMeetingByID(scheduled.ID); !ended.MeetingOver || ended.MeetingPeriod {
+		t.Errorf("Expected the meeting to stay ended, got %+v", ended)
+	}
+	mr.db.Model(&models.Meeting{}).Where("id = ?", scheduled.ID).Updates(map[string]interface{}{"meeting_period": true, "meeting_over": false})
+
+	updated, _ := mr.GetMeetingByID(scheduled.ID)
+	if !updated.MeetingPeriod || updated.Schedule.State != models.ScheduleStateStarted {
+		t.Errorf("Expected the meeting to be started, got %+v", updated)
+	}
+
+	// ended meetings are no longer pending
+	updated.MeetingPeriod = false
+	updated.MeetingOver = true
+	updated.Schedule.State = models.ScheduleStateEnded
+	mr.TransitionMeeting(updated, started)
+	pending, _ = mr.GetMeetingsWithPendingTransitions(startTime.Add(2 * time.Hour))
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending meetings, got %d", len(pending))
+	}
+}
+
+func TestMeetingRepository_EnsureAttendanceSecret(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Meeting{})
+
+	// Create the Meeting Repository with the test database
+	mr := NewMeetingRepository()
+	mr.db = db
+
+	meeting, err := mr.CreateMeeting(models.Meeting{
+		TeamID:      1,
+		Title:       "Legacy",
+		Description: "Without a secret",
+		Venue:       "Room 1",
+		StartTime:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateMeeting returned an error: %v", err)
+	}
+
+	secret, err := mr.EnsureAttendanceSecret(meeting.ID, "FIRST")
+	if err != nil || secret != "FIRST" {
+		t.Errorf("Expected the secret to be saved, got %q, %v", secret, err)
+	}
+
+	// a second caller gets the secret already saved
+	secret, err = mr.EnsureAttendanceSecret(meeting.ID, "SECOND")
+	if err != nil || secret != "FIRST" {
+		t.Errorf("Expected the saved secret to be kept, got %q, %v", secret, err)
+	}
+
+	if _, err := mr.EnsureAttendanceSecret(meeting.ID+1, "THIRD"); err == nil {
+		t.Errorf("Expected an error for a missing meeting")
+	}
+}
+
+// test GetStartedMeetingsByTeamIDBetween and GetMeetingAttendancesByMeetingIDs
+func TestMeetingRepository_ExportQueries(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Meeting{}, &models.MeetingAttendance{})
+
+	// Create the Meeting Repository with the test database
+	mr := NewMeetingRepository()
+	mr.db = db
+
+	from := time.Now().Add(time.Hour)
+	to := from.Add(7 * 24 * time.Hour)
+	create := func(title string, startTime time.Time, started bool) models.Meeting {
+		meeting, err := mr.CreateMeeting(models.Meeting{TeamID: 1, Title: title, Description: "Sync", Venue: "Room 1", StartTime: startTime})
+		if err != nil {
+			t.Fatalf("CreateMeeting returned an error: %v", err)
+		}
+		if started {
+			meeting.MeetingOver = true
+			meeting, _ = mr.UpdateMeeting(meeting)
+		}
+		return meeting
+	}
+	second := create("Second", from.Add(48*time.Hour), true)
+	first := create("First", from.Add(24*time.Hour), true)
+	create("Not started", from.Add(72*time.Hour), false)
+	create("Out of range", to.Add(time.Hour), true)
+
+	meetings, err := mr.GetStartedMeetingsByTeamIDBetween(1, from, to)
+	if err != nil {
+		t.Errorf("GetStartedMeetingsByTeamIDBetween returned an error: %v", err)
+	}
+	if len(meetings) != 2 || meetings[0].ID != first.ID || meetings[1].ID != second.ID {
+		t.Fatalf("Expected the two started meetings in range ordered by start time, got %+v", meetings)
+	}
+
+	for _, attendance := range []models.MeetingAttendance{
+		{UserID: 1, MeetingID: first.ID, AttendanceMarkedAt: time.Now(), OnTime: true},
+		{UserID: 1, MeetingID: 99, AttendanceMarkedAt: time.Now(), OnTime: true},
+		{UserID: 2, MeetingID: second.ID, AttendanceMarkedAt: time.Now(), OnTime: true},
+	} {
+		if err := mr.AddMeetingAttendance(attendance); err != nil {
+			t.Fatalf("AddMeetingAttendance returned an error: %v", err)
+		}
+	}
+
+	attendance, err := mr.GetMeetingAttendancesByMeetingIDs([]uint{first.ID, second.ID})
+	if err != nil {
+		t.Errorf("GetMeetingAttendancesByMeetingIDs returned an error: %v", err)
+	}
+	if len(attendance) != 2 {
+		t.Errorf("Expected only the attendance in the two meetings, got %+v", attendance)
+	}
+}
+
+func TestMeetingRepository_ImportAttendance(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Meeting{}, &models.MeetingAttendance{})
+
+	// Create the Meeting Repository with the test database
+	mr := NewMeetingRepository()
+	mr.db = db
+
+	past := time.Now().Add(-30 * 24 * time.Hour)
+	imported := func(title string) *models.Meeting {
+		return &models.Meeting{TeamID: 1, Title: title, Description: "Imported attendance", Venue: "Imported", StartTime: past, MeetingOver: true, AttendanceOver: true, Imported: true}
+	}
+
+	// a meeting that is not imported still cannot start in the past, and nothing is kept when it fails
+	rolledBack := imported("Rolled back")
+	notImported := &models.Meeting{TeamID: 1, Title: "Not imported", Description: "Sync", Venue: "Room 1", StartTime: past}
+	err = mr.ImportAttendance([]*models.Meeting{rolledBack, notImported}, []models.ImportedAttendance{
+		{Meeting: rolledBack, Attendance: models.MeetingAttendance{UserID: 1, AttendanceMarkedAt: past, OnTime: true}},
+	})
+	if err == nil {
+		t.Fatal("Expected an error importing a meeting in the past that is not imported")
+	}
+	if meetings, _ := mr.GetMeetingsByTeamID(1); len(meetings) != 0 {
+		t.Errorf("Expected no meetings after a failed import, got %d", len(meetings))
+	}
+
+	// an imported meeting must be over
+	notOver := imported("Not over")
+	notOver.AttendanceOver = false
+	if err := mr.ImportAttendance([]*models.Meeting{notOver}, nil); err == nil {
+		t.Error("Expected an error importing a meeting that is not over")
+	}
+
+	retro := imported("Retro")
+	err = mr.ImportAttendance([]*models.Meeting{retro}, []models.ImportedAttendance{
+		{Meeting: retro, Attendance: models.MeetingAttendance{UserID: 1, AttendanceMarkedAt: past, OnTime: true, Status: models.AttendanceStatusOnTime}},
+		{Meeting: retro, Attendance: models.MeetingAttendance{UserID: 2, AttendanceMarkedAt: past, Status: models.AttendanceStatusLate}},
+	})
+	if err != nil {
+		t.Fatalf("ImportAttendance returned an error: %v", err)
+	}
+	if retro.ID == 0 {
+		t.Fatal("Expected the imported meeting to get an ID")
+	}
+	attendance, err := mr.GetMeetingAttendanceByMeetingID(retro.ID)
+	if err != nil {
+		t.Errorf("GetMeetingAttendanceByMeetingID returned an error: %v", err)
+	}
+	if len(attendance) != 2 {
+		t.Errorf("Expected 2 imported attendance records, got %d", len(attendance))
+	}
+}
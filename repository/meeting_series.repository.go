@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+type MeetingSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewMeetingSeriesRepository() *MeetingSeriesRepository {
+	return &MeetingSeriesRepository{database.DB}
+}
+
+type MeetingSeriesRepositoryInterface interface {
+	CreateMeetingSeries(series models.MeetingSeries) (models.MeetingSeries, error)
+	GetMeetingSeriesByID(id uint) (models.MeetingSeries, error)
+	GetMeetingSeriesByTeamID(teamID uint) ([]models.MeetingSeries, error)
+	GetMeetingSeriesMaterializedBefore(horizon time.Time) ([]models.MeetingSeries, error)
+	UpdateMeetingSeries(series models.MeetingSeries) (models.MeetingSeries, error)
+	SplitMeetingSeries(series, following models.MeetingSeries, from time.Time) (models.MeetingSeries, error)
+	DeleteMeetingSeries(series models.MeetingSeries, from time.Time) error
+}
+
+// CreateMeetingSeries creates a new meeting series record.
+func (msr *MeetingSeriesRepository) CreateMeetingSeries(series models.MeetingSeries) (models.MeetingSeries, error) {
+	if err := msr.db.Create(&series).Error; err != nil {
+		return models.MeetingSeries{}, err
+	}
+	return series, nil
+}
+
+// GetMeetingSeriesByID retrieves a meeting series by its ID.
+func (msr *MeetingSeriesRepository) GetMeetingSeriesByID(id uint) (models.MeetingSeries, error) {
+	var series models.MeetingSeries
+	if err := msr.db.First(&series, id).Error; err != nil {
+		return series, err
+	}
+	return series, nil
+}
+
+// GetMeetingSeriesByTeamID fetches all meeting series of a team.
+func (msr *MeetingSeriesRepository) GetMeetingSeriesByTeamID(teamID uint) ([]models.MeetingSeries, error) {
+	var series []models.MeetingSeries
+	if err := msr.db.Where("team_id = ?", teamID).Find(&series).Error; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// GetMeetingSeriesMaterializedBefore fetches all meeting series whose occurrences have only been created up to before horizon.
+func (msr *MeetingSeriesRepository) GetMeetingSeriesMaterializedBefore(horizon time.Time) ([]models.MeetingSeries, error) {
+	var series []models.MeetingSeries
+	if err := msr.db.Where("materialized_until < ?", horizon).Find(&series).Error; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// UpdateMeetingSeries updates an existing meeting series record.
+func (msr *MeetingSeriesRepository) UpdateMeetingSeries(series models.MeetingSeries) (models.MeetingSeries, error) {
+	if err := msr.db.Save(&series).Error; err != nil {
+		return models.MeetingSeries{}, err
+	}
+	return series, nil
+}
+
+// SplitMeetingSeries ends series before from, and creates following to continue it, in a transaction.
+// Occurrences of series from the time from on that have not started are deleted, following creates its own.
+func (msr *MeetingSeriesRepository) SplitMeetingSeries(series, following models.MeetingSeries, from time.Time) (models.MeetingSeries, error) {
+	err := msr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&series).Error; err != nil {
+			return err
+		}
+		if err := deleteUnstartedOccurrences(tx, series.ID, from); err != nil {
+			return err
+		}
+		return tx.Create(&following).Error
+	})
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	return following, nil
+}
+
+// DeleteMeetingSeries deletes a meeting series, and its occurrences from the time from on that have not started, in a transaction.
+// Meetings that have started or are over are kept.
+func (msr *MeetingSeriesRepository) DeleteMeetingSeries(series models.MeetingSeries, from time.Time) error {
+	return msr.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteUnstartedOccurrences(tx, series.ID, from); err != nil {
+			return err
+		}
+		return tx.Delete(&series).Error
+	})
+}
+
+func deleteUnstartedOccurrences(tx *gorm.DB, seriesID uint, from time.Time) error {
+	return tx.Where("series_id = ? AND occurrence_time >= ? AND meeting_period = ? AND meeting_over = ?", seriesID, from, false, false).Delete(&models.Meeting{}).Error
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func newTestMeetingSeries(startTime time.Time) models.MeetingSeries {
+	return models.MeetingSeries{
+		TeamID:      1,
+		Title:       "Weekly sync",
+		Description: "Sync",
+		Venue:       "Room 1",
+		StartTime:   startTime,
+		RRule:       "FREQ=WEEKLY;BYDAY=TU,TH",
+	}
+}
+
+// test CreateMeetingSeries, GetMeetingSeriesByID, GetMeetingSeriesByTeamID and GetMeetingSeriesMaterializedBefore
+func TestMeetingSeriesRepository_CreateAndGetMeetingSeries(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.MeetingSeries{})
+
+	// Create the Meeting Series Repository with the test database
+	msr := NewMeetingSeriesRepository()
+	msr.db = db
+
+	now := time.Now()
+	series, err := msr.CreateMeetingSeries(newTestMeetingSeries(now.Add(24 * time.Hour)))
+	if err != nil {
+		t.Fatalf("CreateMeetingSeries returned an error: %v", err)
+	}
+
+	// invalid rule
+	invalid := newTestMeetingSeries(now.Add(24 * time.Hour))
+	invalid.RRule = "FREQ=YEARLY"
+	if _, err := msr.CreateMeetingSeries(invalid); err == nil {
+		t.Errorf("CreateMeetingSeries should have returned an error for an invalid rule")
+	}
+
+	retrievedSeries, err := msr.GetMeetingSeriesByID(series.ID)
+	if err != nil {
+		t.Errorf("GetMeetingSeriesByID returned an error: %v", err)
+	}
+	if retrievedSeries.RRule != series.RRule {
+		t.Errorf("Expected rule %v, got %v", series.RRule, retrievedSeries.RRule)
+	}
+
+	teamSeries, err := msr.GetMeetingSeriesByTeamID(1)
+	if err != nil {
+		t.Errorf("GetMeetingSeriesByTeamID returned an error: %v", err)
+	}
+	if len(teamSeries) != 1 {
+		t.Errorf("Expected 1 meeting series, got %d", len(teamSeries))
+	}
+
+	// not materialized yet
+	due, _ := msr.GetMeetingSeriesMaterializedBefore(now)
+	if len(due) != 1 {
+		t.Errorf("Expected 1 meeting series to materialize, got %d", len(due))
+	}
+
+	retrievedSeries.MaterializedUntil = now.Add(time.Hour)
+	if _, err := msr.UpdateMeetingSeries(retrievedSeries); err != nil {
+		t.Errorf("UpdateMeetingSeries returned an error: %v", err)
+	}
+	due, _ = msr.GetMeetingSeriesMaterializedBefore(now)
+	if len(due) != 0 {
+		t.Errorf("Expected no meeting series to materialize, got %d", len(due))
+	}
+}
+
+// test SplitMeetingSeries and DeleteMeetingSeries
+func TestMeetingSeriesRepository_SplitAndDeleteMeetingSeries(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.MeetingSeries{}, &models.Meeting{})
+
+	msr := NewMeetingSeriesRepository()
+	msr.db = db
+	mr := NewMeetingRepository()
+	mr.db = db
+
+	first := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	series, err := msr.CreateMeetingSeries(newTestMeetingSeries(first))
+	if err != nil {
+		t.Fatalf("CreateMeetingSeries returned an error: %v", err)
+	}
+
+	// three occurrences, a week apart
+	for i := 0; i < 3; i++ {
+		occurrence := first.Add(time.Duration(i) * 7 * 24 * time.Hour)
+		_, err := mr.CreateMeeting(models.Meeting{
+			TeamID:         1,
+			Title:          series.Title,
+			Description:    series.Description,
+			Venue:          series.Venue,
+			StartTime:      occurrence,
+			SeriesID:       series.ID,
+			OccurrenceTime: occurrence,
+		})
+		if err != nil {
+			t.Fatalf("CreateMeeting returned an error: %v", err)
+		}
+	}
+
+	// an occurrence cannot be created twice
+	_, err = mr.CreateMeeting(models.Meeting{TeamID: 1, Title: "a", Description: "b", Venue: "c", StartTime: first, SeriesID: series.ID, OccurrenceTime: first})
+	if err == nil {
+		t.Errorf("CreateMeeting should have returned an error for a duplicate occurrence")
+	}
+
+	// split from the second occurrence
+	from := first.Add(7 * 24 * time.Hour)
+	series.RRule = "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=" + from.Add(-time.Second).UTC().Format("20060102T150405Z")
+	following := newTestMeetingSeries(from)
+	following.Venue = "Room 2"
+	following, err = msr.SplitMeetingSeries(series, following, from)
+	if err != nil {
+		t.Fatalf("SplitMeetingSeries returned an error: %v", err)
+	}
+	if following.ID == 0 || following.ID == series.ID {
+		t.Errorf("Expected a new meeting series, got ID %d", following.ID)
+	}
+
+	meetings, _ := mr.GetMeetingsByTeamID(1)
+	if len(meetings) != 1 || !meetings[0].OccurrenceTime.Equal(first) {
+		t.Errorf("Expected only the first occurrence to be kept, got %d meetings", len(meetings))
+	}
+	// deleted occurrences are still returned so they are not created again
+	occurrences, _ := mr.GetMeetingsBySeriesID(series.ID)
+	if len(occurrences) != 3 {
+		t.Errorf("Expected 3 occurrences including deleted ones, got %d", len(occurrences))
+	}
+
+	// delete the original series from after its first occurrence, the first occurrence stays
+	if err := msr.DeleteMeetingSeries(series, from); err != nil {
+		t.Errorf("DeleteMeetingSeries returned an error: %v", err)
+	}
+	if _, err := msr.GetMeetingSeriesByID(series.ID); err == nil {
+		t.Errorf("GetMeetingSeriesByID should have returned an error for a deleted series")
+	}
+	meetings, _ = mr.GetMeetingsByTeamID(1)
+	if len(meetings) != 1 {
+		t.Errorf("Expected the first occurrence to be kept, got %d meetings", len(meetings))
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+// ErrOAuthStateInvalid is returned when finishing a login whose state was not issued, already used or expired.
+var ErrOAuthStateInvalid = errors.New("login state is invalid or expired, please start logging in again")
+
+type OAuthStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthStateRepository() *OAuthStateRepository {
+	return &OAuthStateRepository{database.DB}
+}
+
+type OAuthStateRepositoryInterface interface {
+	CreateOAuthState(state models.OAuthState) (models.OAuthState, error)
+	ConsumeOAuthState(stateHash string, now time.Time) (models.OAuthState, error)
+	DeleteEndedOAuthStates(now time.Time) error
+}
+
+// CreateOAuthState creates a new state.
+func (osr *OAuthStateRepository) CreateOAuthState(state models.OAuthState) (models.OAuthState, error) {
+	if err := osr.db.Create(&state).Error; err != nil {
+		return models.OAuthState{}, err
+	}
+	return state, nil
+}
+
+// ConsumeOAuthState uses up the state that hashes to stateHash, returning ErrOAuthStateInvalid if there is no such unused,
+// unexpired state. The update is conditional, so of concurrent uses only one succeeds.
+func (osr *OAuthStateRepository) ConsumeOAuthState(stateHash string, now time.Time) (models.OAuthState, error) {
+	result := osr.db.Model(&models.OAuthState{}).
+		Where("state_hash = ? AND consumed_at IS NULL AND expires_at > ?", stateHash, now).
+		Update("consumed_at", now)
+	if result.Error != nil {
+		return models.OAuthState{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.OAuthState{}, ErrOAuthStateInvalid
+	}
+
+	var state models.OAuthState
+	if err := osr.db.Where("state_hash = ?", stateHash).First(&state).Error; err != nil {
+		return models.OAuthState{}, err
+	}
+	return state, nil
+}
+
+// DeleteEndedOAuthStates deletes states that were used or expired a day before now.
+func (osr *OAuthStateRepository) DeleteEndedOAuthStates(now time.Time) error {
+	before := now.Add(-24 * time.Hour)
+	return osr.db.Where("consumed_at < ? OR expires_at < ?", before, before).Delete(&models.OAuthState{}).Error
+}
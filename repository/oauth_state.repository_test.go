@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestOAuthStateRepository_ConsumeOAuthState(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.OAuthState{})
+
+	repository := NewOAuthStateRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if _, err := repository.CreateOAuthState(models.OAuthState{StateHash: "hash", Nonce: "nonce", CodeChallenge: "challenge", ExpiresAt: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("Failed to create state: %v", err)
+	}
+
+	if _, err := repository.ConsumeOAuthState("other", now); !errors.Is(err, ErrOAuthStateInvalid) {
+		t.Errorf("Expected ErrOAuthStateInvalid for an unknown state, got: %v", err)
+	}
+	if _, err := repository.ConsumeOAuthState("hash", now.Add(time.Minute)); !errors.Is(err, ErrOAuthStateInvalid) {
+		t.Errorf("Expected ErrOAuthStateInvalid for an expired state, got: %v", err)
+	}
+
+	state, err := repository.ConsumeOAuthState("hash", now)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if state.Nonce != "nonce" || !state.Mobile() || state.ConsumedAt == nil {
+		t.Errorf("Expected the consumed mobile state, got: %+v", state)
+	}
+
+	if _, err := repository.ConsumeOAuthState("hash", now); !errors.Is(err, ErrOAuthStateInvalid) {
+		t.Errorf("Expected ErrOAuthStateInvalid using a state twice, got: %v", err)
+	}
+}
+
+func TestOAuthStateRepository_DeleteEndedOAuthStates(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.OAuthState{})
+
+	repository := NewOAuthStateRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	repository.CreateOAuthState(models.OAuthState{StateHash: "expired", Nonce: "nonce", ExpiresAt: now.Add(-48 * time.Hour)})
+	repository.CreateOAuthState(models.OAuthState{StateHash: "pending", Nonce: "nonce", ExpiresAt: now.Add(time.Minute)})
+
+	if err := repository.DeleteEndedOAuthStates(now); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	var count int64
+	db.Model(&models.OAuthState{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected only the pending state to be kept, got %d states", count)
+	}
+}
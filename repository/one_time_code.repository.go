@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors of using a one-time code.
+var (
+	ErrOneTimeCodeNotFound  = errors.New("no code was sent, or it was already used")
+	ErrOneTimeCodeExpired   = errors.New("code has expired")
+	ErrOneTimeCodeIncorrect = errors.New("code is not correct")
+	ErrOneTimeCodeExhausted = errors.New("too many wrong codes, the code cannot be used anymore")
+)
+
+type OneTimeCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeCodeRepository() *OneTimeCodeRepository {
+	return &OneTimeCodeRepository{database.DB}
+}
+
+type OneTimeCodeRepositoryInterface interface {
+	CreateOneTimeCode(oneTimeCode models.OneTimeCode) (models.OneTimeCode, error)
+	ConsumeOneTimeCode(purpose, email, code string, maxAttempts int, now time.Time) error
+	DeleteOneTimeCodesByEmail(email string) error
+	DeleteEndedOneTimeCodes(now time.Time) error
+}
+
+// CreateOneTimeCode creates a one-time code, replacing the unused codes sent for the same purpose to the same email.
+func (ocr *OneTimeCodeRepository) CreateOneTimeCode(oneTimeCode models.OneTimeCode) (models.OneTimeCode, error) {
+	err := ocr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purpose = ? AND email = ? AND consumed_at IS NULL", oneTimeCode.Purpose, oneTimeCode.Email).Delete(&models.OneTimeCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&oneTimeCode).Error
+	})
+	if err != nil {
+		return models.OneTimeCode{}, err
+	}
+	return oneTimeCode, nil
+}
+
+// ConsumeOneTimeCode uses up the code sent for the purpose to the email if it is correct. A wrong code counts as an attempt,
+// and after maxAttempts the code cannot be used anymore, ErrOneTimeCodeExhausted is returned.
+// The code is locked while checking, so concurrent attempts on other replicas are all counted.
+func (ocr *OneTimeCodeRepository) ConsumeOneTimeCode(purpose, email, code string, maxAttempts int, now time.Time) error {
+	// the outcome is returned after committing, so wrong attempts are counted
+	var outcome error
+	err := ocr.db.Transaction(func(tx *gorm.DB) error {
+		var oneTimeCode models.OneTimeCode
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("purpose = ? AND email = ? AND consumed_at IS NULL", purpose, email).
+			Order("id DESC").
+			First(&oneTimeCode).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			outcome = ErrOneTimeCodeNotFound
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case oneTimeCode.Attempts >= maxAttempts:
+			outcome = ErrOneTimeCodeExhausted
+			return nil
+		case !now.Before(oneTimeCode.ExpiresAt):
+			outcome = ErrOneTimeCodeExpired
+			return nil
+		case oneTimeCode.Matches(code):
+			return tx.Model(&oneTimeCode).Update("consumed_at", now).Error
+		}
+
+		oneTimeCode.Attempts++
+		outcome = ErrOneTimeCodeIncorrect
+		if oneTimeCode.Attempts >= maxAttempts {
+			outcome = ErrOneTimeCodeExhausted
+		}
+		return tx.Model(&oneTimeCode).Update("attempts", oneTimeCode.Attempts).Error
+	})
+	if err != nil {
+		return err
+	}
+	return outcome
+}
+
+// DeleteOneTimeCodesByEmail deletes every code sent to the email.
+func (ocr *OneTimeCodeRepository) DeleteOneTimeCodesByEmail(email string) error {
+	return ocr.db.Where("email = ?", email).Delete(&models.OneTimeCode{}).Error
+}
+
+// DeleteEndedOneTimeCodes deletes codes that were used or expired a day before now.
+func (ocr *OneTimeCodeRepository) DeleteEndedOneTimeCodes(now time.Time) error {
+	before := now.Add(-24 * time.Hour)
+	return ocr.db.Where("consumed_at < ? OR expires_at < ?", before, before).Delete(&models.OneTimeCode{}).Error
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+// newOneTimeCode returns a code for the purpose sent to jane@example.com.
+func newOneTimeCode(t *testing.T, purpose, code string, expiresAt time.Time) models.OneTimeCode {
+	oneTimeCode := models.OneTimeCode{Purpose: purpose, Email: "jane@example.com", ExpiresAt: expiresAt}
+	if err := oneTimeCode.SetCode(code); err != nil {
+		t.Fatalf("Failed to hash code: %v", err)
+	}
+	return oneTimeCode
+}
+
+func TestOneTimeCodeRepository_ConsumeOneTimeCode(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.OneTimeCode{})
+
+	repository := NewOneTimeCodeRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if _, err := repository.CreateOneTimeCode(newOneTimeCode(t, models.OneTimeCodeForgot, "111111", now.Add(time.Minute))); err != nil {
+		t.Fatalf("Failed to create code: %v", err)
+	}
+
+	// codes only work for their purpose
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeDeletion, "jane@example.com", "111111", 3, now); !errors.Is(err, ErrOneTimeCodeNotFound) {
+		t.Errorf("Expected ErrOneTimeCodeNotFound for another purpose, got: %v", err)
+	}
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeForgot, "jane@example.com", "222222", 3, now); !errors.Is(err, ErrOneTimeCodeIncorrect) {
+		t.Errorf("Expected ErrOneTimeCodeIncorrect, got: %v", err)
+	}
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeForgot, "jane@example.com", "111111", 3, now.Add(time.Minute)); !errors.Is(err, ErrOneTimeCodeExpired) {
+		t.Errorf("Expected ErrOneTimeCodeExpired, got: %v", err)
+	}
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeForgot, "jane@example.com", "111111", 3, now); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeForgot, "jane@example.com", "111111", 3, now); !errors.Is(err, ErrOneTimeCodeNotFound) {
+		t.Errorf("Expected ErrOneTimeCodeNotFound using a code twice, got: %v", err)
+	}
+
+	// too many wrong codes invalidate the code, even the right one
+	repository.CreateOneTimeCode(newOneTimeCode(t, models.OneTimeCodeVerification, "333333", now.Add(time.Hour)))
+	for i, expected := range []error{ErrOneTimeCodeIncorrect, ErrOneTimeCodeIncorrect, ErrOneTimeCodeExhausted} {
+		if err := repository.ConsumeOneTimeCode(models.OneTimeCodeVerification, "jane@example.com", "000000", 3, now); !errors.Is(err, expected) {
+			t.Errorf("Attempt %d: expected %v, got: %v", i+1, expected, err)
+		}
+	}
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeVerification, "jane@example.com", "333333", 3, now); !errors.Is(err, ErrOneTimeCodeExhausted) {
+		t.Errorf("Expected ErrOneTimeCodeExhausted, got: %v", err)
+	}
+
+	// a new code replaces the exhausted one
+	repository.CreateOneTimeCode(newOneTimeCode(t, models.OneTimeCodeVerification, "444444", now.Add(time.Hour)))
+	if err := repository.ConsumeOneTimeCode(models.OneTimeCodeVerification, "jane@example.com", "444444", 3, now); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	var count int64
+	db.Model(&models.OneTimeCode{}).Where("purpose = ?", models.OneTimeCodeVerification).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected the replaced code to be deleted, got %d codes", count)
+	}
+}
+
+func TestOneTimeCodeRepository_DeleteOneTimeCodes(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.OneTimeCode{})
+
+	repository := NewOneTimeCodeRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	repository.CreateOneTimeCode(newOneTimeCode(t, models.OneTimeCodeForgot, "111111", now.Add(-48*time.Hour)))
+	repository.CreateOneTimeCode(newOneTimeCode(t, models.OneTimeCodeDeletion, "222222", now.Add(time.Hour)))
+
+	if err := repository.DeleteEndedOneTimeCodes(now); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	var count int64
+	db.Model(&models.OneTimeCode{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected the expired code to be deleted, got %d codes", count)
+	}
+
+	if err := repository.DeleteOneTimeCodesByEmail("jane@example.com"); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	db.Model(&models.OneTimeCode{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected the codes of the email to be deleted, got %d codes", count)
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+// ErrSessionEnded is returned when refreshing a session that expired, was revoked or does not exist.
+var ErrSessionEnded = errors.New("session has ended, please log in again")
+
+// ErrRefreshTokenReused is returned when a refresh token is used again after it was replaced. The session is revoked.
+var ErrRefreshTokenReused = errors.New("refresh token was already used, session revoked")
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{database.DB}
+}
+
+type SessionRepositoryInterface interface {
+	CreateSession(session models.Session) (models.Session, error)
+	GetSessionByID(id uint) (models.Session, error)
+	GetActiveSessionsByUserID(userID uint, now time.Time) ([]models.Session, error)
+	RotateRefreshToken(oldHash, newHash, device, ip string, now, expiresAt time.Time) (models.Session, error)
+	RevokeSession(userID, sessionID uint, now time.Time) error
+	RevokeUserSessions(userID, exceptSessionID uint, now time.Time) error
+	DeleteEndedSessions(now time.Time) error
+}
+
+// CreateSession creates a new session.
+func (sr *SessionRepository) CreateSession(session models.Session) (models.Session, error) {
+	if err := sr.db.Create(&session).Error; err != nil {
+		return models.Session{}, err
+	}
+	return session, nil
+}
+
+// GetSessionByID retrieves a session by its ID.
+func (sr *SessionRepository) GetSessionByID(id uint) (models.Session, error) {
+	var session models.Session
+	if err := sr.db.First(&session, id).Error; err != nil {
+		return session, err
+	}
+	return session, nil
+}
+
+// GetActiveSessionsByUserID retrieves the sessions of a user that are neither expired nor revoked, most recently seen first.
+func (sr *SessionRepository) GetActiveSessionsByUserID(userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	if err := sr.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).Order("last_seen_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RotateRefreshToken replaces the refresh token of the session whose token hashes to oldHash, recording where it was used from,
+// and pushes back its expiry. Returns ErrSessionEnded if there is no such active session, and ErrRefreshTokenReused,
+// revoking the session, if oldHash was already replaced, even by a concurrent rotation.
+func (sr *SessionRepository) RotateRefreshToken(oldHash, newHash, device, ip string, now, expiresAt time.Time) (models.Session, error) {
+	result := sr.db.Model(&models.Session{}).
+		Where("refresh_token_hash = ? AND revoked_at IS NULL AND expires_at > ?", oldHash, now).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  newHash,
+			"previous_token_hash": oldHash,
+			"device":              device,
+			"ip":                  ip,
+			"last_seen_at":        now,
+			"expires_at":          expiresAt,
+		})
+	if result.Error != nil {
+		return models.Session{}, result.Error
+	}
+	if result.RowsAffected == 1 {
+		var session models.Session
+		if err := sr.db.Where("refresh_token_hash = ?", newHash).First(&session).Error; err != nil {
+			return models.Session{}, err
+		}
+		return session, nil
+	}
+
+	// the token was replaced before, whoever used it first may have stolen it
+	result = sr.db.Model(&models.Session{}).
+		Where("previous_token_hash = ? AND revoked_at IS NULL", oldHash).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return models.Session{}, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return models.Session{}, ErrRefreshTokenReused
+	}
+	return models.Session{}, ErrSessionEnded
+}
+
+// RevokeSession ends a session of a user. Returns gorm.ErrRecordNotFound if the user has no such active session.
+func (sr *SessionRepository) RevokeSession(userID, sessionID uint, now time.Time) error {
+	result := sr.db.Model(&models.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RevokeUserSessions ends every session of a user except exceptSessionID, 0 to end them all.
+func (sr *SessionRepository) RevokeUserSessions(userID, exceptSessionID uint, now time.Time) error {
+	return sr.db.Model(&models.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Update("revoked_at", now).Error
+}
+
+// DeleteEndedSessions permanently deletes sessions that expired or were revoked before now.
+func (sr *SessionRepository) DeleteEndedSessions(now time.Time) error {
+	return sr.db.Where("expires_at <= ? OR revoked_at <= ?", now, now).Delete(&models.Session{}).Error
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestSessionRepository_RotateRefreshToken(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Session{})
+
+	repository := NewSessionRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	session, err := repository.CreateSession(models.Session{UserID: 1, RefreshTokenHash: "first", Device: "phone", LastSeenAt: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	later := now.Add(30 * time.Minute)
+	rotated, err := repository.RotateRefreshToken("first", "second", "laptop", "10.0.0.1", later, later.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if rotated.ID != session.ID || rotated.RefreshTokenHash != "second" || rotated.Device != "laptop" || !rotated.ExpiresAt.Equal(later.Add(time.Hour)) {
+		t.Errorf("Expected the session to be rotated and pushed back, got %+v", rotated)
+	}
+
+	// unknown tokens end nothing
+	if _, err := repository.RotateRefreshToken("unknown", "third", "laptop", "10.0.0.1", later, later.Add(time.Hour)); !errors.Is(err, ErrSessionEnded) {
+		t.Errorf("Expected ErrSessionEnded, got: %v", err)
+	}
+
+	// reusing the replaced token revokes the session
+	if _, err := repository.RotateRefreshToken("first", "third", "laptop", "10.0.0.2", later, later.Add(time.Hour)); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("Expected ErrRefreshTokenReused, got: %v", err)
+	}
+	if _, err := repository.RotateRefreshToken("second", "third", "laptop", "10.0.0.1", later, later.Add(time.Hour)); !errors.Is(err, ErrSessionEnded) {
+		t.Errorf("Expected the session to be revoked, got: %v", err)
+	}
+
+	// expired sessions cannot be refreshed
+	expired, _ := repository.CreateSession(models.Session{UserID: 1, RefreshTokenHash: "old", LastSeenAt: now, ExpiresAt: now.Add(time.Minute)})
+	if _, err := repository.RotateRefreshToken("old", "new", "phone", "10.0.0.1", later, later.Add(time.Hour)); !errors.Is(err, ErrSessionEnded) {
+		t.Errorf("Expected ErrSessionEnded for session %d, got: %v", expired.ID, err)
+	}
+}
+
+func TestSessionRepository_RevokeSessions(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Session{})
+
+	repository := NewSessionRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	var sessions []models.Session
+	for i, hash := range []string{"a", "b", "c"} {
+		session, err := repository.CreateSession(models.Session{UserID: 1, RefreshTokenHash: hash, LastSeenAt: now.Add(time.Duration(i) * time.Minute), ExpiresAt: now.Add(time.Hour)})
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		sessions = append(sessions, session)
+	}
+	other, _ := repository.CreateSession(models.Session{UserID: 2, RefreshTokenHash: "d", LastSeenAt: now, ExpiresAt: now.Add(time.Hour)})
+
+	active, err := repository.GetActiveSessionsByUserID(1, now)
+	if err != nil || len(active) != 3 || active[0].ID != sessions[2].ID {
+		t.Fatalf("Expected 3 active sessions, most recently seen first, got %v: %v", active, err)
+	}
+
+	// users can only revoke their own sessions
+	if err := repository.RevokeSession(1, other.ID, now); err == nil {
+		t.Errorf("Expected an error revoking the session of another user")
+	}
+	if err := repository.RevokeSession(1, sessions[0].ID, now); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if err := repository.RevokeSession(1, sessions[0].ID, now); err == nil {
+		t.Errorf("Expected an error revoking a revoked session")
+	}
+
+	// all but the current session
+	if err := repository.RevokeUserSessions(1, sessions[2].ID, now); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	active, _ = repository.GetActiveSessionsByUserID(1, now)
+	if len(active) != 1 || active[0].ID != sessions[2].ID {
+		t.Errorf("Expected only the current session to be active, got %v", active)
+	}
+	if active, _ := repository.GetActiveSessionsByUserID(2, now); len(active) != 1 {
+		t.Errorf("Expected the sessions of other users to be kept, got %v", active)
+	}
+
+	// ended sessions are cleaned up
+	if err := repository.DeleteEndedSessions(now.Add(time.Minute)); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	var count int64
+	db.Model(&models.Session{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected the 2 active sessions to be kept, got %d", count)
+	}
+}
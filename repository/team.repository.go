@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/infra/database"
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"gorm.io/gorm"
@@ -20,6 +22,8 @@ type TeamRepositoryInterface interface {
 	GetTeamByInvite(inviteCode string) (models.Team, error)
 	UpdateTeam(team models.Team) (models.Team, error)
 	DeleteTeamByID(id uint) error
+	DeleteTeamCascade(id uint) error
+	ArchiveTeam(id uint, at time.Time) (models.Team, error)
 	GetUnprotectedTeams() ([]models.Team, error)
 	UpdateTeamSuperAdmin(teamID, userID uint) (models.Team, error)
 }
@@ -63,10 +67,70 @@ func (tr *TeamRepository) DeleteTeamByID(id uint) error {
 	return tr.db.Unscoped().Delete(&models.Team{}, id).Error
 }
 
-// GetUnprotectedTeams retrieves all unprotected teams.
+// DeleteTeamCascade permanently deletes a team with its members, entry requests, roles, join rules, invite links and email invites, meetings and their attendance, meeting series and leave requests, all or nothing.
+func (tr *TeamRepository) DeleteTeamCascade(id uint) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		meetingIDs := tx.Unscoped().Model(&models.Meeting{}).Select("id").Where("team_id = ?", id)
+		if err := tx.Unscoped().Where("meeting_id IN (?)", meetingIDs).Delete(&models.MeetingAttendance{}).Error; err != nil {
+			return err
+		}
+		ruleIDs := tx.Unscoped().Model(&models.TeamJoinRule{}).Select("id").Where("team_id = ?", id)
+		if err := tx.Unscoped().Where("join_rule_id IN (?)", ruleIDs).Delete(&models.TeamRosterEntry{}).Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{&models.LeaveRequest{}, &models.Meeting{}, &models.MeetingSeries{}, &models.TeamEntryRequest{}, &models.TeamInviteUse{}, &models.TeamInviteLink{}, &models.TeamEmailInvite{}, &models.TeamJoinRule{}, &models.TeamRole{}, &models.TeamMember{}} {
+			if err := tx.Unscoped().Where("team_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Unscoped().Delete(&models.Team{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ArchiveTeam makes a team read-only, keeping its members and past meetings with their attendance, all or nothing.
+// Meetings that have not started and meeting series are deleted, pending entry requests are rejected and pending email invites revoked.
+func (tr *TeamRepository) ArchiveTeam(id uint, at time.Time) (models.Team, error) {
+	var team models.Team
+	err := tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&team, id).Error; err != nil {
+			return err
+		}
+		team.ArchivedAt = &at
+		if err := tx.Save(&team).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ? AND meeting_period = ? AND meeting_over = ?", id, false, false).Delete(&models.Meeting{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("team_id = ?", id).Delete(&models.MeetingSeries{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.TeamEntryRequest{}).
+			Where("team_id = ? AND status = ?", id, models.TeamEntryRequestPending).
+			Update("status", models.TeamEntryRequestRejected).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.TeamEmailInvite{}).
+			Where("team_id = ? AND status = ?", id, models.TeamEmailInvitePending).
+			Updates(map[string]interface{}{"status": models.TeamEmailInviteRevoked, "responded_at": at}).Error
+	})
+	if err != nil {
+		return models.Team{}, err
+	}
+	return team, nil
+}
+
+// GetUnprotectedTeams retrieves all unprotected teams that are not archived.
 func (tr *TeamRepository) GetUnprotectedTeams() ([]models.Team, error) {
 	var teams []models.Team
-	if err := tr.db.Find(&teams, "protected = ?", false).Error; err != nil {
+	if err := tr.db.Find(&teams, "protected = ? AND archived_at IS NULL", false).Error; err != nil {
 		return nil, err
 	}
 	return teams, nil
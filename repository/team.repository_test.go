@@ -2,9 +2,11 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"gorm.io/gorm"
 )
 
 func TestTeamRepository_CreateTeam(t *testing.T) {
@@ -297,3 +299,139 @@ func TestTeamRepository_UpdateTeamSuperAdmin(t *testing.T) {
 	// 	t.Error("Expected UpdateTeamSuperAdmin to return an error, got nil")
 	// }
 }
+
+// seedTeam creates a team with a member, a pending entry request, a meeting that is over with attendance and a leave request,
+// a meeting that has not started and a meeting series.
+func seedTeam(t *testing.T, db *gorm.DB, name string) (models.Team, models.Meeting, models.Meeting) {
+	team := models.Team{Name: name}
+	if err := db.Create(&team).Error; err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	startTime := time.Now().Add(24 * time.Hour)
+	over := models.Meeting{TeamID: team.ID, Title: "Over", Description: "Sync", Venue: "Room 1", StartTime: startTime}
+	upcoming := models.Meeting{TeamID: team.ID, Title: "Upcoming", Description: "Sync", Venue: "Room 1", StartTime: startTime}
+	series := models.MeetingSeries{TeamID: team.ID, Title: "Weekly", Description: "Sync", Venue: "Room 1", StartTime: startTime, RRule: "FREQ=WEEKLY;COUNT=2"}
+	for _, record := range []interface{}{
+		&models.TeamMember{TeamID: team.ID, UserID: 1, Role: models.SuperAdminRole},
+		&models.TeamEntryRequest{TeamID: team.ID, UserID: 2, Status: models.TeamEntryRequestPending},
+		&over, &upcoming, &series,
+	} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+	over.MeetingOver = true
+	db.Save(&over)
+	for _, record := range []interface{}{
+		&models.MeetingAttendance{MeetingID: over.ID, UserID: 1, AttendanceMarkedAt: startTime, OnTime: true},
+		&models.LeaveRequest{TeamID: team.ID, MeetingID: over.ID, UserID: 3, Reason: "Sick"},
+	} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("Failed to create %T: %v", record, err)
+		}
+	}
+	return team, over, upcoming
+}
+
+func countRows(db *gorm.DB, model interface{}, query string, args ...interface{}) int64 {
+	var count int64
+	db.Unscoped().Model(model).Where(query, args...).Count(&count)
+	return count
+}
+
+func TestTeamRepository_DeleteTeamCascade(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Team{}, &models.TeamMember{}, &models.TeamEntryRequest{}, &models.Meeting{}, &models.MeetingAttendance{}, &models.MeetingSeries{}, &models.LeaveRequest{})
+
+	// Create the Team Repository with the test database
+	tr := NewTeamRepository()
+	tr.db = db
+
+	team, over, _ := seedTeam(t, db, "Deleted")
+	other, otherOver, _ := seedTeam(t, db, "Other")
+
+	if err := tr.DeleteTeamCascade(team.ID); err != nil {
+		t.Fatalf("DeleteTeamCascade returned an error: %v", err)
+	}
+
+	for _, model := range []interface{}{&models.Team{}, &models.TeamMember{}, &models.TeamEntryRequest{}, &models.Meeting{}, &models.MeetingSeries{}, &models.LeaveRequest{}} {
+		column := "team_id"
+		if _, ok := model.(*models.Team); ok {
+			column = "id"
+		}
+		if count := countRows(db, model, column+" = ?", team.ID); count != 0 {
+			t.Errorf("Expected no %T rows of the deleted team, got %d", model, count)
+		}
+		if count := countRows(db, model, column+" = ?", other.ID); count == 0 {
+			t.Errorf("Expected %T rows of the other team to be kept", model)
+		}
+	}
+	if count := countRows(db, &models.MeetingAttendance{}, "meeting_id = ?", over.ID); count != 0 {
+		t.Errorf("Expected no attendance of the deleted team, got %d", count)
+	}
+	if count := countRows(db, &models.MeetingAttendance{}, "meeting_id = ?", otherOver.ID); count != 1 {
+		t.Errorf("Expected the attendance of the other team to be kept, got %d", count)
+	}
+
+	if err := tr.DeleteTeamCascade(team.ID); err == nil {
+		t.Error("Expected an error deleting a team that does not exist")
+	}
+}
+
+func TestTeamRepository_ArchiveTeam(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Team{}, &models.TeamMember{}, &models.TeamEntryRequest{}, &models.Meeting{}, &models.MeetingAttendance{}, &models.MeetingSeries{}, &models.LeaveRequest{})
+
+	// Create the Team Repository with the test database
+	tr := NewTeamRepository()
+	tr.db = db
+
+	team, over, upcoming := seedTeam(t, db, "Archived")
+	protected, _ := tr.CreateTeam(models.Team{Name: "Listed"})
+
+	archivedAt := time.Now()
+	archivedTeam, err := tr.ArchiveTeam(team.ID, archivedAt)
+	if err != nil {
+		t.Fatalf("ArchiveTeam returned an error: %v", err)
+	}
+	if !archivedTeam.Archived() {
+		t.Error("Expected the team to be archived")
+	}
+
+	// past meetings, attendance and members stay, upcoming meetings and series go
+	if err := db.First(&models.Meeting{}, over.ID).Error; err != nil {
+		t.Errorf("Expected the meeting that is over to be kept: %v", err)
+	}
+	if err := db.First(&models.Meeting{}, upcoming.ID).Error; err == nil {
+		t.Error("Expected the upcoming meeting to be deleted")
+	}
+	if err := db.First(&models.MeetingSeries{}, "team_id = ?", team.ID).Error; err == nil {
+		t.Error("Expected the meeting series to be deleted")
+	}
+	if count := countRows(db, &models.MeetingAttendance{}, "meeting_id = ?", over.ID); count != 1 {
+		t.Errorf("Expected the attendance to be kept, got %d", count)
+	}
+	if count := countRows(db, &models.TeamMember{}, "team_id = ?", team.ID); count != 1 {
+		t.Errorf("Expected the members to be kept, got %d", count)
+	}
+	var request models.TeamEntryRequest
+	db.First(&request, "team_id = ?", team.ID)
+	if request.Status != models.TeamEntryRequestRejected {
+		t.Errorf("Expected the pending entry request to be rejected, got %s", request.Status)
+	}
+
+	// archived teams are not listed
+	teams, err := tr.GetUnprotectedTeams()
+	if err != nil {
+		t.Errorf("GetUnprotectedTeams returned an error: %v", err)
+	}
+	if len(teams) != 1 || teams[0].ID != protected.ID {
+		t.Errorf("Expected only the team that is not archived, got %+v", teams)
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+// ErrEmailInviteNotPending is returned when an email invite has already been accepted, declined or revoked.
+var ErrEmailInviteNotPending = errors.New("invitation is no longer pending")
+
+type TeamEmailInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamEmailInviteRepository() *TeamEmailInviteRepository {
+	return &TeamEmailInviteRepository{database.DB}
+}
+
+type TeamEmailInviteRepositoryInterface interface {
+	CreateEmailInvite(invite models.TeamEmailInvite) (models.TeamEmailInvite, error)
+	GetEmailInviteByID(id uint) (models.TeamEmailInvite, error)
+	GetPendingEmailInviteByTeamIDAndEmail(teamID uint, email string) (models.TeamEmailInvite, error)
+	GetEmailInvitesByTeamID(teamID uint, status string) ([]models.TeamEmailInvite, error)
+	GetPendingEmailInvitesByEmail(email string) ([]models.TeamEmailInvite, error)
+	RespondToEmailInvite(id uint, status string, at time.Time) (models.TeamEmailInvite, error)
+	AcceptEmailInvite(invite models.TeamEmailInvite, userID uint, at time.Time, join bool) (models.TeamEmailInvite, error)
+}
+
+// CreateEmailInvite creates a new email invite.
+func (teir *TeamEmailInviteRepository) CreateEmailInvite(invite models.TeamEmailInvite) (models.TeamEmailInvite, error) {
+	if err := teir.db.Create(&invite).Error; err != nil {
+		return models.TeamEmailInvite{}, err
+	}
+	return invite, nil
+}
+
+// GetEmailInviteByID retrieves an email invite by its ID.
+func (teir *TeamEmailInviteRepository) GetEmailInviteByID(id uint) (models.TeamEmailInvite, error) {
+	var invite models.TeamEmailInvite
+	if err := teir.db.First(&invite, id).Error; err != nil {
+		return models.TeamEmailInvite{}, err
+	}
+	return invite, nil
+}
+
+// GetPendingEmailInviteByTeamIDAndEmail retrieves the pending invite of an email to a team. Emails are compared case-insensitively.
+func (teir *TeamEmailInviteRepository) GetPendingEmailInviteByTeamIDAndEmail(teamID uint, email string) (models.TeamEmailInvite, error) {
+	var invite models.TeamEmailInvite
+	if err := teir.db.Where("team_id = ? AND LOWER(email) = LOWER(?) AND status = ?", teamID, email, models.TeamEmailInvitePending).First(&invite).Error; err != nil {
+		return models.TeamEmailInvite{}, err
+	}
+	return invite, nil
+}
+
+// GetEmailInvitesByTeamID retrieves the email invites of a team, newest first. An empty status matches any.
+func (teir *TeamEmailInviteRepository) GetEmailInvitesByTeamID(teamID uint, status string) ([]models.TeamEmailInvite, error) {
+	var invites []models.TeamEmailInvite
+	query := teir.db.Where("team_id = ?", teamID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC").Find(&invites).Error; err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// GetPendingEmailInvitesByEmail retrieves the pending invites of an email to any team, oldest first. Emails are compared case-insensitively.
+func (teir *TeamEmailInviteRepository) GetPendingEmailInvitesByEmail(email string) ([]models.TeamEmailInvite, error) {
+	var invites []models.TeamEmailInvite
+	if err := teir.db.Where("LOWER(email) = LOWER(?) AND status = ?", email, models.TeamEmailInvitePending).Order("created_at").Find(&invites).Error; err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// RespondToEmailInvite declines or revokes a pending email invite.
+// Returns ErrEmailInviteNotPending if it was already responded to.
+func (teir *TeamEmailInviteRepository) RespondToEmailInvite(id uint, status string, at time.Time) (models.TeamEmailInvite, error) {
+	result := teir.db.Model(&models.TeamEmailInvite{}).
+		Where("id = ? AND status = ?", id, models.TeamEmailInvitePending).
+		Updates(map[string]interface{}{"status": status, "responded_at": at})
+	if result.Error != nil {
+		return models.TeamEmailInvite{}, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return models.TeamEmailInvite{}, ErrEmailInviteNotPending
+	}
+	return teir.GetEmailInviteByID(id)
+}
+
+// AcceptEmailInvite accepts a pending email invite and, if join, adds the user to the team with the invite's role, all or nothing.
+// Returns ErrEmailInviteNotPending if it was already responded to.
+func (teir *TeamEmailInviteRepository) AcceptEmailInvite(invite models.TeamEmailInvite, userID uint, at time.Time, join bool) (models.TeamEmailInvite, error) {
+	err := teir.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TeamEmailInvite{}).
+			Where("id = ? AND status = ?", invite.ID, models.TeamEmailInvitePending).
+			Updates(map[string]interface{}{"status": models.TeamEmailInviteAccepted, "responded_at": at})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrEmailInviteNotPending
+		}
+		if !join {
+			return nil
+		}
+		return tx.Create(&models.TeamMember{TeamID: invite.TeamID, UserID: userID, Role: invite.Role}).Error
+	})
+	if err != nil {
+		return models.TeamEmailInvite{}, err
+	}
+	invite.Status = models.TeamEmailInviteAccepted
+	invite.RespondedAt = &at
+	return invite, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestTeamEmailInviteRepository_GetPendingEmailInvites(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamEmailInvite{})
+
+	repository := NewTeamEmailInviteRepository()
+	repository.db = db
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	invites := []models.TeamEmailInvite{
+		{TeamID: 1, Email: "a@example.com", InvitedByID: 1},
+		{TeamID: 2, Email: "a@example.com", InvitedByID: 1},
+		{TeamID: 1, Email: "b@example.com", InvitedByID: 1},
+	}
+	for i, invite := range invites {
+		invites[i], err = repository.CreateEmailInvite(invite)
+		if err != nil {
+			t.Fatalf("Failed to create invite: %v", err)
+		}
+	}
+	if invites[0].Status != models.TeamEmailInvitePending || invites[0].Role != models.MemberRole {
+		t.Errorf("Expected a pending member invite, got %q and %q", invites[0].Status, invites[0].Role)
+	}
+	if _, err := repository.RespondToEmailInvite(invites[1].ID, models.TeamEmailInviteDeclined, now); err != nil {
+		t.Fatalf("Failed to decline invite: %v", err)
+	}
+
+	pending, err := repository.GetPendingEmailInvitesByEmail("a@example.com")
+	if err != nil || len(pending) != 1 || pending[0].ID != invites[0].ID {
+		t.Errorf("Expected the one pending invite, got %+v: %v", pending, err)
+	}
+	if pending, _ := repository.GetPendingEmailInvitesByEmail("A@Example.com"); len(pending) != 1 {
+		t.Errorf("Expected the pending invite of the email in another case, got %d", len(pending))
+	}
+	if _, err := repository.GetPendingEmailInviteByTeamIDAndEmail(2, "a@example.com"); err == nil {
+		t.Errorf("Expected no pending invite after declining")
+	}
+
+	teamInvites, err := repository.GetEmailInvitesByTeamID(1, models.TeamEmailInvitePending)
+	if err != nil || len(teamInvites) != 2 {
+		t.Errorf("Expected 2 pending invites of the team, got %d: %v", len(teamInvites), err)
+	}
+}
+
+func TestTeamEmailInviteRepository_AcceptEmailInvite(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamEmailInvite{}, &models.TeamMember{})
+
+	repository := NewTeamEmailInviteRepository()
+	repository.db = db
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	invite, err := repository.CreateEmailInvite(models.TeamEmailInvite{TeamID: 1, Email: "a@example.com", InvitedByID: 1, Role: models.AdminRole})
+	if err != nil {
+		t.Fatalf("Failed to create invite: %v", err)
+	}
+
+	accepted, err := repository.AcceptEmailInvite(invite, 2, now, true)
+	if err != nil || accepted.Status != models.TeamEmailInviteAccepted {
+		t.Fatalf("Expected the invite to be accepted, got %q: %v", accepted.Status, err)
+	}
+	var member models.TeamMember
+	if err := db.Where("team_id = ? AND user_id = ?", 1, 2).First(&member).Error; err != nil || member.Role != models.AdminRole {
+		t.Errorf("Expected the user to join as admin, got %q: %v", member.Role, err)
+	}
+
+	// an invite can only be responded to once
+	if _, err := repository.AcceptEmailInvite(invite, 2, now, true); !errors.Is(err, ErrEmailInviteNotPending) {
+		t.Errorf("Expected ErrEmailInviteNotPending, got %v", err)
+	}
+	if _, err := repository.RespondToEmailInvite(invite.ID, models.TeamEmailInviteRevoked, now); !errors.Is(err, ErrEmailInviteNotPending) {
+		t.Errorf("Expected ErrEmailInviteNotPending, got %v", err)
+	}
+}
@@ -95,3 +95,101 @@ func (ter *TeamEntryRequestRepository) DeleteTeamEntryRequestByID(requestID uint
 func (ter *TeamEntryRequestRepository) DeleteTeamEntryRequestsByUserID(userID uint) error {
 	return ter.db.Where("user_id = ?", userID).Delete(&models.TeamEntryRequest{}).Error
 }
+
+// CreateApprovedTeamEntryRequest records a request that was approved on creation, and adds the user to the team with the request's role, all or nothing.
+func (ter *TeamEntryRequestRepository) CreateApprovedTeamEntryRequest(request models.TeamEntryRequest) (models.TeamEntryRequest, error) {
+	request.Status = models.TeamEntryRequestApproved
+	err := ter.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&request).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TeamMember{TeamID: request.TeamID, UserID: request.UserID, Role: request.Role}).Error
+	})
+	if err != nil {
+		return models.TeamEntryRequest{}, err
+	}
+	return request, nil
+}
+
+// ReviewTeamEntryRequests sets the status of requests of a team to approved or rejected, adding approved users to the team with
+// the role of their request, all or nothing. With allPending every pending request of the team is reviewed instead of requestIDs.
+// Requests that are not in the team or already have the status are reported in the results and left as they are.
+func (ter *TeamEntryRequestRepository) ReviewTeamEntryRequests(teamID uint, requestIDs []uint, allPending bool, status string) ([]models.TeamEntryRequestReviewResult, error) {
+	var results []models.TeamEntryRequestReviewResult
+	err := ter.db.Transaction(func(tx *gorm.DB) error {
+		var requests []models.TeamEntryRequest
+		query := tx.Where("team_id = ?", teamID)
+		if allPending {
+			query = query.Where("status = ?", models.TeamEntryRequestPending)
+		} else {
+			query = query.Where("id IN ?", requestIDs)
+		}
+		if err := query.Order("id").Find(&requests).Error; err != nil {
+			return err
+		}
+
+		found := make(map[uint]models.TeamEntryRequest, len(requests))
+		for _, request := range requests {
+			found[request.ID] = request
+		}
+		if allPending {
+			requestIDs = make([]uint, len(requests))
+			for i, request := range requests {
+				requestIDs[i] = request.ID
+			}
+		}
+
+		results = make([]models.TeamEntryRequestReviewResult, 0, len(requestIDs))
+		reviewed := make(map[uint]bool, len(requestIDs))
+		for _, id := range requestIDs {
+			if reviewed[id] {
+				continue
+			}
+			reviewed[id] = true
+
+			request, ok := found[id]
+			if !ok {
+				results = append(results, models.TeamEntryRequestReviewResult{RequestID: id, Error: "request not found"})
+				continue
+			}
+			result := models.TeamEntryRequestReviewResult{RequestID: id, UserID: request.UserID, Status: request.Status}
+			switch {
+			case request.Status == models.TeamEntryRequestApproved:
+				result.Error = "request has already been approved"
+			case request.Status == status:
+				result.Error = "request has already been " + status
+			}
+			if result.Error != "" {
+				results = append(results, result)
+				continue
+			}
+
+			if err := tx.Model(&request).Update("status", status).Error; err != nil {
+				return err
+			}
+			if status == models.TeamEntryRequestApproved {
+				var members int64
+				if err := tx.Model(&models.TeamMember{}).Where("team_id = ? AND user_id = ?", teamID, request.UserID).Count(&members).Error; err != nil {
+					return err
+				}
+				role := request.Role
+				if role == "" {
+					role = models.MemberRole
+				}
+				if members == 0 {
+					if err := tx.Create(&models.TeamMember{TeamID: teamID, UserID: request.UserID, Role: role}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			result.Updated = true
+			result.Status = status
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
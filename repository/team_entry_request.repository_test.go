@@ -279,3 +279,79 @@ func TestTeamEntryRequestRepository_DeleteTeamEntryRequestsByUserID(t *testing.T
 		t.Errorf("Expected 0 requests, but got %d", len(retrievedRequests))
 	}
 }
+
+func TestTeamEntryRequestRepository_CreateApprovedTeamEntryRequest(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamEntryRequest{}, &models.TeamMember{})
+
+	repository := NewTeamEntryRequestRepository()
+	repository.db = db
+
+	request, err := repository.CreateApprovedTeamEntryRequest(models.TeamEntryRequest{TeamID: 1, UserID: 2, Role: models.MemberRole, JoinRuleID: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if request.Status != models.TeamEntryRequestApproved || request.JoinRuleID != 3 {
+		t.Errorf("Expected an approved request with its join rule, got %q and %d", request.Status, request.JoinRuleID)
+	}
+
+	var member models.TeamMember
+	if err := db.Where("team_id = ? AND user_id = ?", 1, 2).First(&member).Error; err != nil {
+		t.Errorf("Expected the user to be added to the team, got %v", err)
+	}
+}
+
+func TestTeamEntryRequestRepository_ReviewTeamEntryRequests(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamEntryRequest{}, &models.TeamMember{})
+
+	repository := NewTeamEntryRequestRepository()
+	repository.db = db
+
+	requests := []models.TeamEntryRequest{
+		{TeamID: 1, UserID: 1, Status: models.TeamEntryRequestPending},
+		{TeamID: 1, UserID: 2, Status: models.TeamEntryRequestPending, Role: models.AdminRole},
+		{TeamID: 1, UserID: 3, Status: models.TeamEntryRequestApproved},
+		{TeamID: 2, UserID: 4, Status: models.TeamEntryRequestPending},
+		{TeamID: 1, UserID: 5, Status: models.TeamEntryRequestPending},
+	}
+	for i, request := range requests {
+		requests[i], err = repository.CreateTeamEntryRequest(request)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+	}
+
+	// a list of requests, with one already approved and one of another team
+	results, err := repository.ReviewTeamEntryRequests(1, []uint{requests[0].ID, requests[1].ID, requests[2].ID, requests[3].ID}, false, models.TeamEntryRequestApproved)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(results) != 4 || !results[0].Updated || !results[1].Updated || results[2].Updated || results[3].Updated {
+		t.Fatalf("Expected the first two requests to be updated, got %+v", results)
+	}
+	var member models.TeamMember
+	if err := db.Where("team_id = ? AND user_id = ?", 1, 2).First(&member).Error; err != nil || member.Role != models.AdminRole {
+		t.Errorf("Expected the user to join with the role of the request, got %q: %v", member.Role, err)
+	}
+
+	// all pending requests of the team
+	results, err = repository.ReviewTeamEntryRequests(1, nil, true, models.TeamEntryRequestRejected)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(results) != 1 || results[0].RequestID != requests[4].ID || results[0].Status != models.TeamEntryRequestRejected {
+		t.Errorf("Expected the last pending request to be rejected, got %+v", results)
+	}
+	var members int64
+	db.Model(&models.TeamMember{}).Where("team_id = ?", 1).Count(&members)
+	if members != 2 {
+		t.Errorf("Expected 2 members, got %d", members)
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+// ErrInviteLinkUnusable is returned when an invite link was revoked, expired or used up before it could be redeemed.
+var ErrInviteLinkUnusable = errors.New("invite link is no longer usable")
+
+type TeamInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamInviteRepository() *TeamInviteRepository {
+	return &TeamInviteRepository{database.DB}
+}
+
+type TeamInviteRepositoryInterface interface {
+	CreateInviteLink(link models.TeamInviteLink) (models.TeamInviteLink, error)
+	GetInviteLinkByID(id uint) (models.TeamInviteLink, error)
+	GetInviteLinkByCode(code string) (models.TeamInviteLink, error)
+	GetInviteLinksByTeamID(teamID uint) ([]models.TeamInviteLink, error)
+	RevokeInviteLink(id uint, at time.Time) (models.TeamInviteLink, error)
+	RedeemInviteLink(link models.TeamInviteLink, userID uint, now time.Time, records ...interface{}) error
+	GetInviteUsesByInviteLinkID(linkID uint) ([]models.TeamInviteUse, error)
+}
+
+// CreateInviteLink creates a new invite link, generating its code.
+func (tir *TeamInviteRepository) CreateInviteLink(link models.TeamInviteLink) (models.TeamInviteLink, error) {
+	if err := tir.db.Create(&link).Error; err != nil {
+		return models.TeamInviteLink{}, err
+	}
+	return link, nil
+}
+
+// GetInviteLinkByID retrieves an invite link by its ID.
+func (tir *TeamInviteRepository) GetInviteLinkByID(id uint) (models.TeamInviteLink, error) {
+	var link models.TeamInviteLink
+	if err := tir.db.First(&link, id).Error; err != nil {
+		return models.TeamInviteLink{}, err
+	}
+	return link, nil
+}
+
+// GetInviteLinkByCode retrieves an invite link by its code.
+func (tir *TeamInviteRepository) GetInviteLinkByCode(code string) (models.TeamInviteLink, error) {
+	var link models.TeamInviteLink
+	if err := tir.db.Where("code = ?", code).First(&link).Error; err != nil {
+		return models.TeamInviteLink{}, err
+	}
+	return link, nil
+}
+
+// GetInviteLinksByTeamID retrieves all invite links of a team, including revoked ones, newest first.
+func (tir *TeamInviteRepository) GetInviteLinksByTeamID(teamID uint) ([]models.TeamInviteLink, error) {
+	var links []models.TeamInviteLink
+	if err := tir.db.Where("team_id = ?", teamID).Order("created_at DESC").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// RevokeInviteLink stops an invite link from being used. The link is kept to see who joined with it.
+func (tir *TeamInviteRepository) RevokeInviteLink(id uint, at time.Time) (models.TeamInviteLink, error) {
+	var link models.TeamInviteLink
+	if err := tir.db.First(&link, id).Error; err != nil {
+		return models.TeamInviteLink{}, err
+	}
+	if link.RevokedAt == nil {
+		link.RevokedAt = &at
+		if err := tir.db.Save(&link).Error; err != nil {
+			return models.TeamInviteLink{}, err
+		}
+	}
+	return link, nil
+}
+
+// RedeemInviteLink counts a use of the link by a user and creates the records it led to, a *models.TeamMember if they joined
+// and a *models.TeamEntryRequest if they asked to, all or nothing.
+// Returns ErrInviteLinkUnusable if the link was revoked, expired or used up, even by a concurrent redeem.
+func (tir *TeamInviteRepository) RedeemInviteLink(link models.TeamInviteLink, userID uint, now time.Time, records ...interface{}) error {
+	outcome := models.TeamInviteRequested
+	for _, record := range records {
+		if _, ok := record.(*models.TeamMember); ok {
+			outcome = models.TeamInviteJoined
+		}
+	}
+
+	return tir.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TeamInviteLink{}).
+			Where("id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?) AND (max_uses = 0 OR uses < max_uses)", link.ID, now).
+			Update("uses", gorm.Expr("uses + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrInviteLinkUnusable
+		}
+		for _, record := range records {
+			if err := tx.Create(record).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&models.TeamInviteUse{InviteLinkID: link.ID, TeamID: link.TeamID, UserID: userID, Outcome: outcome}).Error
+	})
+}
+
+// GetInviteUsesByInviteLinkID retrieves who used an invite link, oldest first.
+func (tir *TeamInviteRepository) GetInviteUsesByInviteLinkID(linkID uint) ([]models.TeamInviteUse, error) {
+	var uses []models.TeamInviteUse
+	if err := tir.db.Where("invite_link_id = ?", linkID).Order("created_at").Find(&uses).Error; err != nil {
+		return nil, err
+	}
+	return uses, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestTeamInviteRepository_CreateInviteLink(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamInviteLink{})
+
+	repository := NewTeamInviteRepository()
+	repository.db = db
+
+	link, err := repository.CreateInviteLink(models.TeamInviteLink{TeamID: 1, CreatedByID: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(link.Code) != 16 || link.Role != models.MemberRole {
+		t.Errorf("Expected a generated code and the member role, got %q and %q", link.Code, link.Role)
+	}
+
+	found, err := repository.GetInviteLinkByCode(link.Code)
+	if err != nil || found.ID != link.ID {
+		t.Errorf("Expected to find the link by its code, got %v", err)
+	}
+
+	if _, err := repository.CreateInviteLink(models.TeamInviteLink{TeamID: 1, CreatedByID: 1, Role: models.SuperAdminRole}); err == nil {
+		t.Errorf("Expected an error for a super admin link")
+	}
+}
+
+func TestTeamInviteRepository_RedeemInviteLink(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamInviteLink{}, &models.TeamInviteUse{}, &models.TeamMember{}, &models.TeamEntryRequest{})
+
+	repository := NewTeamInviteRepository()
+	repository.db = db
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+	link, err := repository.CreateInviteLink(models.TeamInviteLink{TeamID: 1, CreatedByID: 1, MaxUses: 2, ExpiresAt: &expiresAt, Role: models.AdminRole})
+	if err != nil {
+		t.Fatalf("Failed to create link: %v", err)
+	}
+
+	// a member and a request use up the link
+	if err := repository.RedeemInviteLink(link, 2, now, &models.TeamMember{TeamID: 1, UserID: 2, Role: link.Role}); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if err := repository.RedeemInviteLink(link, 3, now, &models.TeamEntryRequest{TeamID: 1, UserID: 3, Role: link.Role}); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	err = repository.RedeemInviteLink(link, 4, now, &models.TeamMember{TeamID: 1, UserID: 4, Role: link.Role})
+	if !errors.Is(err, ErrInviteLinkUnusable) {
+		t.Errorf("Expected the link to be used up, got %v", err)
+	}
+
+	uses, err := repository.GetInviteUsesByInviteLinkID(link.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(uses) != 2 || uses[0].Outcome != models.TeamInviteJoined || uses[1].Outcome != models.TeamInviteRequested {
+		t.Errorf("Expected a joined and a requested use, got %+v", uses)
+	}
+	var members int64
+	db.Model(&models.TeamMember{}).Where("team_id = ?", 1).Count(&members)
+	if members != 1 {
+		t.Errorf("Expected the failed redeem to add no member, got %d members", members)
+	}
+
+	// expired
+	unlimited, _ := repository.CreateInviteLink(models.TeamInviteLink{TeamID: 1, CreatedByID: 1, ExpiresAt: &expiresAt})
+	err = repository.RedeemInviteLink(unlimited, 5, expiresAt, &models.TeamMember{TeamID: 1, UserID: 5, Role: models.MemberRole})
+	if !errors.Is(err, ErrInviteLinkUnusable) {
+		t.Errorf("Expected the link to be expired, got %v", err)
+	}
+
+	// revoked
+	revoked, err := repository.RevokeInviteLink(unlimited.ID, now)
+	if err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("Failed to revoke link: %v", err)
+	}
+	err = repository.RedeemInviteLink(unlimited, 5, now, &models.TeamMember{TeamID: 1, UserID: 5, Role: models.MemberRole})
+	if !errors.Is(err, ErrInviteLinkUnusable) {
+		t.Errorf("Expected the link to be revoked, got %v", err)
+	}
+
+	links, err := repository.GetInviteLinksByTeamID(1)
+	if err != nil || len(links) != 2 {
+		t.Errorf("Expected 2 links, got %d: %v", len(links), err)
+	}
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+type TeamJoinRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamJoinRuleRepository() *TeamJoinRuleRepository {
+	return &TeamJoinRuleRepository{database.DB}
+}
+
+type TeamJoinRuleRepositoryInterface interface {
+	CreateJoinRule(rule models.TeamJoinRule, roster []string) (models.TeamJoinRule, error)
+	GetJoinRuleByID(id uint) (models.TeamJoinRule, error)
+	GetJoinRulesByTeamID(teamID uint) ([]models.TeamJoinRule, error)
+	GetMatchingJoinRule(teamID uint, email string) (models.TeamJoinRule, error)
+	DeleteJoinRule(id uint) error
+}
+
+// CreateJoinRule creates a join rule with the emails of its roster, all or nothing.
+func (tjr *TeamJoinRuleRepository) CreateJoinRule(rule models.TeamJoinRule, roster []string) (models.TeamJoinRule, error) {
+	err := tjr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&rule).Error; err != nil {
+			return err
+		}
+		if len(roster) == 0 {
+			return nil
+		}
+		entries := make([]models.TeamRosterEntry, len(roster))
+		for i, email := range roster {
+			entries[i] = models.TeamRosterEntry{JoinRuleID: rule.ID, Email: email}
+		}
+		return tx.CreateInBatches(entries, 500).Error
+	})
+	if err != nil {
+		return models.TeamJoinRule{}, err
+	}
+	rule.RosterSize = len(roster)
+	return rule, nil
+}
+
+// GetJoinRuleByID retrieves a join rule by its ID.
+func (tjr *TeamJoinRuleRepository) GetJoinRuleByID(id uint) (models.TeamJoinRule, error) {
+	var rule models.TeamJoinRule
+	if err := tjr.db.First(&rule, id).Error; err != nil {
+		return models.TeamJoinRule{}, err
+	}
+	return rule, nil
+}
+
+// GetJoinRulesByTeamID retrieves the join rules of a team, oldest first, with the size of their rosters.
+func (tjr *TeamJoinRuleRepository) GetJoinRulesByTeamID(teamID uint) ([]models.TeamJoinRule, error) {
+	var rules []models.TeamJoinRule
+	if err := tjr.db.Where("team_id = ?", teamID).Order("id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].Type != models.JoinRuleRoster {
+			continue
+		}
+		var count int64
+		if err := tjr.db.Model(&models.TeamRosterEntry{}).Where("join_rule_id = ?", rules[i].ID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		rules[i].RosterSize = int(count)
+	}
+	return rules, nil
+}
+
+// GetMatchingJoinRule retrieves the oldest join rule of a team that matches the email, or gorm.ErrRecordNotFound if none does.
+func (tjr *TeamJoinRuleRepository) GetMatchingJoinRule(teamID uint, email string) (models.TeamJoinRule, error) {
+	rules, err := tjr.GetJoinRulesByTeamID(teamID)
+	if err != nil {
+		return models.TeamJoinRule{}, err
+	}
+	email = strings.ToLower(email)
+	for _, rule := range rules {
+		if rule.MatchesDomain(email) {
+			return rule, nil
+		}
+		if rule.Type != models.JoinRuleRoster {
+			continue
+		}
+		var count int64
+		if err := tjr.db.Model(&models.TeamRosterEntry{}).Where("join_rule_id = ? AND email = ?", rule.ID, email).Count(&count).Error; err != nil {
+			return models.TeamJoinRule{}, err
+		}
+		if count > 0 {
+			return rule, nil
+		}
+	}
+	return models.TeamJoinRule{}, gorm.ErrRecordNotFound
+}
+
+// DeleteJoinRule permanently deletes a join rule with its roster, all or nothing. Requests it approved keep its ID.
+func (tjr *TeamJoinRuleRepository) DeleteJoinRule(id uint) error {
+	return tjr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("join_rule_id = ?", id).Delete(&models.TeamRosterEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.TeamJoinRule{}, id).Error
+	})
+}
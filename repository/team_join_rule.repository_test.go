@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"gorm.io/gorm"
+)
+
+func TestTeamJoinRuleRepository_GetMatchingJoinRule(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamJoinRule{}, &models.TeamRosterEntry{})
+
+	repository := NewTeamJoinRuleRepository()
+	repository.db = db
+
+	domainRule, err := repository.CreateJoinRule(models.TeamJoinRule{TeamID: 1, Type: models.JoinRuleEmailDomain, Domain: "@VITstudent.ac.in", CreatedByID: 1}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	if domainRule.Domain != "vitstudent.ac.in" {
+		t.Errorf("Expected the domain to be normalised, got %q", domainRule.Domain)
+	}
+	rosterRule, err := repository.CreateJoinRule(models.TeamJoinRule{TeamID: 1, Type: models.JoinRuleRoster, CreatedByID: 1}, []string{"a@gmail.com", "b@gmail.com"})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	if _, err := repository.CreateJoinRule(models.TeamJoinRule{TeamID: 1, Type: models.JoinRuleEmailDomain, CreatedByID: 1}, nil); err == nil {
+		t.Errorf("Expected an error for a domain rule without a domain")
+	}
+
+	rule, err := repository.GetMatchingJoinRule(1, "Someone@vitstudent.ac.in")
+	if err != nil || rule.ID != domainRule.ID {
+		t.Errorf("Expected the domain rule to match, got %d: %v", rule.ID, err)
+	}
+	rule, err = repository.GetMatchingJoinRule(1, "b@gmail.com")
+	if err != nil || rule.ID != rosterRule.ID {
+		t.Errorf("Expected the roster rule to match, got %d: %v", rule.ID, err)
+	}
+	for _, email := range []string{"c@gmail.com", "someone@notvitstudent.ac.in"} {
+		if _, err := repository.GetMatchingJoinRule(1, email); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("Expected no rule to match %s, got %v", email, err)
+		}
+	}
+	if _, err := repository.GetMatchingJoinRule(2, "b@gmail.com"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected rules of other teams not to match, got %v", err)
+	}
+
+	rules, err := repository.GetJoinRulesByTeamID(1)
+	if err != nil || len(rules) != 2 || rules[1].RosterSize != 2 {
+		t.Errorf("Expected 2 rules with a roster of 2, got %+v: %v", rules, err)
+	}
+
+	if err := repository.DeleteJoinRule(rosterRule.ID); err != nil {
+		t.Fatalf("Failed to delete rule: %v", err)
+	}
+	var entries int64
+	db.Model(&models.TeamRosterEntry{}).Count(&entries)
+	if entries != 0 {
+		t.Errorf("Expected the roster to be deleted, got %d entries", entries)
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/GDGVIT/attendance-app-backend/infra/database"
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"gorm.io/gorm"
@@ -20,6 +22,8 @@ type TeamMemberRepositoryInterface interface {
 	UpdateTeamMember(teamMember models.TeamMember) (models.TeamMember, error)
 	DeleteTeamMember(teamID, userID uint) error
 	GetTeamMembersByTeamID(teamID uint) ([]models.TeamMember, error)
+	GetTeamMembersByTeamIDAsOf(teamID uint, at time.Time) ([]models.TeamMember, error)
+	EachTeamMemberBetween(teamID uint, from, to time.Time, meetingIDs []uint, fn func(member models.TeamMemberUser) error) error
 	GetTeamMembersByUserID(userID uint) ([]models.TeamMember, error)
 	GetTeamMembersByUserAndRole(userID uint, role string) ([]models.TeamMember, error)
 	GetTeamMembersByTeamAndRole(teamID uint, role string) ([]models.TeamMember, error)
@@ -67,6 +71,46 @@ func (tmr *TeamMemberRepository) GetTeamMembersByTeamID(teamID uint) ([]models.T
 	return teamMembers, nil
 }
 
+// GetTeamMembersByTeamIDAsOf retrieves the TeamMembers of a team at the given time, i.e. those who had joined by then and had not yet left.
+// Members who left since are included, a member who left and rejoined can appear more than once.
+func (tmr *TeamMemberRepository) GetTeamMembersByTeamIDAsOf(teamID uint, at time.Time) ([]models.TeamMember, error) {
+	var teamMembers []models.TeamMember
+	if err := tmr.db.Unscoped().Where("team_id = ? AND created_at <= ? AND (deleted_at IS NULL OR deleted_at > ?)", teamID, at, at).Find(&teamMembers).Error; err != nil {
+		return nil, err
+	}
+	return teamMembers, nil
+}
+
+// EachTeamMemberBetween calls fn with each TeamMember of a team at any time between from and to, with the name and email of its user,
+// ordered by UserID. Rows are read one at a time, so a whole team is never held in memory, and fn must not query the database.
+// Members with attendance of one of the meetings are included even if they were not in the team then, as imported history is
+// recorded for members who joined later.
+// Like GetTeamMembersByTeamIDAsOf, a member who left and rejoined can appear more than once. Members whose account was deleted are left out.
+func (tmr *TeamMemberRepository) EachTeamMemberBetween(teamID uint, from, to time.Time, meetingIDs []uint, fn func(member models.TeamMemberUser) error) error {
+	attendees := tmr.db.Model(&models.MeetingAttendance{}).Select("user_id").Where("meeting_id IN ?", meetingIDs)
+	rows, err := tmr.db.Unscoped().Model(&models.TeamMember{}).
+		Select("team_members.*, users.name, users.email").
+		Joins("JOIN users ON users.id = team_members.user_id AND users.deleted_at IS NULL").
+		Where("team_members.team_id = ? AND (team_members.created_at <= ? AND (team_members.deleted_at IS NULL OR team_members.deleted_at > ?) OR team_members.user_id IN (?))", teamID, to, from, attendees).
+		Order("team_members.user_id, team_members.created_at").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member models.TeamMemberUser
+		if err := tmr.db.ScanRows(rows, &member); err != nil {
+			return err
+		}
+		if err := fn(member); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // GetTeamMembersByUserID retrieves all TeamMembers for a given UserID.
 func (tmr *TeamMemberRepository) GetTeamMembersByUserID(userID uint) ([]models.TeamMember, error) {
 	var teamMembers []models.TeamMember
@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
@@ -224,6 +228,160 @@ func TestTeamMemberRepository_GetTeamMembersByTeamID(t *testing.T) {
 	}
 }
 
+func TestTeamMemberRepository_GetTeamMembersByTeamIDAsOf(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamMember{})
+
+	// Create the TeamMember Repository with the test database
+	tmr := NewTeamMemberRepository()
+	tmr.db = db
+
+	teamID := uint(1)
+	meetingTime := time.Now()
+
+	// Joined before the meeting and still a member
+	stayed := models.TeamMember{TeamID: teamID, UserID: 1, Role: models.MemberRole}
+	stayed.CreatedAt = meetingTime.Add(-48 * time.Hour)
+	// Joined before the meeting and left after it
+	leftAfter := models.TeamMember{TeamID: teamID, UserID: 2, Role: models.MemberRole}
+	leftAfter.CreatedAt = meetingTime.Add(-48 * time.Hour)
+	// Joined before the meeting and left before it
+	leftBefore := models.TeamMember{TeamID: teamID, UserID: 3, Role: models.MemberRole}
+	leftBefore.CreatedAt = meetingTime.Add(-48 * time.Hour)
+	// Joined after the meeting
+	joinedAfter := models.TeamMember{TeamID: teamID, UserID: 4, Role: models.MemberRole}
+	joinedAfter.CreatedAt = meetingTime.Add(time.Hour)
+
+	for _, teamMember := range []models.TeamMember{stayed, leftAfter, leftBefore, joinedAfter} {
+		_, err = tmr.CreateTeamMember(teamMember)
+		if err != nil {
+			t.Fatalf("Failed to create a test team member: %v", err)
+		}
+	}
+	db.Model(&models.TeamMember{}).Where("user_id = ?", 2).Update("deleted_at", meetingTime.Add(time.Hour))
+	db.Model(&models.TeamMember{}).Where("user_id = ?", 3).Update("deleted_at", meetingTime.Add(-time.Hour))
+
+	// Test GetTeamMembersByTeamIDAsOf function to retrieve team members at the time of the meeting
+	retrievedTeamMembers, err := tmr.GetTeamMembersByTeamIDAsOf(teamID, meetingTime)
+	if err != nil {
+		t.Errorf("GetTeamMembersByTeamIDAsOf returned an error: %v", err)
+	}
+
+	if len(retrievedTeamMembers) != 2 {
+		t.Fatalf("Expected 2 team members, got %d", len(retrievedTeamMembers))
+	}
+	for _, retrieved := range retrievedTeamMembers {
+		if retrieved.UserID != 1 && retrieved.UserID != 2 {
+			t.Errorf("Unexpected team member with UserID %d", retrieved.UserID)
+		}
+	}
+}
+
+func TestTeamMemberRepository_EachTeamMemberBetween(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamMember{}, &models.User{}, &models.MeetingAttendance{})
+
+	// Create the TeamMember Repository with the test database
+	tmr := NewTeamMemberRepository()
+	tmr.db = db
+
+	teamID := uint(1)
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+
+	// Joined before the range and still a member
+	stayed := models.TeamMember{TeamID: teamID, UserID: 1, Role: models.MemberRole}
+	stayed.CreatedAt = from.Add(-time.Hour)
+	// Joined before the range and left during it
+	leftDuring := models.TeamMember{TeamID: teamID, UserID: 2, Role: models.MemberRole}
+	leftDuring.CreatedAt = from.Add(-time.Hour)
+	// Joined and left before the range
+	leftBefore := models.TeamMember{TeamID: teamID, UserID: 3, Role: models.MemberRole}
+	leftBefore.CreatedAt = from.Add(-2 * time.Hour)
+	// Joined during the range
+	joinedDuring := models.TeamMember{TeamID: teamID, UserID: 4, Role: models.MemberRole}
+	joinedDuring.CreatedAt = to.Add(-time.Hour)
+	// Joined after the range
+	joinedAfter := models.TeamMember{TeamID: teamID, UserID: 5, Role: models.MemberRole}
+	joinedAfter.CreatedAt = to.Add(time.Hour)
+	// Deleted their account
+	deleted := models.TeamMember{TeamID: teamID, UserID: 6, Role: models.MemberRole}
+	deleted.CreatedAt = from.Add(-time.Hour)
+
+	// users 1 to 5 exist
+	for userID := uint(1); userID <= 5; userID++ {
+		user := models.User{Name: fmt.Sprintf("User %d", userID), Email: fmt.Sprintf("user%d@example.com", userID)}
+		user.ID = userID
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("Failed to create a test user: %v", err)
+		}
+	}
+
+	for _, teamMember := range []models.TeamMember{joinedDuring, stayed, leftDuring, leftBefore, joinedAfter, deleted} {
+		_, err = tmr.CreateTeamMember(teamMember)
+		if err != nil {
+			t.Fatalf("Failed to create a test team member: %v", err)
+		}
+	}
+	db.Model(&models.TeamMember{}).Where("user_id = ?", 2).Update("deleted_at", from.Add(time.Hour))
+	db.Model(&models.TeamMember{}).Where("user_id = ?", 3).Update("deleted_at", from.Add(-time.Hour))
+
+	// Test EachTeamMemberBetween function to read team members at any time in the range with their users, ordered by user
+	var retrievedTeamMembers []models.TeamMemberUser
+	err = tmr.EachTeamMemberBetween(teamID, from, to, nil, func(member models.TeamMemberUser) error {
+		retrievedTeamMembers = append(retrievedTeamMembers, member)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("EachTeamMemberBetween returned an error: %v", err)
+	}
+
+	if len(retrievedTeamMembers) != 3 {
+		t.Fatalf("Expected 3 team members, got %d", len(retrievedTeamMembers))
+	}
+	for i, userID := range []uint{1, 2, 4} {
+		if retrievedTeamMembers[i].UserID != userID {
+			t.Errorf("Expected team member %d to have UserID %d, got %d", i, userID, retrievedTeamMembers[i].UserID)
+		}
+		if retrievedTeamMembers[i].Email != fmt.Sprintf("user%d@example.com", userID) {
+			t.Errorf("Expected team member %d to have the email of user %d, got %q", i, userID, retrievedTeamMembers[i].Email)
+		}
+	}
+	if !retrievedTeamMembers[1].DeletedAt.Valid {
+		t.Errorf("Expected the membership of user 2 to have ended")
+	}
+
+	// members who joined after the range are included with attendance of one of the meetings, as for imported history
+	db.Create(&models.MeetingAttendance{UserID: 5, MeetingID: 7, AttendanceMarkedAt: from, Status: models.AttendanceStatusAbsent})
+	var withAttendance []uint
+	err = tmr.EachTeamMemberBetween(teamID, from, to, []uint{7}, func(member models.TeamMemberUser) error {
+		withAttendance = append(withAttendance, member.UserID)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("EachTeamMemberBetween returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(withAttendance, []uint{1, 2, 4, 5}) {
+		t.Errorf("Expected users 1, 2, 4 and 5, got %v", withAttendance)
+	}
+
+	// errors of fn stop reading
+	calls := 0
+	err = tmr.EachTeamMemberBetween(teamID, from, to, nil, func(member models.TeamMemberUser) error {
+		calls++
+		return errors.New("write failed")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("Expected the error of the first call, got %v after %d calls", err, calls)
+	}
+}
+
 func TestTeamMemberRepository_GetTeamMembersByUserID(t *testing.T) {
 	db, err := test_utils.SetupTestDB()
 	if err != nil {
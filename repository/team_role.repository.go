@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+type TeamRoleRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRoleRepository() *TeamRoleRepository {
+	return &TeamRoleRepository{database.DB}
+}
+
+type TeamRoleRepositoryInterface interface {
+	CreateTeamRole(role models.TeamRole) (models.TeamRole, error)
+	GetTeamRoleByID(id uint) (models.TeamRole, error)
+	GetTeamRoleByName(teamID uint, name string) (models.TeamRole, error)
+	GetTeamRolesByTeamID(teamID uint) ([]models.TeamRole, error)
+	UpdateTeamRole(role models.TeamRole) (models.TeamRole, error)
+	DeleteTeamRole(id uint) error
+	GetPermissions(teamID uint, role string) ([]string, error)
+}
+
+// CreateTeamRole creates a new role of a team.
+func (trr *TeamRoleRepository) CreateTeamRole(role models.TeamRole) (models.TeamRole, error) {
+	if err := trr.db.Create(&role).Error; err != nil {
+		return models.TeamRole{}, err
+	}
+	return role, nil
+}
+
+// GetTeamRoleByID retrieves a team role by its ID.
+func (trr *TeamRoleRepository) GetTeamRoleByID(id uint) (models.TeamRole, error) {
+	var role models.TeamRole
+	if err := trr.db.First(&role, id).Error; err != nil {
+		return models.TeamRole{}, err
+	}
+	return role, nil
+}
+
+// GetTeamRoleByName retrieves a role of a team by its name.
+func (trr *TeamRoleRepository) GetTeamRoleByName(teamID uint, name string) (models.TeamRole, error) {
+	var role models.TeamRole
+	if err := trr.db.Where("team_id = ? AND name = ?", teamID, name).First(&role).Error; err != nil {
+		return models.TeamRole{}, err
+	}
+	return role, nil
+}
+
+// GetTeamRolesByTeamID retrieves the roles defined by a team, by name.
+func (trr *TeamRoleRepository) GetTeamRolesByTeamID(teamID uint) ([]models.TeamRole, error) {
+	var roles []models.TeamRole
+	if err := trr.db.Where("team_id = ?", teamID).Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// UpdateTeamRole updates an existing team role.
+func (trr *TeamRoleRepository) UpdateTeamRole(role models.TeamRole) (models.TeamRole, error) {
+	if err := trr.db.Save(&role).Error; err != nil {
+		return models.TeamRole{}, err
+	}
+	return role, nil
+}
+
+// DeleteTeamRole permanently deletes a team role, so its name can be used again.
+func (trr *TeamRoleRepository) DeleteTeamRole(id uint) error {
+	return trr.db.Unscoped().Delete(&models.TeamRole{}, id).Error
+}
+
+// GetPermissions returns the permissions of a role in a team, built in or defined by the team.
+// A role the team does not define has no permissions.
+func (trr *TeamRoleRepository) GetPermissions(teamID uint, role string) ([]string, error) {
+	if permissions, ok := models.BuiltInRolePermissions(role); ok {
+		return permissions, nil
+	}
+	teamRole, err := trr.GetTeamRoleByName(teamID, role)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return teamRole.EffectivePermissions(), nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestTeamRoleRepository_CreateTeamRole(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamRole{})
+
+	repository := NewTeamRoleRepository()
+	repository.db = db
+
+	role, err := repository.CreateTeamRole(models.TeamRole{
+		TeamID:      1,
+		Name:        "attendance_taker",
+		Permissions: []string{models.PermissionAttendanceStart, models.PermissionAttendanceStart},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(role.Permissions) != 1 {
+		t.Errorf("Expected duplicate permissions to be removed, got %v", role.Permissions)
+	}
+
+	found, err := repository.GetTeamRoleByName(1, "attendance_taker")
+	if err != nil || len(found.Permissions) != 1 || found.Permissions[0] != models.PermissionAttendanceStart {
+		t.Errorf("Expected the role to be stored with its permissions, got %+v: %v", found, err)
+	}
+
+	invalid := []models.TeamRole{
+		{TeamID: 1, Name: "attendance_taker"},                                             // taken in the team
+		{TeamID: 1, Name: models.AdminRole},                                               // built in
+		{TeamID: 1, Name: "Viewer"},                                                       // not lowercase
+		{TeamID: 1, Name: "viewer", Permissions: []string{"reports.print"}},               // unknown permission
+		{TeamID: 1, Name: "viewer", Permissions: []string{models.PermissionTeamDelete}},   // reserved permission
+		{TeamID: 1, Name: "viewer", Permissions: []string{models.PermissionRolesManage}},  // reserved permission
+		{TeamID: 1, Name: "viewer", Permissions: []string{models.PermissionTeamHandover}}, // reserved permission
+	}
+	for _, role := range invalid {
+		if _, err := repository.CreateTeamRole(role); err == nil {
+			t.Errorf("Expected an error creating %+v", role)
+		}
+	}
+
+	// the name can be used in other teams, and again once deleted
+	if _, err := repository.CreateTeamRole(models.TeamRole{TeamID: 2, Name: "attendance_taker"}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if err := repository.DeleteTeamRole(role.ID); err != nil {
+		t.Fatalf("Failed to delete role: %v", err)
+	}
+	if _, err := repository.CreateTeamRole(models.TeamRole{TeamID: 1, Name: "attendance_taker"}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+}
+
+func TestTeamRoleRepository_GetPermissions(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TeamRole{})
+
+	repository := NewTeamRoleRepository()
+	repository.db = db
+
+	if _, err := repository.CreateTeamRole(models.TeamRole{TeamID: 1, Name: "viewer", Permissions: []string{models.PermissionReportsView}}); err != nil {
+		t.Fatalf("Failed to create role: %v", err)
+	}
+
+	tests := []struct {
+		teamID     uint
+		role       string
+		permission string
+		want       bool
+	}{
+		{1, models.SuperAdminRole, models.PermissionTeamDelete, true},
+		{1, models.AdminRole, models.PermissionAttendanceStart, true},
+		{1, models.AdminRole, models.PermissionMembersKick, false},
+		{1, models.MemberRole, models.PermissionAttendanceMark, true},
+		{1, models.MemberRole, models.PermissionReportsView, false},
+		{1, "viewer", models.PermissionReportsView, true},
+		{1, "viewer", models.PermissionMeetingView, true}, // of members
+		{1, "viewer", models.PermissionMembersKick, false},
+		{2, "viewer", models.PermissionMeetingView, false}, // not a role of the team
+	}
+	for _, tt := range tests {
+		permissions, err := repository.GetPermissions(tt.teamID, tt.role)
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if got := models.HasPermission(permissions, tt.permission); got != tt.want {
+			t.Errorf("%s of team %d has %s = %v, want %v", tt.role, tt.teamID, tt.permission, got, tt.want)
+		}
+	}
+}
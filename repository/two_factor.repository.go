@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"gorm.io/gorm"
+)
+
+// ErrTwoFactorCodeUsed is returned when a code is used a second time while still valid.
+var ErrTwoFactorCodeUsed = errors.New("two-factor code was already used")
+
+// ErrTwoFactorEnabled is returned when enrolling a user whose second factor is already confirmed.
+var ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository() *TwoFactorRepository {
+	return &TwoFactorRepository{database.DB}
+}
+
+type TwoFactorRepositoryInterface interface {
+	GetTwoFactorByUserID(userID uint) (models.TwoFactor, error)
+	IsTwoFactorEnabled(userID uint) (bool, error)
+	SaveTwoFactorSecret(userID uint, secret string) (models.TwoFactor, error)
+	ConfirmTwoFactor(userID uint, recoveryCodeHashes []string, now time.Time) error
+	UseTwoFactorCode(userID uint, code string, now, reusableBefore time.Time) error
+	ReplaceRecoveryCodes(userID uint, recoveryCodeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string, now time.Time) error
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+	DeleteTwoFactor(userID uint) error
+}
+
+// GetTwoFactorByUserID retrieves the second factor of a user, confirmed or not.
+func (tr *TwoFactorRepository) GetTwoFactorByUserID(userID uint) (models.TwoFactor, error) {
+	var twoFactor models.TwoFactor
+	if err := tr.db.Where("user_id = ?", userID).First(&twoFactor).Error; err != nil {
+		return twoFactor, err
+	}
+	return twoFactor, nil
+}
+
+// IsTwoFactorEnabled reports whether the user has a confirmed second factor.
+func (tr *TwoFactorRepository) IsTwoFactorEnabled(userID uint) (bool, error) {
+	var count int64
+	if err := tr.db.Model(&models.TwoFactor{}).Where("user_id = ? AND confirmed_at IS NOT NULL", userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SaveTwoFactorSecret starts enrolling a user with a new secret, replacing an unconfirmed one.
+// A confirmed second factor is not replaced, ErrTwoFactorEnabled is returned instead.
+func (tr *TwoFactorRepository) SaveTwoFactorSecret(userID uint, secret string) (models.TwoFactor, error) {
+	result := tr.db.Model(&models.TwoFactor{}).
+		Where("user_id = ? AND confirmed_at IS NULL", userID).
+		Update("secret", secret)
+	if result.Error != nil {
+		return models.TwoFactor{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		enabled, err := tr.IsTwoFactorEnabled(userID)
+		if err != nil {
+			return models.TwoFactor{}, err
+		}
+		if enabled {
+			return models.TwoFactor{}, ErrTwoFactorEnabled
+		}
+		if err := tr.db.Create(&models.TwoFactor{UserID: userID, Secret: secret}).Error; err != nil {
+			return models.TwoFactor{}, err
+		}
+	}
+	return tr.GetTwoFactorByUserID(userID)
+}
+
+// ConfirmTwoFactor enables the second factor of a user with new recovery codes, all or nothing.
+// Returns gorm.ErrRecordNotFound if the user is not enrolling.
+func (tr *TwoFactorRepository) ConfirmTwoFactor(userID uint, recoveryCodeHashes []string, now time.Time) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TwoFactor{}).
+			Where("user_id = ? AND confirmed_at IS NULL", userID).
+			Update("confirmed_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+// UseTwoFactorCode records a valid code as used, so it cannot be replayed.
+// Returns ErrTwoFactorCodeUsed if the same code was already used after reusableBefore, when it was not yet valid.
+func (tr *TwoFactorRepository) UseTwoFactorCode(userID uint, code string, now, reusableBefore time.Time) error {
+	result := tr.db.Model(&models.TwoFactor{}).
+		Where("user_id = ? AND NOT (last_used_code = ? AND last_used_at >= ?)", userID, code, reusableBefore).
+		Updates(map[string]interface{}{"last_used_code": code, "last_used_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTwoFactorCodeUsed
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes replaces all recovery codes of a user, used or not.
+func (tr *TwoFactorRepository) ReplaceRecoveryCodes(userID uint, recoveryCodeHashes []string) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, recoveryCodeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	codes := make([]models.RecoveryCode, len(recoveryCodeHashes))
+	for i, hash := range recoveryCodeHashes {
+		codes[i] = models.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
+
+// UseRecoveryCode marks an unused recovery code of the user as used. Returns gorm.ErrRecordNotFound if there is none.
+func (tr *TwoFactorRepository) UseRecoveryCode(userID uint, codeHash string, now time.Time) error {
+	result := tr.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes the user has left.
+func (tr *TwoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	if err := tr.db.Model(&models.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteTwoFactor removes the second factor of a user and their recovery codes.
+func (tr *TwoFactorRepository) DeleteTwoFactor(userID uint) error {
+	return tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.TwoFactor{}).Error
+	})
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"gorm.io/gorm"
+)
+
+func TestTwoFactorRepository_Enrollment(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TwoFactor{}, &models.RecoveryCode{})
+
+	repository := NewTwoFactorRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if err := repository.ConfirmTwoFactor(1, []string{"a"}, now); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected gorm.ErrRecordNotFound confirming without enrolling, got: %v", err)
+	}
+
+	// enrolling again replaces the unconfirmed secret
+	if _, err := repository.SaveTwoFactorSecret(1, "FIRST"); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	twoFactor, err := repository.SaveTwoFactorSecret(1, "SECOND")
+	if err != nil || twoFactor.Secret != "SECOND" || twoFactor.Enabled() {
+		t.Fatalf("Expected the unconfirmed secret to be replaced, got %+v: %v", twoFactor, err)
+	}
+	if enabled, _ := repository.IsTwoFactorEnabled(1); enabled {
+		t.Errorf("Expected two-factor authentication to be disabled until confirmed")
+	}
+
+	if err := repository.ConfirmTwoFactor(1, []string{"a", "b"}, now); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if enabled, _ := repository.IsTwoFactorEnabled(1); !enabled {
+		t.Errorf("Expected two-factor authentication to be enabled")
+	}
+	if count, _ := repository.CountUnusedRecoveryCodes(1); count != 2 {
+		t.Errorf("Expected 2 recovery codes, got %d", count)
+	}
+
+	// a confirmed secret cannot be replaced
+	if _, err := repository.SaveTwoFactorSecret(1, "THIRD"); !errors.Is(err, ErrTwoFactorEnabled) {
+		t.Errorf("Expected ErrTwoFactorEnabled, got: %v", err)
+	}
+
+	if err := repository.DeleteTwoFactor(1); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if enabled, _ := repository.IsTwoFactorEnabled(1); enabled {
+		t.Errorf("Expected two-factor authentication to be disabled")
+	}
+	if count, _ := repository.CountUnusedRecoveryCodes(1); count != 0 {
+		t.Errorf("Expected the recovery codes to be deleted, got %d", count)
+	}
+}
+
+func TestTwoFactorRepository_SingleUse(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TwoFactor{}, &models.RecoveryCode{})
+
+	repository := NewTwoFactorRepository()
+	repository.db = db
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	repository.SaveTwoFactorSecret(1, "SECRET")
+	repository.ConfirmTwoFactor(1, []string{"a", "b"}, now)
+
+	if err := repository.UseTwoFactorCode(1, "123456", now, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	later := now.Add(30 * time.Second)
+	if err := repository.UseTwoFactorCode(1, "123456", later, later.Add(-time.Minute)); !errors.Is(err, ErrTwoFactorCodeUsed) {
+		t.Errorf("Expected ErrTwoFactorCodeUsed replaying a code, got: %v", err)
+	}
+	if err := repository.UseTwoFactorCode(1, "654321", later, later.Add(-time.Minute)); err != nil {
+		t.Errorf("Expected another code to be accepted, got: %v", err)
+	}
+
+	if err := repository.UseRecoveryCode(1, "a", now); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if err := repository.UseRecoveryCode(1, "a", now); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected gorm.ErrRecordNotFound using a recovery code twice, got: %v", err)
+	}
+	if err := repository.UseRecoveryCode(2, "b", now); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected gorm.ErrRecordNotFound using the recovery code of another user, got: %v", err)
+	}
+	if count, _ := repository.CountUnusedRecoveryCodes(1); count != 1 {
+		t.Errorf("Expected 1 recovery code left, got %d", count)
+	}
+
+	if err := repository.ReplaceRecoveryCodes(1, []string{"c", "d", "e"}); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if count, _ := repository.CountUnusedRecoveryCodes(1); count != 3 {
+		t.Errorf("Expected 3 new recovery codes, got %d", count)
+	}
+	if err := repository.UseRecoveryCode(1, "b", now); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected replaced recovery codes to be refused, got: %v", err)
+	}
+}
@@ -2,12 +2,16 @@ package routers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/controllers"
+	"github.com/GDGVIT/attendance-app-backend/infra/scheduler"
+	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/repository"
 	"github.com/GDGVIT/attendance-app-backend/routers/middleware"
 	"github.com/GDGVIT/attendance-app-backend/services"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 )
 
 // RegisterRoutes add all routing list here automatically get main router
@@ -23,14 +27,40 @@ func RegisterRoutes(route *gin.Engine) {
 	teamRepo := repository.NewTeamRepository()
 	userRepo := repository.NewUserRepository()
 	teamMemberRepo := repository.NewTeamMemberRepository()
+	leaveRequestRepo := repository.NewLeaveRequestRepository()
 	emailService := services.NewEmailService(teamRepo, teamMemberRepo, userRepo)
-	meetingService := services.NewMeetingService(meetingRepo, emailService, userRepo, teamRepo, teamMemberRepo)
+	auditLogService := services.NewAuditLogService(repository.NewAuditLogRepository())
+	meetingService := services.NewMeetingService(meetingRepo, emailService, userRepo, teamRepo, teamMemberRepo, leaveRequestRepo, auditLogService)
 	meetingController := controllers.NewMeetingController(meetingService)
+	meetingSeriesRepo := repository.NewMeetingSeriesRepository()
+	meetingSeriesService := services.NewMeetingSeriesService(meetingSeriesRepo, meetingRepo, meetingService, emailService, auditLogService)
+	meetingSeriesController := controllers.NewMeetingSeriesController(meetingSeriesService)
+	attendanceExportService := services.NewAttendanceExportService(meetingRepo, teamRepo, teamMemberRepo, leaveRequestRepo)
+	attendanceExportController := controllers.NewAttendanceExportController(attendanceExportService)
+	attendanceImportService := services.NewAttendanceImportService(meetingRepo, teamMemberRepo, userRepo, auditLogService)
+	attendanceImportController := controllers.NewAttendanceImportController(attendanceImportService)
+	leaveRequestService := services.NewLeaveRequestService(leaveRequestRepo, meetingRepo, userRepo, emailService, auditLogService)
+	leaveRequestController := controllers.NewLeaveRequestController(leaveRequestService)
+	auditLogController := controllers.NewAuditLogController(auditLogService)
+
+	// background jobs, safe to run on every replica
+	viper.SetDefault("SCHEDULER_INTERVAL_SECONDS", 30)
+	jobs := scheduler.New()
+	jobs.Every(time.Duration(viper.GetInt("SCHEDULER_INTERVAL_SECONDS"))*time.Second, "meeting transitions", meetingService.RunScheduledTransitions)
+	jobs.Every(time.Hour, "meeting series", meetingSeriesService.MaterializeMeetingSeries)
+	jobs.Every(time.Hour, "ended sessions", repository.NewSessionRepository().DeleteEndedSessions)
+	jobs.Every(time.Hour, "stale auth throttles", repository.NewAuthThrottleRepository().DeleteStaleAuthThrottles)
+	jobs.Every(time.Hour, "ended one-time codes", repository.NewOneTimeCodeRepository().DeleteEndedOneTimeCodes)
+	jobs.Every(time.Hour, "ended oauth states", repository.NewOAuthStateRepository().DeleteEndedOAuthStates)
+	jobs.Start()
 
 	userController := controllers.NewUserController()
 
 	teamController := controllers.NewTeamController()
 
+	// Public keys to verify access tokens with
+	route.GET("/.well-known/jwks.json", userController.GetJWKS)
+
 	auth := v1.Group("/auth") // Create an /auth/ group
 	{
 		// Define the user registration route
@@ -39,6 +69,24 @@ func RegisterRoutes(route *gin.Engine) {
 		// Define the user login route
 		auth.POST("/login", userController.Login)
 
+		// Complete logging in with a two-factor or recovery code
+		auth.POST("/2fa/verify", userController.VerifyTwoFactor)
+
+		// Request a link to log in without a password
+		auth.POST("/magic-link", userController.RequestMagicLink)
+
+		// Log in with the code of a magic link
+		auth.POST("/magic-link/verify", userController.MagicLinkLogin)
+
+		// Exchange a refresh token for new access and refresh tokens
+		auth.POST("/refresh", userController.RefreshSession)
+
+		// Log out the current session
+		auth.POST("/logout", middleware.BaseAuthMiddleware(), userController.Logout)
+
+		// Log out every session of the user
+		auth.POST("/logout-all", middleware.BaseAuthMiddleware(), userController.LogoutAll)
+
 		// Verify user account by providing otp
 		auth.POST("/verify", userController.VerifyEmail)
 
@@ -68,6 +116,12 @@ func RegisterRoutes(route *gin.Engine) {
 
 		// Google Callback
 		auth.GET("/google/verify", userController.GoogleCallback)
+
+		// Start a Google login in the app with the challenge of its PKCE verifier
+		auth.POST("/google/mobile/start", userController.StartGoogleMobileLogin)
+
+		// Finish a Google login in the app with the code of its redirect and its PKCE verifier
+		auth.POST("/google/mobile/exchange", userController.GoogleMobileExchange)
 	}
 
 	user := v1.Group("/user")
@@ -87,6 +141,26 @@ func RegisterRoutes(route *gin.Engine) {
 		// Get my team requests, query ?status=accepted/rejected/pending
 		user.GET("/me/requests", middleware.BaseAuthMiddleware(), userController.GetMyRequests)
 
+		// Get my pending invitations to teams
+		user.GET("/me/invites", middleware.BaseAuthMiddleware(), userController.GetMyInvites)
+
+		// Accept or decline an invitation to a team
+		user.POST("/me/invites/:inviteID/accept", middleware.BaseAuthMiddleware(), userController.AcceptInvite)
+		user.POST("/me/invites/:inviteID/decline", middleware.BaseAuthMiddleware(), userController.DeclineInvite)
+
+		// Get the active sessions of the user, the devices they are logged in on
+		user.GET("/me/sessions", middleware.BaseAuthMiddleware(), userController.GetMySessions)
+
+		// Log out one of the user's sessions
+		user.DELETE("/me/sessions/:sessionID", middleware.BaseAuthMiddleware(), userController.RevokeMySession)
+
+		// Two-factor authentication of the current user
+		user.GET("/me/2fa", middleware.BaseAuthMiddleware(), userController.GetTwoFactorStatus)
+		user.POST("/me/2fa", middleware.BaseAuthMiddleware(), userController.EnrollTwoFactor)
+		user.POST("/me/2fa/confirm", middleware.BaseAuthMiddleware(), userController.ConfirmTwoFactor)
+		user.POST("/me/2fa/recovery-codes", middleware.BaseAuthMiddleware(), userController.RegenerateRecoveryCodes)
+		user.DELETE("/me/2fa", middleware.BaseAuthMiddleware(), userController.DisableTwoFactor)
+
 		// Get past user attendance, TODO: filterable by team
 		user.GET("/me/attendance", middleware.BaseAuthMiddleware(), meetingController.GetUserAttendanceRecords)
 	}
@@ -103,75 +177,171 @@ func RegisterRoutes(route *gin.Engine) {
 		team.GET("/invite/:inviteCode", middleware.BaseAuthMiddleware(), teamController.GetTeamByInviteCode)
 
 		// Update a team
-		team.PATCH("/:teamID", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), teamController.UpdateTeam)
+		team.PATCH("/:teamID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamUpdate), middleware.TeamNotArchived(), teamController.UpdateTeam)
 
 		// Regenerate invite code
-		team.GET("/:teamID/regenerate-invite", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), teamController.RegenerateInviteCode)
+		team.GET("/:teamID/regenerate-invite", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamUpdate), middleware.TeamNotArchived(), teamController.RegenerateInviteCode)
 
 		// Join a team
 		team.POST("/invite/:inviteCode/join", middleware.BaseAuthMiddleware(), teamController.JoinTeamByInviteCode)
 
 		// Get team requests, query ?status=accepted/rejected/pending
-		team.GET("/:teamID/requests", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), teamController.GetTeamRequests)
+		team.GET("/:teamID/requests", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRequestsReview), teamController.GetTeamRequests)
 
 		// Accept or reject a request Patch /team/:teamID/requests/:requestID by admin/superadmin
-		team.PATCH("/:teamID/requests/:requestID", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), teamController.UpdateTeamRequestStatus)
+		team.PATCH("/:teamID/requests/:requestID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRequestsReview), middleware.TeamNotArchived(), teamController.UpdateTeamRequestStatus)
+
+		// Accept or reject many requests, a list of IDs or all pending ones, by admin/superadmin
+		team.PATCH("/:teamID/requests", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRequestsReview), middleware.TeamNotArchived(), teamController.ReviewTeamRequests)
+
+		// invite links of a team, by admin/superadmin, only the super admin can create links for admins
+		team.GET("/:teamID/invites", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), teamController.ListInviteLinks)
+		team.POST("/:teamID/invites", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), middleware.TeamNotArchived(), teamController.CreateInviteLink)
+		team.DELETE("/:teamID/invites/:inviteID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), middleware.TeamNotArchived(), teamController.RevokeInviteLink)
+
+		// who joined or requested to join with an invite link, by admin/superadmin
+		team.GET("/:teamID/invites/:inviteID/uses", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), teamController.GetInviteLinkUses)
+
+		// invite a list of emails, by admin/superadmin, only the super admin can invite admins
+		team.POST("/:teamID/email-invites", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), middleware.TeamNotArchived(), teamController.InviteByEmail)
+
+		// email invitations of a team, query ?status=pending/accepted/declined/revoked
+		team.GET("/:teamID/email-invites", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), teamController.ListEmailInvites)
+
+		// revoke a pending email invitation
+		team.DELETE("/:teamID/email-invites/:inviteID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionInvitesManage), middleware.TeamNotArchived(), teamController.RevokeEmailInvite)
+
+		// join rules that auto-approve requests to a protected team, listed by admin/superadmin
+		team.GET("/:teamID/join-rules", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRequestsReview), teamController.ListJoinRules)
+
+		// add a rule for a verified email domain, or for an uploaded roster of emails, by super admin
+		team.POST("/:teamID/join-rules/email-domain", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionJoinRulesManage), middleware.TeamNotArchived(), teamController.CreateEmailDomainJoinRule)
+		team.POST("/:teamID/join-rules/roster", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionJoinRulesManage), middleware.TeamNotArchived(), teamController.CreateRosterJoinRule)
+
+		// remove a join rule, by super admin
+		team.DELETE("/:teamID/join-rules/:ruleID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionJoinRulesManage), middleware.TeamNotArchived(), teamController.DeleteJoinRule)
 
 		// same as /team/invite/:inviteCode, for uniformity with next routes
-		team.GET("/:teamID", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), teamController.GetTeamByID)
+		team.GET("/:teamID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamView), teamController.GetTeamByID)
 
 		// get current user's role in a team
-		team.GET("/:teamID/myrole", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), teamController.GetCurrentUserRoleInTeam)
+		team.GET("/:teamID/myrole", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamView), teamController.GetCurrentUserRoleInTeam)
+
+		// get the permissions of the built in roles and the roles defined by the team
+		team.GET("/:teamID/roles", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamView), teamController.GetTeamRoles)
+
+		// define, change or remove a role of the team, by super admin
+		team.POST("/:teamID/roles", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRolesManage), middleware.TeamNotArchived(), teamController.CreateTeamRole)
+		team.PATCH("/:teamID/roles/:roleID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRolesManage), middleware.TeamNotArchived(), teamController.UpdateTeamRole)
+		team.DELETE("/:teamID/roles/:roleID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionRolesManage), middleware.TeamNotArchived(), teamController.DeleteTeamRole)
 
 		// get team members, query ?role=member/admin/super_admin
-		team.GET("/:teamID/members", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), teamController.GetTeamMembers)
+		team.GET("/:teamID/members", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamView), teamController.GetTeamMembers)
 
-		// promote/demote a member to admin/member, visible to superadmin only
-		team.PATCH("/:teamID/members/:memberID", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), teamController.PromoteOrDemoteTeamMember)
+		// promote/demote a member to admin/member, or give them a role of the team with ?role=, by roles with members.manage
+		team.PATCH("/:teamID/members/:memberID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMembersManage), middleware.TeamNotArchived(), teamController.PromoteOrDemoteTeamMember)
 
 		// leave a team
-		team.DELETE("/:teamID/leave", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), teamController.LeaveTeam)
+		team.DELETE("/:teamID/leave", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamView), teamController.LeaveTeam)
 
 		// kick a member
-		team.DELETE("/:teamID/members/:memberID", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), teamController.KickTeamMember)
+		team.DELETE("/:teamID/members/:memberID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMembersKick), middleware.TeamNotArchived(), teamController.KickTeamMember)
+
+		// delete a team with its meetings and attendance, or archive it with ?archive=true to keep them read-only
+		team.DELETE("/:teamID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamDelete), teamController.DeleteTeam)
 
 		// handover superadmin to another member
-		team.PATCH("/:teamID/handover", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), teamController.HandoverTeamSuperAdmin)
+		team.PATCH("/:teamID/handover", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionTeamHandover), middleware.TeamNotArchived(), teamController.HandoverTeamSuperAdmin)
 
 		// /:teamID/meetings to create one, by super admin
-		team.POST("/:teamID/meetings", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), meetingController.CreateMeeting)
+		team.POST("/:teamID/meetings", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingCreate), middleware.TeamNotArchived(), meetingController.CreateMeeting)
 
 		// get all meetings of a team, query ?meetingOver=true/false
-		team.GET("/:teamID/meetings", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), meetingController.GetMeetingsByTeamID)
+		team.GET("/:teamID/meetings", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingView), meetingController.GetMeetingsByTeamID)
 
 		// get a meeting by id
-		team.GET("/:teamID/meetings/:meetingID", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), meetingController.GetMeetingDetails)
+		team.GET("/:teamID/meetings/:meetingID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingView), meetingController.GetMeetingDetails)
+
+		// update details of a meeting, by super admin
+		team.PATCH("/:teamID/meetings/:meetingID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingUpdate), middleware.TeamNotArchived(), meetingController.UpdateMeeting)
+
+		// /:teamID/meeting-series to create a recurring meeting, by super admin
+		team.POST("/:teamID/meeting-series", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingCreate), middleware.TeamNotArchived(), meetingSeriesController.CreateMeetingSeries)
+
+		// get all recurring meetings of a team
+		team.GET("/:teamID/meeting-series", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingView), meetingSeriesController.GetMeetingSeriesByTeamID)
+
+		// get a recurring meeting by id
+		team.GET("/:teamID/meeting-series/:seriesID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingView), meetingSeriesController.GetMeetingSeriesDetails)
+
+		// edit a meeting of a series, query ?scope=this/following
+		team.PATCH("/:teamID/meeting-series/:seriesID/meetings/:meetingID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingUpdate), middleware.TeamNotArchived(), meetingSeriesController.UpdateOccurrence)
+
+		// delete a recurring meeting and its meetings that have not started, by super admin
+		team.DELETE("/:teamID/meeting-series/:seriesID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingDelete), middleware.TeamNotArchived(), meetingSeriesController.DeleteMeetingSeries)
 
 		// start a meeting
-		team.PATCH("/:teamID/meetings/:meetingID/start", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), meetingController.StartMeeting)
+		team.PATCH("/:teamID/meetings/:meetingID/start", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingRun), middleware.TeamNotArchived(), meetingController.StartMeeting)
 
 		// end a meeting
-		team.PATCH("/:teamID/meetings/:meetingID/end", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), meetingController.EndMeeting)
+		team.PATCH("/:teamID/meetings/:meetingID/end", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingRun), middleware.TeamNotArchived(), meetingController.EndMeeting)
 
 		// start attendance
-		team.PATCH("/:teamID/meetings/:meetingID/attendance/start", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), meetingController.StartAttendance)
+		team.PATCH("/:teamID/meetings/:meetingID/attendance/start", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceStart), middleware.TeamNotArchived(), meetingController.StartAttendance)
 
 		// end attendance
-		team.PATCH("/:teamID/meetings/:meetingID/attendance/end", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), meetingController.EndAttendance)
+		team.PATCH("/:teamID/meetings/:meetingID/attendance/end", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceStart), middleware.TeamNotArchived(), meetingController.EndAttendance)
 
 		// delete a meeting
-		team.DELETE("/:teamID/meetings/:meetingID", middleware.BaseAuthMiddleware(), middleware.AuthorizeSuperAdmin(), meetingController.DeleteMeetingByID)
+		team.DELETE("/:teamID/meetings/:meetingID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionMeetingDelete), middleware.TeamNotArchived(), meetingController.DeleteMeetingByID)
+
+		// get the current rotating attendance code, for admins to display
+		team.GET("/:teamID/meetings/:meetingID/attendance/code", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceStart), meetingController.GetAttendanceCode)
 
 		// mark attendance for a user in a meeting
-		team.PATCH("/:teamID/meetings/:meetingID/attendance", middleware.BaseAuthMiddleware(), middleware.AuthorizeMember(), meetingController.MarkAttendance)
+		team.PATCH("/:teamID/meetings/:meetingID/attendance", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceMark), middleware.TeamNotArchived(), meetingController.MarkAttendance)
 
 		// admin get attendance for a meeting
-		team.GET("/:teamID/meetings/:meetingID/attendance", middleware.BaseAuthMiddleware(), middleware.AuthorizeAdmin(), meetingController.GetAttendanceForMeeting)
+		team.GET("/:teamID/meetings/:meetingID/attendance", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionReportsView), meetingController.GetAttendanceForMeeting)
+
+		// admin get present, late, absent and excused members of a meeting
+		team.GET("/:teamID/meetings/:meetingID/summary", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionReportsView), meetingController.GetMeetingSummary)
+
+		// Download the attendance register of a meeting as csv or xlsx
+		team.GET("/:teamID/meetings/:meetingID/attendance/export", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionReportsView), attendanceExportController.ExportMeetingAttendance)
+
+		// Download the attendance register of the team's meetings in a date range as csv or xlsx
+		team.GET("/:teamID/attendance/export", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionReportsView), attendanceExportController.ExportTeamAttendance)
+
+		// Import historical attendance from a csv, creating past meetings as needed. ?dryRun=true only checks the rows
+		team.POST("/:teamID/attendance/import", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceImport), middleware.TeamNotArchived(), attendanceImportController.ImportAttendance)
+
+		// ask to be excused from a meeting, before or after it
+		team.POST("/:teamID/meetings/:meetingID/leave", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionLeaveSubmit), middleware.TeamNotArchived(), leaveRequestController.SubmitLeaveRequest)
+
+		// withdraw a pending leave request for a meeting
+		team.DELETE("/:teamID/meetings/:meetingID/leave", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionLeaveSubmit), middleware.TeamNotArchived(), leaveRequestController.WithdrawLeaveRequest)
+
+		// get current user's leave requests in a team
+		team.GET("/:teamID/leave-requests/me", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionLeaveSubmit), leaveRequestController.GetMyLeaveRequests)
+
+		// admin get leave requests, query ?status=pending/approved/rejected&meetingID=
+		team.GET("/:teamID/leave-requests", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionLeaveReview), leaveRequestController.GetLeaveRequests)
+
+		// admin approve or reject a leave request, approved leaves show as excused
+		team.PATCH("/:teamID/leave-requests/:requestID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionLeaveReview), middleware.TeamNotArchived(), leaveRequestController.ReviewLeaveRequest)
+
+		// admin mark a member present or late, or flip on time of an existing record
+		team.PUT("/:teamID/meetings/:meetingID/attendance/:userID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceOverride), middleware.TeamNotArchived(), meetingController.OverrideAttendance)
+
+		// admin remove a member's attendance record
+		team.DELETE("/:teamID/meetings/:meetingID/attendance/:userID", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAttendanceOverride), middleware.TeamNotArchived(), meetingController.RemoveAttendance)
+
+		// super admin page through the audit log, query ?actorID=&action=&from=&to=&page=&pageSize=
+		team.GET("/:teamID/audit-log", middleware.BaseAuthMiddleware(), middleware.Authorize(models.PermissionAuditView), auditLogController.GetTeamAuditLog)
 	}
 }
 
-// TODO delete team
 // TODO controller-service-repo pattern
 // TODO unit of work pattern
-// For google oauth, make slight change. Instead of redirecting to the callback on backend directly, redirect to frontend url (or app uri), and have a route which accepts the auth code that frontend sends and does wht my callback is doing rn.
 // TODO maybe some event broker like kafka for notifs
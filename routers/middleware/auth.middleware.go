@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
@@ -11,11 +12,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// BaseAuthMiddleware checks if the user is authenticated
+// BaseAuthMiddleware checks if the user is authenticated with an access token of a session that is still active.
+// The user is set as "user" and the session ID as "sessionID" for the handlers.
 func BaseAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		userID, err := token.ValidateToken(c)
+		userID, sessionID, err := token.ValidateToken(c)
 
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "auth", "message": "Please login to continue."})
@@ -24,6 +26,15 @@ func BaseAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// the session may have been logged out since the token was issued
+		sessionRepo := repository.NewSessionRepository()
+		session, err := sessionRepo.GetSessionByID(sessionID)
+		if err != nil || session.UserID != userID || !session.Active(time.Now()) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "auth", "message": "Please login to continue."})
+			c.Abort()
+			return
+		}
+
 		var user models.User
 
 		userRepo := repository.NewUserRepository()
@@ -36,62 +47,68 @@ func BaseAuthMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set("user", &user)
+		c.Set("sessionID", sessionID)
 
 		c.Next()
 	}
 }
 
-func AuthorizeSuperAdmin() gin.HandlerFunc {
+// Authorize checks that the user is a member of the team whose role has the permission, built in or defined by the team.
+// Admin permissions need two-factor authentication in teams that require it of their admins.
+// The team member is set as "teamMember" for the handlers.
+func Authorize(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Check if the user is a super_admin
-		user, _ := c.Get("user") // Assuming you have user information in the context
+		user, _ := c.Get("user")
 		teamID, _ := strconv.Atoi(c.Param("teamID"))
 		// Check the user's role and permissions for the team
 		teamMemberRepo := repository.NewTeamMemberRepository()
 		teamMember, err := teamMemberRepo.GetTeamMemberByID(uint(teamID), user.(*models.User).ID)
-		if err == nil && teamMember.Role == models.SuperAdminRole {
-			// User is authorized as superadmin for given team, proceed to the next handler
-			c.Next()
-		} else {
-			// User is not authorized, return an error response
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"message": "You are not authorized to do that action on this team."})
 			c.Abort()
+			return
 		}
-	}
-}
 
-// AuthorizeAdmin checks if the user is a super_admin or admin for the given team
-func AuthorizeAdmin() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		user, _ := c.Get("user") // Assuming you have user information in the context
-		teamID, _ := strconv.Atoi(c.Param("teamID"))
-		// Check the user's role and permissions for the team
-		teamMemberRepo := repository.NewTeamMemberRepository()
-		teamMember, err := teamMemberRepo.GetTeamMemberByID(uint(teamID), user.(*models.User).ID)
-		if err == nil && (teamMember.Role == models.SuperAdminRole || teamMember.Role == models.AdminRole) {
-			c.Next()
-		} else {
-			// User is not authorized, return an error response
-			c.JSON(http.StatusUnauthorized, gin.H{"message": "You are not authorized to do that action on this team."})
+		teamRoleRepo := repository.NewTeamRoleRepository()
+		permissions, err := teamRoleRepo.GetPermissions(teamMember.TeamID, teamMember.Role)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "role-fetch-error", "message": "Internal error while fetching role."})
+			logger.Errorf("Fetching Team Role Error: %v", err)
 			c.Abort()
+			return
 		}
-	}
-}
-
-// AuthorizeMember checks if the user is a super_admin, admin or member for the given team
-func AuthorizeMember() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		user, _ := c.Get("user") // Assuming you have user information in the context
-		teamID, _ := strconv.Atoi(c.Param("teamID"))
-		// Check the user's role and permissions for the team
-		teamMemberRepo := repository.NewTeamMemberRepository()
-		teamMember, err := teamMemberRepo.GetTeamMemberByID(uint(teamID), user.(*models.User).ID)
-		if err == nil && (teamMember.Role == models.SuperAdminRole || teamMember.Role == models.AdminRole || teamMember.Role == models.MemberRole) {
-			c.Next()
-		} else {
-			// User is not authorized, return an error response
-			c.JSON(http.StatusUnauthorized, gin.H{"message": "You are not authorized to do that action on this team."})
+		if !models.HasPermission(permissions, permission) {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "You are not authorized to do that action on this team.", "permission": permission})
 			c.Abort()
+			return
 		}
+
+		// teams can require their admins to use two-factor authentication
+		if models.AdminPermission(permission) {
+			team, err := repository.NewTeamRepository().GetTeamByID(teamMember.TeamID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "team-fetch-error", "message": "Internal error while fetching team."})
+				logger.Errorf("Fetching Team Error: %v", err)
+				c.Abort()
+				return
+			}
+			if team.RequireAdminTwoFactor {
+				enabled, err := repository.NewTwoFactorRepository().IsTwoFactorEnabled(teamMember.UserID)
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "two-factor-fetch-error", "message": "Internal error while fetching two-factor authentication."})
+					logger.Errorf("Fetching Two-Factor Error: %v", err)
+					c.Abort()
+					return
+				}
+				if !enabled {
+					c.JSON(http.StatusForbidden, gin.H{"error": "two-factor-required", "message": "This team requires admins to enable two-factor authentication."})
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		c.Set("teamMember", &teamMember)
+		c.Next()
 	}
 }
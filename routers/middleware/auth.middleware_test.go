@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthorize(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.Team{}, &models.TeamMember{}, &models.TeamRole{}, &models.TwoFactor{})
+	database.DB = db
+
+	db.Create(&models.Team{Name: "team", SuperAdminID: 1})
+	db.Create(&models.Team{Name: "secure", SuperAdminID: 1, RequireAdminTwoFactor: true})
+	db.Create(&models.TeamRole{TeamID: 1, Name: "organiser", Permissions: []string{models.PermissionMeetingCreate}})
+	for userID, role := range map[uint]string{1: models.SuperAdminRole, 2: models.AdminRole, 3: models.MemberRole, 4: "organiser", 5: "removed_role"} {
+		db.Create(&models.TeamMember{TeamID: 1, UserID: userID, Role: role})
+	}
+	db.Create(&models.TeamMember{TeamID: 2, UserID: 2, Role: models.AdminRole})
+
+	type authorizeTest struct {
+		name       string
+		teamID     uint
+		userID     uint
+		permission string
+		want       int
+	}
+	tests := []authorizeTest{
+		{"super admin", 1, 1, models.PermissionTeamDelete, http.StatusOK},
+		{"admin", 1, 2, models.PermissionRequestsReview, http.StatusOK},
+		{"admin without permission", 1, 2, models.PermissionMeetingCreate, http.StatusUnauthorized},
+		{"member", 1, 3, models.PermissionMeetingView, http.StatusOK},
+		{"member without permission", 1, 3, models.PermissionAttendanceStart, http.StatusUnauthorized},
+		{"custom role", 1, 4, models.PermissionMeetingCreate, http.StatusOK},
+		{"custom role has member permissions", 1, 4, models.PermissionLeaveSubmit, http.StatusOK},
+		{"custom role without permission", 1, 4, models.PermissionMeetingDelete, http.StatusUnauthorized},
+		{"deleted custom role", 1, 5, models.PermissionTeamView, http.StatusUnauthorized},
+		{"not a member", 1, 6, models.PermissionTeamView, http.StatusUnauthorized},
+		{"another team", 2, 3, models.PermissionTeamView, http.StatusUnauthorized},
+		{"admin without two-factor", 2, 2, models.PermissionRequestsReview, http.StatusForbidden},
+		{"member permission without two-factor", 2, 2, models.PermissionTeamView, http.StatusOK},
+	}
+	// reserved permissions are only had by the super admin
+	for _, permission := range []string{models.PermissionTeamDelete, models.PermissionTeamHandover, models.PermissionRolesManage, models.PermissionAuditView} {
+		tests = append(tests,
+			authorizeTest{"reserved " + permission + " for admin", 1, 2, permission, http.StatusUnauthorized},
+			authorizeTest{"reserved " + permission + " for custom role", 1, 4, permission, http.StatusUnauthorized},
+		)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/team/:teamID", func(c *gin.Context) {
+				user := &models.User{}
+				user.ID = tt.userID
+				c.Set("user", user)
+			}, Authorize(tt.permission), func(c *gin.Context) {
+				teamMember := c.MustGet("teamMember").(*models.TeamMember)
+				if teamMember.UserID != tt.userID {
+					t.Errorf("Expected the team member of user %d, got %+v", tt.userID, teamMember)
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/team/"+strconv.Itoa(int(tt.teamID)), nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
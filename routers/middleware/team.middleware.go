@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// TeamNotArchived stops changes to archived teams, which are read-only. Use after the authorization middleware.
+func TeamNotArchived() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamID, _ := strconv.Atoi(c.Param("teamID"))
+		teamRepo := repository.NewTeamRepository()
+		team, err := teamRepo.GetTeamByID(uint(teamID))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			c.Abort()
+			return
+		}
+		if team.Archived() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "team-archived", "message": "This team has been archived and is read-only."})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
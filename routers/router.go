@@ -18,6 +18,9 @@ func SetupRoute() *gin.Engine {
 	allowedHosts := viper.GetString("ALLOWED_HOSTS")
 	router := gin.New()
 	router.SetTrustedProxies([]string{allowedHosts})
+	// behind a platform's proxy the client IP, which logins are throttled by, is read from the header the platform sets,
+	// Fly-Client-IP on Fly.io
+	router.TrustedPlatform = viper.GetString("TRUSTED_PLATFORM_HEADER")
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(middleware.CORSMiddleware())
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/export"
+)
+
+// Cells of the attendance register.
+const (
+	registerPresent   = "present"
+	registerLate      = "late"
+	registerAbsent    = "absent"
+	registerExcused   = "excused"
+	registerNotInTeam = "" // not a member of the team at the time of the meeting
+)
+
+// AttendanceExportService builds attendance registers and writes them as spreadsheets.
+type AttendanceExportService struct {
+	meetingRepo      repository.MeetingRepositoryInterface
+	teamRepo         repository.TeamRepositoryInterface
+	teamMemberRepo   repository.TeamMemberRepositoryInterface
+	leaveRequestRepo repository.LeaveRequestRepositoryInterface
+}
+
+// NewAttendanceExportService creates a new AttendanceExportService.
+func NewAttendanceExportService(
+	meetingRepo repository.MeetingRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+	teamMemberRepo repository.TeamMemberRepositoryInterface,
+	leaveRequestRepo repository.LeaveRequestRepositoryInterface,
+) *AttendanceExportService {
+	return &AttendanceExportService{meetingRepo, teamRepo, teamMemberRepo, leaveRequestRepo}
+}
+
+type AttendanceExportServiceInterface interface {
+	GetMeetingRegister(meetingID, teamID uint) (models.AttendanceRegister, error)
+	GetTeamRegister(teamID uint, from, to time.Time) (models.AttendanceRegister, error)
+	WriteRegister(register models.AttendanceRegister, w export.Writer) error
+}
+
+// GetMeetingRegister returns the register of a single meeting that has started, with the members of the team as of its StartTime.
+func (aes *AttendanceExportService) GetMeetingRegister(meetingID, teamID uint) (models.AttendanceRegister, error) {
+	meeting, err := aes.meetingRepo.GetMeetingByID(meetingID)
+	if err != nil {
+		return models.AttendanceRegister{}, err
+	}
+	if meeting.TeamID != teamID {
+		return models.AttendanceRegister{}, errors.New("meeting not found")
+	}
+	if !meeting.MeetingPeriod && !meeting.MeetingOver {
+		return models.AttendanceRegister{}, errors.New("attendance cannot be exported before meeting has started")
+	}
+	return aes.getRegister(teamID, []models.Meeting{meeting})
+}
+
+// GetTeamRegister returns the register of the meetings of a team that started between from and to.
+func (aes *AttendanceExportService) GetTeamRegister(teamID uint, from, to time.Time) (models.AttendanceRegister, error) {
+	if to.Before(from) {
+		return models.AttendanceRegister{}, errors.New("export range cannot end before it starts")
+	}
+	meetings, err := aes.meetingRepo.GetStartedMeetingsByTeamIDBetween(teamID, from, to)
+	if err != nil {
+		return models.AttendanceRegister{}, err
+	}
+	if len(meetings) == 0 {
+		return models.AttendanceRegister{}, errors.New("no meetings found in the export range")
+	}
+	return aes.getRegister(teamID, meetings)
+}
+
+func (aes *AttendanceExportService) getRegister(teamID uint, meetings []models.Meeting) (models.AttendanceRegister, error) {
+	team, err := aes.teamRepo.GetTeamByID(teamID)
+	if err != nil {
+		return models.AttendanceRegister{}, err
+	}
+	return models.AttendanceRegister{TeamID: teamID, TeamName: team.Name, Meetings: meetings}, nil
+}
+
+// WriteRegister writes the register as a member x meeting matrix, with the totals of each member in the last columns and of each meeting in the last rows.
+// The attendance and approved leaves of the meetings are loaded at once, while members are read and written one at a time, so the team is never held in memory.
+// Attendance percentages leave out meetings a member was excused from.
+func (aes *AttendanceExportService) WriteRegister(register models.AttendanceRegister, w export.Writer) error {
+	if len(register.Meetings) == 0 {
+		return errors.New("register has no meetings")
+	}
+
+	meetingIDs := make([]uint, len(register.Meetings))
+	header := []interface{}{"Name", "Email"}
+	for i, meeting := range register.Meetings {
+		meetingIDs[i] = meeting.ID
+		header = append(header, fmt.Sprintf("%s (%s)", meeting.Title, meeting.StartTime.Format("2006-01-02 15:04")))
+	}
+	header = append(header, "Present", "Late", "Absent", "Excused", "Attendance %")
+	if err := w.WriteRow(header...); err != nil {
+		return err
+	}
+
+	// attendance records and approved leaves, by user and meeting
+	attendance, err := aes.meetingRepo.GetMeetingAttendancesByMeetingIDs(meetingIDs)
+	if err != nil {
+		return err
+	}
+	records := make(map[uint]map[uint]models.MeetingAttendance)
+	for _, record := range attendance {
+		if records[record.UserID] == nil {
+			records[record.UserID] = make(map[uint]models.MeetingAttendance)
+		}
+		records[record.UserID][record.MeetingID] = record
+	}
+	leaves, err := aes.leaveRequestRepo.GetApprovedLeaveRequestsByMeetingIDs(meetingIDs)
+	if err != nil {
+		return err
+	}
+	excused := make(map[uint]map[uint]bool)
+	for _, leave := range leaves {
+		if excused[leave.UserID] == nil {
+			excused[leave.UserID] = make(map[uint]bool)
+		}
+		excused[leave.UserID][leave.MeetingID] = true
+	}
+
+	// per meeting totals
+	attended := make([]int, len(register.Meetings))
+	expected := make([]int, len(register.Meetings))
+
+	var user models.TeamMemberUser
+	var memberships []models.TeamMember
+	writeMember := func() error {
+		row := []interface{}{user.Name, user.Email}
+		counts := make(map[string]int)
+		for i, meeting := range register.Meetings {
+			cell := registerCell(memberships, meeting, records[user.UserID], excused[user.UserID])
+			row = append(row, cell)
+			if cell == registerNotInTeam {
+				continue
+			}
+			counts[cell]++
+			if cell != registerExcused {
+				expected[i]++
+			}
+			if cell == registerPresent || cell == registerLate {
+				attended[i]++
+			}
+		}
+		row = append(row,
+			counts[registerPresent], counts[registerLate], counts[registerAbsent], counts[registerExcused],
+			percentage(counts[registerPresent]+counts[registerLate], counts[registerPresent]+counts[registerLate]+counts[registerAbsent]),
+		)
+		return w.WriteRow(row...)
+	}
+
+	// memberships of the same user are read one after another, a member is written once all of theirs are read
+	from, to := register.Meetings[0].StartTime, register.Meetings[len(register.Meetings)-1].StartTime
+	err = aes.teamMemberRepo.EachTeamMemberBetween(register.TeamID, from, to, meetingIDs, func(member models.TeamMemberUser) error {
+		if len(memberships) > 0 && member.UserID != user.UserID {
+			if err := writeMember(); err != nil {
+				return err
+			}
+			memberships = memberships[:0]
+		}
+		user = member
+		memberships = append(memberships, member.TeamMember)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(memberships) > 0 {
+		if err := writeMember(); err != nil {
+			return err
+		}
+	}
+
+	attendedRow := []interface{}{"Attended", ""}
+	percentageRow := []interface{}{"Attendance %", ""}
+	for i := range register.Meetings {
+		attendedRow = append(attendedRow, attended[i])
+		percentageRow = append(percentageRow, percentage(attended[i], expected[i]))
+	}
+	if err := w.WriteRow(attendedRow...); err != nil {
+		return err
+	}
+	if err := w.WriteRow(percentageRow...); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// registerCell returns how a member attended a meeting, given their memberships of the team, attendance records by meeting,
+// and the meetings they had leave approved for.
+func registerCell(memberships []models.TeamMember, meeting models.Meeting, records map[uint]models.MeetingAttendance, excused map[uint]bool) string {
+	record, marked := records[meeting.ID]
+	if !marked && !wasMemberAt(memberships, meeting.StartTime) {
+		return registerNotInTeam
+	}
+	if !marked || record.AttendanceStatus() == models.AttendanceStatusAbsent {
+		if excused[meeting.ID] {
+			return registerExcused
+		}
+		return registerAbsent
+	}
+	if record.AttendanceStatus() == models.AttendanceStatusLate {
+		return registerLate
+	}
+	return registerPresent
+}
+
+func wasMemberAt(memberships []models.TeamMember, at time.Time) bool {
+	for _, membership := range memberships {
+		if !membership.CreatedAt.After(at) && (!membership.DeletedAt.Valid || membership.DeletedAt.Time.After(at)) {
+			return true
+		}
+	}
+	return false
+}
+
+// percentage returns part of total as a percentage with one decimal, or an empty cell if there is no total, e.g. when excused from every meeting.
+func percentage(part, total int) interface{} {
+	if total == 0 {
+		return ""
+	}
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
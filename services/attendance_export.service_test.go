@@ -0,0 +1,173 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/export"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAttendanceExportService_GetTeamRegister(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	exportService := NewAttendanceExportService(mockMeetingRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl))
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Range_ends_before_start", func(t *testing.T) {
+		_, err := exportService.GetTeamRegister(1, to, from)
+		assert.Error(t, err)
+	})
+
+	t.Run("No_meetings_in_range", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetStartedMeetingsByTeamIDBetween(uint(1), from, to).Return([]models.Meeting{}, nil)
+
+		_, err := exportService.GetTeamRegister(1, from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("Meetings_in_range", func(t *testing.T) {
+		meetings := []models.Meeting{
+			{TeamID: 1, StartTime: time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC)},
+			{TeamID: 1, StartTime: time.Date(2024, time.January, 9, 18, 0, 0, 0, time.UTC)},
+		}
+		mockMeetingRepo.EXPECT().GetStartedMeetingsByTeamIDBetween(uint(1), from, to).Return(meetings, nil)
+		mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{Name: "Team"}, nil)
+
+		register, err := exportService.GetTeamRegister(1, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AttendanceRegister{TeamID: 1, TeamName: "Team", Meetings: meetings}, register)
+	})
+}
+
+func TestAttendanceExportService_GetMeetingRegister(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	exportService := NewAttendanceExportService(mockMeetingRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl))
+
+	t.Run("Meeting_not_started", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(1)).Return(models.Meeting{TeamID: 1}, nil)
+
+		_, err := exportService.GetMeetingRegister(1, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Meeting_of_another_team", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(1)).Return(models.Meeting{TeamID: 2, MeetingOver: true}, nil)
+
+		_, err := exportService.GetMeetingRegister(1, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Started_meeting", func(t *testing.T) {
+		meeting := models.Meeting{TeamID: 1, MeetingOver: true, StartTime: time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC)}
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+		mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{Name: "Team"}, nil)
+
+		register, err := exportService.GetMeetingRegister(1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Meeting{meeting}, register.Meetings)
+	})
+}
+
+func TestAttendanceExportService_WriteRegister(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
+	exportService := NewAttendanceExportService(mockMeetingRepo, mockTeamRepo, mockTeamMemberRepo, mockLeaveRequestRepo)
+
+	first := models.Meeting{Title: "Sync", StartTime: time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC)}
+	first.ID = 10
+	second := models.Meeting{Title: "Sync", StartTime: time.Date(2024, time.January, 9, 18, 0, 0, 0, time.UTC)}
+	second.ID = 11
+	joined := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+	register := models.AttendanceRegister{TeamID: 1, TeamName: "Team", Meetings: []models.Meeting{first, second}}
+	meetingIDs := []uint{10, 11}
+
+	// user 3 joined after the first meeting and was excused from the second, user 4 left before the second and rejoined,
+	// user 5 put a formula in their name, user 6 joined after both and had their absence from the first imported
+	leftAt := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+	members := []models.TeamMemberUser{
+		{TeamMember: models.TeamMember{Model: gorm.Model{CreatedAt: joined}, UserID: 2}, Name: "Alice", Email: "alice@example.com"},
+		{TeamMember: models.TeamMember{Model: gorm.Model{CreatedAt: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC)}, UserID: 3}, Name: "Bob", Email: "bob@example.com"},
+		{TeamMember: models.TeamMember{Model: gorm.Model{CreatedAt: joined, DeletedAt: gorm.DeletedAt{Time: leftAt, Valid: true}}, UserID: 4}, Name: "Carol", Email: "carol@example.com"},
+		{TeamMember: models.TeamMember{Model: gorm.Model{CreatedAt: time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC)}, UserID: 4}, Name: "Carol", Email: "carol@example.com"},
+		{TeamMember: models.TeamMember{Model: gorm.Model{CreatedAt: joined}, UserID: 5}, Name: "=1+1", Email: "dave@example.com"},
+		{TeamMember: models.TeamMember{Model: gorm.Model{CreatedAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}, UserID: 6}, Name: "Erin", Email: "erin@example.com"},
+	}
+
+	mockMeetingRepo.EXPECT().GetMeetingAttendancesByMeetingIDs(meetingIDs).Return([]models.MeetingAttendance{
+		{MeetingID: 10, UserID: 2, OnTime: true},
+		{MeetingID: 11, UserID: 2, OnTime: false, Status: models.AttendanceStatusLate},
+		{MeetingID: 11, UserID: 4, Status: models.AttendanceStatusAbsent, MinutesLate: 45},
+		{MeetingID: 10, UserID: 5, OnTime: true},
+		{MeetingID: 11, UserID: 5, OnTime: true},
+		{MeetingID: 10, UserID: 6, Status: models.AttendanceStatusAbsent},
+	}, nil)
+	// leave approved for a meeting attended anyway
+	mockLeaveRequestRepo.EXPECT().GetApprovedLeaveRequestsByMeetingIDs(meetingIDs).Return([]models.LeaveRequest{
+		{MeetingID: 10, UserID: 2},
+		{MeetingID: 11, UserID: 3},
+	}, nil)
+	mockTeamMemberRepo.EXPECT().EachTeamMemberBetween(uint(1), first.StartTime, second.StartTime, meetingIDs, gomock.Any()).DoAndReturn(
+		func(teamID uint, from, to time.Time, meetingIDs []uint, fn func(member models.TeamMemberUser) error) error {
+			for _, member := range members {
+				if err := fn(member); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	var buf bytes.Buffer
+	err := exportService.WriteRegister(register, export.NewCSVWriter(&buf))
+	assert.NoError(t, err)
+
+	expected := "Name,Email,Sync (2024-01-02 18:00),Sync (2024-01-09 18:00),Present,Late,Absent,Excused,Attendance %\n" +
+		"Alice,alice@example.com,present,late,1,1,0,0,100\n" +
+		"Bob,bob@example.com,,excused,0,0,0,1,\n" +
+		"Carol,carol@example.com,absent,absent,0,0,2,0,0\n" +
+		"'=1+1,dave@example.com,present,present,2,0,0,0,100\n" +
+		"Erin,erin@example.com,absent,,0,0,1,0,0\n" +
+		"Attended,,2,2\n" +
+		"Attendance %,,50,66.7\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestAttendanceExportService_WriteRegister_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
+	exportService := NewAttendanceExportService(mockMeetingRepo, mocks.NewMockTeamRepository(ctrl), mockTeamMemberRepo, mockLeaveRequestRepo)
+
+	mockMeetingRepo.EXPECT().GetMeetingAttendancesByMeetingIDs(gomock.Any()).Return([]models.MeetingAttendance{}, nil)
+	mockLeaveRequestRepo.EXPECT().GetApprovedLeaveRequestsByMeetingIDs(gomock.Any()).Return([]models.LeaveRequest{}, nil)
+	mockTeamMemberRepo.EXPECT().EachTeamMemberBetween(uint(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection lost"))
+
+	var buf bytes.Buffer
+	err := exportService.WriteRegister(models.AttendanceRegister{TeamID: 1, Meetings: []models.Meeting{{}}}, export.NewCSVWriter(&buf))
+	assert.Error(t, err)
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+)
+
+// maxImportRows is the most attendance rows a single import can have.
+const maxImportRows = 10000
+
+// importReason is recorded as the override reason of imported attendance.
+const importReason = "imported from csv"
+
+// ErrInvalidImportRows is returned when an import is not done because some of its rows are invalid.
+var ErrInvalidImportRows = errors.New("attendance file has invalid rows, nothing was imported")
+
+// Columns an attendance import file must have, in any order.
+var importColumns = []string{"email", "meeting", "date", "status"}
+
+// importStatuses maps the statuses accepted in import files to attendance statuses.
+var importStatuses = map[string]string{
+	"present": models.AttendanceStatusOnTime,
+	"on_time": models.AttendanceStatusOnTime,
+	"late":    models.AttendanceStatusLate,
+	"absent":  models.AttendanceStatusAbsent,
+}
+
+// AttendanceImportService imports historical attendance into a team.
+type AttendanceImportService struct {
+	meetingRepo     repository.MeetingRepositoryInterface
+	teamMemberRepo  repository.TeamMemberRepositoryInterface
+	userRepo        repository.UserRepositoryInterface
+	auditLogService AuditLogServiceInterface
+}
+
+// NewAttendanceImportService creates a new AttendanceImportService.
+func NewAttendanceImportService(
+	meetingRepo repository.MeetingRepositoryInterface,
+	teamMemberRepo repository.TeamMemberRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	auditLogService AuditLogServiceInterface,
+) *AttendanceImportService {
+	return &AttendanceImportService{meetingRepo, teamMemberRepo, userRepo, auditLogService}
+}
+
+type AttendanceImportServiceInterface interface {
+	ImportAttendance(actor models.AuditActor, teamID uint, file io.Reader, dryRun bool, now time.Time) (models.AttendanceImportReport, error)
+}
+
+// ImportAttendance imports a CSV with the columns email, meeting (title), date (YYYY-MM-DD, optionally followed by HH:MM, in UTC) and status (present, late or absent).
+// Rows are matched to team members by email, and to meetings of the team by title and date, or date and time if given.
+// Meetings that do not exist are created as past meetings that are over. Absent rows are recorded as absences, as members usually joined after the meetings imported.
+// Every row is checked first, and nothing is imported if any is invalid. A dry run only checks the rows.
+func (ais *AttendanceImportService) ImportAttendance(actor models.AuditActor, teamID uint, file io.Reader, dryRun bool, now time.Time) (models.AttendanceImportReport, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return models.AttendanceImportReport{}, errors.New("attendance file must have a header row")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importColumns {
+		if _, ok := columns[name]; !ok {
+			return models.AttendanceImportReport{}, errors.New("attendance file is missing the " + name + " column")
+		}
+	}
+
+	existing, err := ais.meetingRepo.GetMeetingsByTeamID(teamID)
+	if err != nil {
+		return models.AttendanceImportReport{}, err
+	}
+
+	batch := attendanceImport{
+		service:  ais,
+		teamID:   teamID,
+		adminID:  actor.UserID,
+		now:      now,
+		existing: existing,
+		created:  make(map[string]*models.Meeting),
+		users:    make(map[string]importedUser),
+		imported: make(map[*models.Meeting]map[uint]bool),
+	}
+	report := models.AttendanceImportReport{DryRun: dryRun, Rows: []models.AttendanceImportRow{}}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(report.Rows) >= maxImportRows {
+			return models.AttendanceImportReport{}, errors.New("attendance file has too many rows")
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && parseErr.Err != csv.ErrFieldCount {
+			// the row cannot be read, report it and go on with the next
+			report.Rows = append(report.Rows, models.AttendanceImportRow{Row: parseErr.Line, Error: parseErr.Err.Error()})
+			report.ErrorCount++
+			continue
+		}
+		if err != nil && parseErr == nil {
+			return models.AttendanceImportReport{}, err
+		}
+		// rows with a missing or extra field are still read, and are reported by their checks
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := models.AttendanceImportRow{
+			Row:     line,
+			Email:   field("email"),
+			Meeting: field("meeting"),
+			Date:    field("date"),
+			Status:  field("status"),
+		}
+		if err := batch.add(row); err != nil {
+			row.Error = err.Error()
+			report.ErrorCount++
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	report.MeetingsCreated = len(batch.meetings)
+	report.AttendanceAdded = len(batch.records)
+	if dryRun {
+		return report, nil
+	}
+	if report.ErrorCount > 0 {
+		return report, ErrInvalidImportRows
+	}
+
+	if err := ais.meetingRepo.ImportAttendance(batch.meetings, batch.records); err != nil {
+		logger.Errorf("Error importing attendance of team %d: %v", teamID, err)
+		return report, err
+	}
+	report.Imported = true
+
+	// the counts of the import, the rows are left out
+	imported := report
+	imported.Rows = nil
+	ais.auditLogService.Record(actor, teamID, models.AuditAttendanceImport, models.AuditTargetTeam, teamID, nil, imported)
+	return report, nil
+}
+
+type importedUser struct {
+	user models.User
+	err  error
+}
+
+// attendanceImport collects the meetings and attendance of an import file as its rows are checked.
+type attendanceImport struct {
+	service  *AttendanceImportService
+	teamID   uint
+	adminID  uint
+	now      time.Time
+	existing []models.Meeting
+	created  map[string]*models.Meeting // meetings to create, by title and start time
+	users    map[string]importedUser    // looked up team members, by email
+	imported map[*models.Meeting]map[uint]bool
+
+	meetings []*models.Meeting
+	records  []models.ImportedAttendance
+}
+
+// add checks a row and adds what it imports.
+func (ai *attendanceImport) add(row models.AttendanceImportRow) error {
+	status, ok := importStatuses[strings.ToLower(row.Status)]
+	if !ok {
+		return errors.New("status must be present, late or absent")
+	}
+	user, err := ai.getMember(row.Email)
+	if err != nil {
+		return err
+	}
+	meeting, err := ai.getMeeting(row.Meeting, row.Date)
+	if err != nil {
+		return err
+	}
+
+	if ai.imported[meeting][user.ID] {
+		return errors.New("member appears more than once for this meeting")
+	}
+	if meeting.ID != 0 {
+		if _, err := ai.service.meetingRepo.GetMeetingAttendanceByUserIDAndMeetingID(user.ID, meeting.ID); err == nil {
+			return errors.New("attendance already recorded for this meeting")
+		}
+	}
+	if ai.imported[meeting] == nil {
+		ai.imported[meeting] = make(map[uint]bool)
+	}
+	ai.imported[meeting][user.ID] = true
+
+	now := ai.now
+	ai.records = append(ai.records, models.ImportedAttendance{
+		Meeting: meeting,
+		Attendance: models.MeetingAttendance{
+			UserID:             user.ID,
+			AttendanceMarkedAt: meeting.StartTime,
+			OnTime:             status == models.AttendanceStatusOnTime,
+			Status:             status,
+			OverriddenByID:     ai.adminID,
+			OverrideReason:     importReason,
+			OverriddenAt:       &now,
+		},
+	})
+	return nil
+}
+
+// getMember returns the team member with the email.
+func (ai *attendanceImport) getMember(email string) (models.User, error) {
+	email = strings.ToLower(email)
+	if found, ok := ai.users[email]; ok {
+		return found.user, found.err
+	}
+
+	user, err := ai.service.userRepo.GetUserByEmail(email)
+	if err != nil {
+		err = errors.New("no user with this email")
+	} else if _, memberErr := ai.service.teamMemberRepo.GetTeamMemberByID(ai.teamID, user.ID); memberErr != nil {
+		err = errors.New("user is not a member of the team")
+	}
+	ai.users[email] = importedUser{user, err}
+	return user, err
+}
+
+// getMeeting returns the meeting of the team with the title on the date, creating it if there is none.
+func (ai *attendanceImport) getMeeting(title, date string) (*models.Meeting, error) {
+	if title == "" {
+		return nil, errors.New("meeting title cannot be empty")
+	}
+	startTime, withTime, err := parseImportDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*models.Meeting
+	for i := range ai.existing {
+		meeting := &ai.existing[i]
+		if !strings.EqualFold(meeting.Title, title) {
+			continue
+		}
+		start := meeting.StartTime.UTC()
+		if withTime && start.Truncate(time.Minute).Equal(startTime) || !withTime && start.Format("2006-01-02") == date {
+			matches = append(matches, meeting)
+		}
+	}
+	if len(matches) > 1 {
+		return nil, errors.New("more than one meeting matches, add the time to the date")
+	}
+	if len(matches) == 1 {
+		if !matches[0].MeetingPeriod && !matches[0].MeetingOver {
+			return nil, errors.New("meeting has not started")
+		}
+		return matches[0], nil
+	}
+
+	key := strings.ToLower(title) + "|" + startTime.Format(time.RFC3339)
+	if meeting, ok := ai.created[key]; ok {
+		return meeting, nil
+	}
+	if startTime.After(ai.now) {
+		return nil, errors.New("meeting date cannot be in the future")
+	}
+	meeting := &models.Meeting{
+		TeamID:         ai.teamID,
+		Title:          title,
+		Description:    "Imported attendance",
+		Venue:          "Imported",
+		StartTime:      startTime,
+		MeetingOver:    true,
+		AttendanceOver: true,
+		Imported:       true,
+	}
+	ai.created[key] = meeting
+	ai.meetings = append(ai.meetings, meeting)
+	return meeting, nil
+}
+
+// parseImportDate reads a date, with or without a time, in UTC. Reports whether the time was given.
+func parseImportDate(date string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02 15:04", date); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, errors.New("date must be formatted as YYYY-MM-DD or YYYY-MM-DD HH:MM")
+}
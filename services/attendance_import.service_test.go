@@ -0,0 +1,241 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/export"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAttendanceImportService_ImportAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	importService := NewAttendanceImportService(mockMeetingRepo, mockTeamMemberRepo, mockUserRepo, mockAuditLogService)
+	actor := models.AuditActor{UserID: 1}
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	standup := models.Meeting{TeamID: 1, Title: "Standup", StartTime: time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC), MeetingOver: true}
+	standup.ID = 10
+	planning := models.Meeting{TeamID: 1, Title: "Planning", StartTime: time.Date(2024, time.January, 3, 9, 0, 0, 0, time.UTC), MeetingOver: true}
+	planning.ID = 11
+	planningAgain := models.Meeting{TeamID: 1, Title: "Planning", StartTime: time.Date(2024, time.January, 3, 15, 0, 0, 0, time.UTC), MeetingOver: true}
+	planningAgain.ID = 12
+	upcoming := models.Meeting{TeamID: 1, Title: "Kickoff", StartTime: time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC)}
+	upcoming.ID = 13
+	existing := []models.Meeting{standup, planning, planningAgain, upcoming}
+
+	alice := models.User{Email: "alice@example.com"}
+	alice.ID = 2
+	bob := models.User{Email: "bob@example.com"}
+	bob.ID = 3
+
+	expectMembers := func() {
+		mockMeetingRepo.EXPECT().GetMeetingsByTeamID(uint(1)).Return(existing, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("alice@example.com").Return(alice, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("bob@example.com").Return(bob, nil)
+		mockTeamMemberRepo.EXPECT().GetTeamMemberByID(uint(1), uint(2)).Return(models.TeamMember{TeamID: 1, UserID: 2}, nil)
+		mockTeamMemberRepo.EXPECT().GetTeamMemberByID(uint(1), uint(3)).Return(models.TeamMember{TeamID: 1, UserID: 3}, nil)
+	}
+
+	validFile := "Email,Meeting,Date,Status\n" +
+		"alice@example.com,Standup,2024-01-02,present\n" +
+		"Bob@example.com,standup,2024-01-02,Late\n" +
+		"alice@example.com,Retro,2024-01-05 18:00,late\n" +
+		"bob@example.com,Retro,2024-01-05 18:00,absent\n"
+
+	t.Run("Missing_column", func(t *testing.T) {
+		_, err := importService.ImportAttendance(actor, 1, strings.NewReader("email,meeting,date\n"), false, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("Dry_run", func(t *testing.T) {
+		expectMembers()
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+
+		report, err := importService.ImportAttendance(actor, 1, strings.NewReader(validFile), true, now)
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.False(t, report.Imported)
+		assert.Len(t, report.Rows, 4)
+		assert.Equal(t, 0, report.ErrorCount)
+		assert.Equal(t, 1, report.MeetingsCreated)
+		assert.Equal(t, 4, report.AttendanceAdded)
+		assert.Equal(t, 2, report.Rows[0].Row)
+	})
+
+	t.Run("Invalid_rows_import_nothing", func(t *testing.T) {
+		file := "email,meeting,date,status\n" +
+			"alice@example.com,Standup,2024-01-02,present\n" +
+			"alice@example.com,Standup,2024-01-02,late\n" +
+			"bob@example.com,Standup,2024-01-02,present\n" +
+			"carol@example.com,Standup,2024-01-02,present\n" +
+			"alice@example.com,Standup,2024-01-02,excused\n" +
+			"alice@example.com,Planning,2024-01-03,present\n" +
+			"alice@example.com,Kickoff,2024-02-01,present\n" +
+			"alice@example.com,Launch,2024-04-01,present\n" +
+			"alice@example.com,Launch,01/04/2024,present\n"
+		expectMembers()
+		mockUserRepo.EXPECT().GetUserByEmail("carol@example.com").Return(models.User{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(10)).Return(models.MeetingAttendance{UserID: 3, MeetingID: 10}, nil)
+
+		report, err := importService.ImportAttendance(actor, 1, strings.NewReader(file), false, now)
+		assert.ErrorIs(t, err, ErrInvalidImportRows)
+		assert.False(t, report.Imported)
+		assert.Equal(t, 8, report.ErrorCount)
+		assert.Empty(t, report.Rows[0].Error)
+		for _, row := range report.Rows[1:] {
+			assert.NotEmpty(t, row.Error, "row %d", row.Row)
+		}
+	})
+
+	t.Run("Malformed_row", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingsByTeamID(uint(1)).Return(existing, nil)
+
+		report, err := importService.ImportAttendance(actor, 1, strings.NewReader("email,meeting,date,status\n\"\n"), true, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.ErrorCount)
+		assert.Equal(t, 2, report.Rows[0].Row)
+		assert.NotEmpty(t, report.Rows[0].Error)
+	})
+
+	t.Run("Not_a_member", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingsByTeamID(uint(1)).Return(existing, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("alice@example.com").Return(alice, nil)
+		mockTeamMemberRepo.EXPECT().GetTeamMemberByID(uint(1), uint(2)).Return(models.TeamMember{}, gorm.ErrRecordNotFound)
+
+		report, err := importService.ImportAttendance(actor, 1, strings.NewReader("email,meeting,date,status\nalice@example.com,Retro,2024-01-05,present\n"), true, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.ErrorCount)
+		assert.Equal(t, "user is not a member of the team", report.Rows[0].Error)
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		expectMembers()
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().ImportAttendance(gomock.Any(), gomock.Any()).DoAndReturn(func(meetings []*models.Meeting, records []models.ImportedAttendance) error {
+			retro := time.Date(2024, time.January, 5, 18, 0, 0, 0, time.UTC)
+			assert.Len(t, meetings, 1)
+			assert.Equal(t, models.Meeting{
+				TeamID: 1, Title: "Retro", Description: "Imported attendance", Venue: "Imported", StartTime: retro,
+				MeetingOver: true, AttendanceOver: true, Imported: true,
+			}, *meetings[0])
+
+			assert.Len(t, records, 4)
+			assert.Equal(t, uint(10), records[0].Meeting.ID)
+			assert.Equal(t, models.MeetingAttendance{
+				UserID: 2, AttendanceMarkedAt: standup.StartTime, OnTime: true, Status: models.AttendanceStatusOnTime,
+				OverriddenByID: 1, OverrideReason: importReason, OverriddenAt: &now,
+			}, records[0].Attendance)
+			assert.Equal(t, models.AttendanceStatusLate, records[1].Attendance.Status)
+			assert.False(t, records[1].Attendance.OnTime)
+			assert.Same(t, meetings[0], records[2].Meeting)
+			assert.Equal(t, retro, records[2].Attendance.AttendanceMarkedAt)
+			// absences are recorded, the members joined after the meetings
+			assert.Equal(t, models.AttendanceStatusAbsent, records[3].Attendance.Status)
+			assert.False(t, records[3].Attendance.OnTime)
+			assert.Equal(t, uint(3), records[3].Attendance.UserID)
+			return nil
+		})
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditAttendanceImport, models.AuditTargetTeam, uint(1), nil, gomock.Any()).
+			Do(func(_ models.AuditActor, _ uint, _, _ string, _ uint, _, after interface{}) {
+				imported := after.(models.AttendanceImportReport)
+				assert.Nil(t, imported.Rows)
+				assert.Equal(t, 4, imported.AttendanceAdded)
+			})
+
+		report, err := importService.ImportAttendance(actor, 1, strings.NewReader(validFile), false, now)
+		assert.NoError(t, err)
+		assert.True(t, report.Imported)
+		assert.Equal(t, 4, report.AttendanceAdded)
+	})
+
+	t.Run("Import_fails", func(t *testing.T) {
+		expectMembers()
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(10)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockMeetingRepo.EXPECT().ImportAttendance(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+
+		report, err := importService.ImportAttendance(actor, 1, strings.NewReader(validFile), false, now)
+		assert.Error(t, err)
+		assert.False(t, report.Imported)
+	})
+}
+
+// imported absences of members who joined after the meetings show in the summary and the export
+func TestAttendanceImportService_ImportedAbsences(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.User{}, &models.Team{}, &models.TeamMember{}, &models.Meeting{}, &models.MeetingAttendance{}, &models.LeaveRequest{})
+	database.DB = db
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	meetingRepo := repository.NewMeetingRepository()
+	teamRepo := repository.NewTeamRepository()
+	teamMemberRepo := repository.NewTeamMemberRepository()
+	userRepo := repository.NewUserRepository()
+	leaveRequestRepo := repository.NewLeaveRequestRepository()
+	importService := NewAttendanceImportService(meetingRepo, teamMemberRepo, userRepo, newAuditLogServiceMock(ctrl))
+	meetingService := NewMeetingService(meetingRepo, mocks.NewMockEmailService(ctrl), userRepo, teamRepo, teamMemberRepo, leaveRequestRepo, newAuditLogServiceMock(ctrl))
+	exportService := NewAttendanceExportService(meetingRepo, teamRepo, teamMemberRepo, leaveRequestRepo)
+
+	// the team moves its history in after its members joined
+	db.Create(&models.Team{Name: "Team", SuperAdminID: 1})
+	db.Create(&models.User{Name: "Alice", Email: "alice@example.com"})
+	db.Create(&models.User{Name: "Bob", Email: "bob@example.com"})
+	db.Create(&models.TeamMember{TeamID: 1, UserID: 1, Role: models.SuperAdminRole})
+	db.Create(&models.TeamMember{TeamID: 1, UserID: 2, Role: models.MemberRole})
+
+	file := "email,meeting,date,status\n" +
+		"alice@example.com,Standup,2024-01-02 09:00,present\n" +
+		"bob@example.com,Standup,2024-01-02 09:00,absent\n"
+	report, err := importService.ImportAttendance(models.AuditActor{UserID: 1}, 1, strings.NewReader(file), false, time.Now())
+	if err != nil {
+		t.Fatalf("ImportAttendance returned an error: %v", err)
+	}
+	assert.Equal(t, 2, report.AttendanceAdded)
+
+	summary, err := meetingService.GetMeetingSummary(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, summary.Expected)
+	assert.Equal(t, 1, summary.PresentCount)
+	assert.Equal(t, 1, summary.AbsentCount)
+	if assert.Len(t, summary.Absent, 1) {
+		assert.Equal(t, "bob@example.com", summary.Absent[0].Email)
+	}
+
+	standup := time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC)
+	register, err := exportService.GetTeamRegister(1, standup, standup)
+	if err != nil {
+		t.Fatalf("GetTeamRegister returned an error: %v", err)
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, exportService.WriteRegister(register, export.NewCSVWriter(&buf)))
+	assert.Equal(t, "Name,Email,Standup (2024-01-02 09:00),Present,Late,Absent,Excused,Attendance %\n"+
+		"Alice,alice@example.com,present,1,0,0,0,100\n"+
+		"Bob,bob@example.com,absent,0,0,1,0,0\n"+
+		"Attended,,1\n"+
+		"Attendance %,,50\n", buf.String())
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+)
+
+// Page sizes of the audit log.
+const (
+	defaultAuditLogPageSize = 50
+	maxAuditLogPageSize     = 200
+)
+
+// AuditLogService records administrative actions on teams and reads them back.
+type AuditLogService struct {
+	auditLogRepo repository.AuditLogRepositoryInterface
+}
+
+// NewAuditLogService creates a new AuditLogService.
+func NewAuditLogService(auditLogRepo repository.AuditLogRepositoryInterface) *AuditLogService {
+	return &AuditLogService{auditLogRepo}
+}
+
+type AuditLogServiceInterface interface {
+	Record(actor models.AuditActor, teamID uint, action, targetType string, targetID uint, before, after interface{})
+	GetTeamAuditLogs(filter models.AuditLogFilter) (models.AuditLogPage, error)
+}
+
+// Record adds an action to the audit log of a team. Failures are only logged, the action stands.
+func (als *AuditLogService) Record(actor models.AuditActor, teamID uint, action, targetType string, targetID uint, before, after interface{}) {
+	_, err := als.auditLogRepo.CreateAuditLog(models.AuditLog{
+		TeamID:     teamID,
+		ActorID:    actor.UserID,
+		IP:         actor.IP,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+	})
+	if err != nil {
+		logger.Errorf("Failed to record %s by user %d on team %d in the audit log: %v", action, actor.UserID, teamID, err)
+	}
+}
+
+// GetTeamAuditLogs returns a page of the audit log of a team, newest first. The page defaults to the first, of 50 entries.
+func (als *AuditLogService) GetTeamAuditLogs(filter models.AuditLogFilter) (models.AuditLogPage, error) {
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = defaultAuditLogPageSize
+	}
+	if filter.Page < 1 || filter.PageSize < 1 || filter.PageSize > maxAuditLogPageSize {
+		return models.AuditLogPage{}, errors.New("page must be at least 1 and page size between 1 and 200")
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return models.AuditLogPage{}, errors.New("date range cannot end before it starts")
+	}
+
+	entries, total, err := als.auditLogRepo.GetAuditLogs(filter)
+	if err != nil {
+		return models.AuditLogPage{}, err
+	}
+	return models.AuditLogPage{Entries: entries, Total: total, Page: filter.Page, PageSize: filter.PageSize}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
@@ -45,6 +46,8 @@ type GenericEmail struct {
 
 type EmailServiceInterface interface {
 	SendMeetingNotification(teamID uint, meeting models.Meeting) error
+	SendMeetingUpdateNotification(teamID uint, meeting models.Meeting, changes []string) error
+	SendMeetingSeriesNotification(teamID uint, series models.MeetingSeries) error
 	GenericSendMail(subject string, content string, toEmail string, userName string) error
 }
 
@@ -56,24 +59,39 @@ func (es *EmailService) SendMeetingNotification(teamID uint, meeting models.Meet
 		return err
 	}
 
-	// get members of the team and send an email to each member
-	teamMembers, err := es.teamMemberRepo.GetTeamMembersByTeamID(teamID)
+	teamMemberEmails, err := es.getTeamMemberEmails(teamID)
 	if err != nil {
 		return err
 	}
 
-	teamMemberEmails := []string{}
-	for _, teamMember := range teamMembers {
-		user, err := es.userRepo.GetUserByID(teamMember.UserID)
+	content := "A new meeting " + meeting.Title + " has been scheduled for the team " + team.Name + " at " + meeting.StartTime.String() + "."
+	subject := "New Meeting."
+
+	for _, email := range teamMemberEmails {
+		err := es.GenericSendMail(subject, content, email, team.Name+" Team")
 		if err != nil {
 			return err
 		}
-		// add user email to teamMemberEmails
-		teamMemberEmails = append(teamMemberEmails, user.Email)
 	}
 
-	content := "A new meeting " + meeting.Title + " has been scheduled for the team " + team.Name + " at " + meeting.StartTime.String() + "."
-	subject := "New Meeting."
+	return nil
+}
+
+// SendMeetingUpdateNotification lets team members know which details of a meeting changed.
+func (es *EmailService) SendMeetingUpdateNotification(teamID uint, meeting models.Meeting, changes []string) error {
+	// get name of the team
+	team, err := es.teamRepo.GetTeamByID(teamID)
+	if err != nil {
+		return err
+	}
+
+	teamMemberEmails, err := es.getTeamMemberEmails(teamID)
+	if err != nil {
+		return err
+	}
+
+	content := "The meeting " + meeting.Title + " of the team " + team.Name + " has been updated:\n" + strings.Join(changes, "\n")
+	subject := "Meeting Updated."
 
 	for _, email := range teamMemberEmails {
 		err := es.GenericSendMail(subject, content, email, team.Name+" Team")
@@ -85,6 +103,51 @@ func (es *EmailService) SendMeetingNotification(teamID uint, meeting models.Meet
 	return nil
 }
 
+// SendMeetingSeriesNotification lets team members know of the schedule of a recurring meeting.
+func (es *EmailService) SendMeetingSeriesNotification(teamID uint, series models.MeetingSeries) error {
+	// get name of the team
+	team, err := es.teamRepo.GetTeamByID(teamID)
+	if err != nil {
+		return err
+	}
+
+	teamMemberEmails, err := es.getTeamMemberEmails(teamID)
+	if err != nil {
+		return err
+	}
+
+	content := "The recurring meeting " + series.Title + " of the team " + team.Name + " is scheduled from " + series.StartTime.String() + ", repeating " + series.RRule + "."
+	subject := "Recurring Meeting Scheduled."
+
+	for _, email := range teamMemberEmails {
+		err := es.GenericSendMail(subject, content, email, team.Name+" Team")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getTeamMemberEmails returns the email of every member of the team.
+func (es *EmailService) getTeamMemberEmails(teamID uint) ([]string, error) {
+	teamMembers, err := es.teamMemberRepo.GetTeamMembersByTeamID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMemberEmails := []string{}
+	for _, teamMember := range teamMembers {
+		user, err := es.userRepo.GetUserByID(teamMember.UserID)
+		if err != nil {
+			return nil, err
+		}
+		// add user email to teamMemberEmails
+		teamMemberEmails = append(teamMemberEmails, user.Email)
+	}
+	return teamMemberEmails, nil
+}
+
 // GenericSendMail sends a generic email.
 func (es *EmailService) GenericSendMail(subject string, content string, toEmail string, userName string) error {
 	url := "https://send.api.mailtrap.io/api/send"
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"gorm.io/gorm"
+)
+
+// maxLeaveReasonLength is the longest reason or review note of a leave request.
+const maxLeaveReasonLength = 500
+
+// LeaveRequestService handles members asking to be excused from meetings, and admins reviewing the requests.
+type LeaveRequestService struct {
+	leaveRequestRepo repository.LeaveRequestRepositoryInterface
+	meetingRepo      repository.MeetingRepositoryInterface
+	userRepo         repository.UserRepositoryInterface
+	emailService     EmailServiceInterface
+	auditLogService  AuditLogServiceInterface
+}
+
+// NewLeaveRequestService creates a new LeaveRequestService.
+func NewLeaveRequestService(
+	leaveRequestRepo repository.LeaveRequestRepositoryInterface,
+	meetingRepo repository.MeetingRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	emailService EmailServiceInterface,
+	auditLogService AuditLogServiceInterface,
+) *LeaveRequestService {
+	return &LeaveRequestService{leaveRequestRepo, meetingRepo, userRepo, emailService, auditLogService}
+}
+
+type LeaveRequestServiceInterface interface {
+	SubmitLeaveRequest(teamID, meetingID, userID uint, reason string) (models.LeaveRequest, error)
+	WithdrawLeaveRequest(teamID, meetingID, userID uint) error
+	GetUserLeaveRequests(teamID, userID uint) ([]models.LeaveRequest, error)
+	GetLeaveRequests(teamID uint, status string, meetingID uint) ([]models.LeaveRequestResponse, error)
+	ReviewLeaveRequest(actor models.AuditActor, requestID, teamID uint, status, note string, now time.Time) (models.LeaveRequest, error)
+}
+
+// SubmitLeaveRequest asks for a member to be excused from a meeting of the team, before or after it.
+// A rejected request can be replaced by a new one, a pending or approved one cannot.
+func (lrs *LeaveRequestService) SubmitLeaveRequest(teamID, meetingID, userID uint, reason string) (models.LeaveRequest, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return models.LeaveRequest{}, errors.New("reason cannot be empty")
+	}
+	if len(reason) > maxLeaveReasonLength {
+		return models.LeaveRequest{}, errors.New("reason is too long")
+	}
+
+	meeting, err := lrs.meetingRepo.GetMeetingByID(meetingID)
+	if err != nil || meeting.TeamID != teamID {
+		return models.LeaveRequest{}, errors.New("meeting not found")
+	}
+
+	attendance, err := lrs.meetingRepo.GetMeetingAttendanceByUserIDAndMeetingID(userID, meetingID)
+	if err == nil && attendance.AttendanceStatus() != models.AttendanceStatusAbsent {
+		return models.LeaveRequest{}, errors.New("attendance already marked for this meeting")
+	}
+
+	existing, err := lrs.leaveRequestRepo.GetLeaveRequestByMeetingIDAndUserID(meetingID, userID)
+	if err == nil {
+		switch existing.Status {
+		case models.LeaveRequestPending:
+			return models.LeaveRequest{}, errors.New("leave request already pending")
+		case models.LeaveRequestApproved:
+			return models.LeaveRequest{}, errors.New("leave request already approved")
+		}
+		if err := lrs.leaveRequestRepo.DeleteLeaveRequestByID(existing.ID); err != nil {
+			return models.LeaveRequest{}, err
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.LeaveRequest{}, err
+	}
+
+	return lrs.leaveRequestRepo.CreateLeaveRequest(models.LeaveRequest{
+		TeamID:    teamID,
+		MeetingID: meetingID,
+		UserID:    userID,
+		Reason:    reason,
+		Status:    models.LeaveRequestPending,
+	})
+}
+
+// WithdrawLeaveRequest deletes a member's leave request for a meeting, as long as it has not been reviewed.
+func (lrs *LeaveRequestService) WithdrawLeaveRequest(teamID, meetingID, userID uint) error {
+	request, err := lrs.leaveRequestRepo.GetLeaveRequestByMeetingIDAndUserID(meetingID, userID)
+	if err != nil || request.TeamID != teamID {
+		return errors.New("leave request not found")
+	}
+	if request.Status != models.LeaveRequestPending {
+		return errors.New("only pending leave requests can be withdrawn")
+	}
+	return lrs.leaveRequestRepo.DeleteLeaveRequestByID(request.ID)
+}
+
+// GetUserLeaveRequests returns a member's leave requests in a team, newest first.
+func (lrs *LeaveRequestService) GetUserLeaveRequests(teamID, userID uint) ([]models.LeaveRequest, error) {
+	return lrs.leaveRequestRepo.GetLeaveRequestsByTeamIDAndUserID(teamID, userID)
+}
+
+// GetLeaveRequests returns the leave requests of a team with who made them, newest first.
+// An empty status or a zero meetingID matches any.
+func (lrs *LeaveRequestService) GetLeaveRequests(teamID uint, status string, meetingID uint) ([]models.LeaveRequestResponse, error) {
+	if status != "" && status != models.LeaveRequestPending && status != models.LeaveRequestApproved && status != models.LeaveRequestRejected {
+		return nil, errors.New("status must be pending, approved or rejected")
+	}
+	requests, err := lrs.leaveRequestRepo.GetLeaveRequestsByTeamID(teamID, status, meetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.LeaveRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		user, err := lrs.userRepo.GetUserByID(request.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// account deleted since
+				continue
+			}
+			return nil, err
+		}
+		responses = append(responses, models.LeaveRequestResponse{Request: request, User: user})
+	}
+	return responses, nil
+}
+
+// ReviewLeaveRequest approves or rejects a pending leave request, and lets the member know.
+func (lrs *LeaveRequestService) ReviewLeaveRequest(actor models.AuditActor, requestID, teamID uint, status, note string, now time.Time) (models.LeaveRequest, error) {
+	if status != models.LeaveRequestApproved && status != models.LeaveRequestRejected {
+		return models.LeaveRequest{}, errors.New("status must be approved or rejected")
+	}
+	note = strings.TrimSpace(note)
+	if len(note) > maxLeaveReasonLength {
+		return models.LeaveRequest{}, errors.New("note is too long")
+	}
+
+	request, err := lrs.leaveRequestRepo.GetLeaveRequestByID(requestID)
+	if err != nil || request.TeamID != teamID {
+		return models.LeaveRequest{}, errors.New("leave request not found")
+	}
+	if request.Status != models.LeaveRequestPending {
+		return models.LeaveRequest{}, errors.New("leave request has already been reviewed")
+	}
+
+	before := request
+	request.Status = status
+	request.ReviewedByID = actor.UserID
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+	request, err = lrs.leaveRequestRepo.UpdateLeaveRequest(request)
+	if err != nil {
+		return models.LeaveRequest{}, err
+	}
+
+	lrs.auditLogService.Record(actor, teamID, models.AuditLeaveReview, models.AuditTargetLeave, request.ID, before, request)
+
+	lrs.notifyReview(request)
+	return request, nil
+}
+
+// notifyReview emails the member the outcome of their leave request. Failures are only logged, the review stands.
+func (lrs *LeaveRequestService) notifyReview(request models.LeaveRequest) {
+	user, err := lrs.userRepo.GetUserByID(request.UserID)
+	if err != nil {
+		logger.Errorf("Failed to get user %d to notify of leave request %d: %v", request.UserID, request.ID, err)
+		return
+	}
+	meeting, err := lrs.meetingRepo.GetMeetingByID(request.MeetingID)
+	if err != nil {
+		logger.Errorf("Failed to get meeting %d to notify of leave request %d: %v", request.MeetingID, request.ID, err)
+		return
+	}
+
+	content := "Your leave request for the meeting " + meeting.Title + " has been " + request.Status + "."
+	if request.ReviewNote != "" {
+		content += "\nNote: " + request.ReviewNote
+	}
+	subject := "Leave Request Rejected."
+	if request.Status == models.LeaveRequestApproved {
+		subject = "Leave Request Approved."
+	}
+	if err := lrs.emailService.GenericSendMail(subject, content, user.Email, user.Name); err != nil {
+		logger.Errorf("Failed to send leave request notification to %s: %v", user.Email, err)
+	}
+}
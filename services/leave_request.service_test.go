@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestLeaveRequestService_SubmitLeaveRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	leaveRequestService := NewLeaveRequestService(mockLeaveRequestRepo, mockMeetingRepo, mocks.NewMockUserRepository(ctrl), mocks.NewMockEmailService(ctrl), newAuditLogServiceMock(ctrl))
+
+	meeting := models.Meeting{TeamID: 1, Title: "Sync"}
+	meeting.ID = 2
+	pending := models.LeaveRequest{TeamID: 1, MeetingID: 2, UserID: 3, Reason: "Exam", Status: models.LeaveRequestPending}
+
+	t.Run("Empty_reason", func(t *testing.T) {
+		_, err := leaveRequestService.SubmitLeaveRequest(1, 2, 3, "  ")
+		assert.Error(t, err)
+	})
+
+	t.Run("Meeting_of_another_team", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(2)).Return(models.Meeting{TeamID: 5}, nil)
+
+		_, err := leaveRequestService.SubmitLeaveRequest(1, 2, 3, "Exam")
+		assert.EqualError(t, err, "meeting not found")
+	})
+
+	t.Run("Already_attended", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(2)).Return(meeting, nil)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(2)).Return(models.MeetingAttendance{OnTime: true}, nil)
+
+		_, err := leaveRequestService.SubmitLeaveRequest(1, 2, 3, "Exam")
+		assert.EqualError(t, err, "attendance already marked for this meeting")
+	})
+
+	t.Run("Already_pending", func(t *testing.T) {
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(2)).Return(meeting, nil)
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(2)).Return(models.MeetingAttendance{}, gorm.ErrRecordNotFound)
+		mockLeaveRequestRepo.EXPECT().GetLeaveRequestByMeetingIDAndUserID(uint(2), uint(3)).Return(pending, nil)
+
+		_, err := leaveRequestService.SubmitLeaveRequest(1, 2, 3, "Exam")
+		assert.EqualError(t, err, "leave request already pending")
+	})
+
+	t.Run("Replaces_rejected_request", func(t *testing.T) {
+		rejected := pending
+		rejected.ID = 7
+		rejected.Status = models.LeaveRequestRejected
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(2)).Return(meeting, nil)
+		// marked too late for the attendance policy, so still absent
+		mockMeetingRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(2)).Return(models.MeetingAttendance{Status: models.AttendanceStatusAbsent}, nil)
+		mockLeaveRequestRepo.EXPECT().GetLeaveRequestByMeetingIDAndUserID(uint(2), uint(3)).Return(rejected, nil)
+		mockLeaveRequestRepo.EXPECT().DeleteLeaveRequestByID(uint(7)).Return(nil)
+		mockLeaveRequestRepo.EXPECT().CreateLeaveRequest(pending).Return(pending, nil)
+
+		request, err := leaveRequestService.SubmitLeaveRequest(1, 2, 3, " Exam ")
+		assert.NoError(t, err)
+		assert.Equal(t, pending, request)
+	})
+}
+
+func TestLeaveRequestService_WithdrawLeaveRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
+	leaveRequestService := NewLeaveRequestService(mockLeaveRequestRepo, mocks.NewMockMeetingRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockEmailService(ctrl), newAuditLogServiceMock(ctrl))
+
+	request := models.LeaveRequest{TeamID: 1, MeetingID: 2, UserID: 3, Status: models.LeaveRequestApproved}
+	request.ID = 7
+
+	mockLeaveRequestRepo.EXPECT().GetLeaveRequestByMeetingIDAndUserID(uint(2), uint(3)).Return(request, nil)
+	assert.Error(t, leaveRequestService.WithdrawLeaveRequest(1, 2, 3))
+
+	request.Status = models.LeaveRequestPending
+	mockLeaveRequestRepo.EXPECT().GetLeaveRequestByMeetingIDAndUserID(uint(2), uint(3)).Return(request, nil)
+	mockLeaveRequestRepo.EXPECT().DeleteLeaveRequestByID(uint(7)).Return(nil)
+	assert.NoError(t, leaveRequestService.WithdrawLeaveRequest(1, 2, 3))
+}
+
+func TestLeaveRequestService_ReviewLeaveRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	leaveRequestService := NewLeaveRequestService(mockLeaveRequestRepo, mockMeetingRepo, mockUserRepo, mockEmailService, mockAuditLogService)
+	actor := models.AuditActor{UserID: 9}
+
+	now := time.Now()
+	request := models.LeaveRequest{TeamID: 1, MeetingID: 2, UserID: 3, Reason: "Exam", Status: models.LeaveRequestPending}
+	request.ID = 7
+	user := models.User{Name: "Alice", Email: "alice@example.com"}
+
+	t.Run("Invalid_status", func(t *testing.T) {
+		_, err := leaveRequestService.ReviewLeaveRequest(actor, 7, 1, models.LeaveRequestPending, "", now)
+		assert.Error(t, err)
+	})
+
+	t.Run("Request_of_another_team", func(t *testing.T) {
+		mockLeaveRequestRepo.EXPECT().GetLeaveRequestByID(uint(7)).Return(request, nil)
+
+		_, err := leaveRequestService.ReviewLeaveRequest(actor, 7, 5, models.LeaveRequestApproved, "", now)
+		assert.EqualError(t, err, "leave request not found")
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		approved := request
+		approved.Status = models.LeaveRequestApproved
+		approved.ReviewedByID = 9
+		approved.ReviewedAt = &now
+		approved.ReviewNote = "Good luck"
+		mockLeaveRequestRepo.EXPECT().GetLeaveRequestByID(uint(7)).Return(request, nil)
+		mockLeaveRequestRepo.EXPECT().UpdateLeaveRequest(approved).Return(approved, nil)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditLeaveReview, models.AuditTargetLeave, uint(7), request, approved)
+		mockUserRepo.EXPECT().GetUserByID(uint(3)).Return(user, nil)
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(2)).Return(models.Meeting{Title: "Sync"}, nil)
+		mockEmailService.EXPECT().GenericSendMail("Leave Request Approved.", "Your leave request for the meeting Sync has been approved.\nNote: Good luck", "alice@example.com", "Alice").Return(nil)
+
+		reviewed, err := leaveRequestService.ReviewLeaveRequest(actor, 7, 1, models.LeaveRequestApproved, "Good luck", now)
+		assert.NoError(t, err)
+		assert.Equal(t, approved, reviewed)
+	})
+
+	t.Run("Already_reviewed", func(t *testing.T) {
+		rejected := request
+		rejected.Status = models.LeaveRequestRejected
+		mockLeaveRequestRepo.EXPECT().GetLeaveRequestByID(uint(7)).Return(rejected, nil)
+
+		_, err := leaveRequestService.ReviewLeaveRequest(actor, 7, 1, models.LeaveRequestApproved, "", now)
+		assert.EqualError(t, err, "leave request has already been reviewed")
+	})
+}
@@ -2,21 +2,29 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"sort"
 	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/geo"
+	"github.com/GDGVIT/attendance-app-backend/utils/totp"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
 )
 
 // MeetingService handles business logic related to meetings.
 type MeetingService struct {
-	meetingRepo    repository.MeetingRepositoryInterface
-	emailService   EmailServiceInterface
-	userRepo       repository.UserRepositoryInterface
-	teamRepo       repository.TeamRepositoryInterface
-	teamMemberRepo repository.TeamMemberRepositoryInterface
+	meetingRepo      repository.MeetingRepositoryInterface
+	emailService     EmailServiceInterface
+	userRepo         repository.UserRepositoryInterface
+	teamRepo         repository.TeamRepositoryInterface
+	teamMemberRepo   repository.TeamMemberRepositoryInterface
+	leaveRequestRepo repository.LeaveRequestRepositoryInterface
+	auditLogService  AuditLogServiceInterface
 }
 
 // NewMeetingService creates a new MeetingService.
@@ -26,34 +34,76 @@ func NewMeetingService(
 	userRepo repository.UserRepositoryInterface,
 	teamRepo repository.TeamRepositoryInterface,
 	teamMemberRepo repository.TeamMemberRepositoryInterface,
+	leaveRequestRepo repository.LeaveRequestRepositoryInterface,
+	auditLogService AuditLogServiceInterface,
 ) *MeetingService {
-	return &MeetingService{meetingRepo, emailService, userRepo, teamRepo, teamMemberRepo}
+	return &MeetingService{meetingRepo, emailService, userRepo, teamRepo, teamMemberRepo, leaveRequestRepo, auditLogService}
+}
+
+// Reasons attendance cannot be marked, as opposed to failures to mark it.
+var (
+	ErrMeetingNotOpen          = errors.New("meeting not started or meeting over")
+	ErrAttendanceNotOpen       = errors.New("attendance not started")
+	ErrAttendanceMarked        = errors.New("attendance already marked")
+	ErrInvalidAttendanceCode   = errors.New("invalid or expired attendance code")
+	ErrLocationRequired        = errors.New("location is required to mark attendance for this meeting")
+	ErrLocationInaccurate      = errors.New("location accuracy too low, please try again")
+	ErrOutsideGeofence         = errors.New("you are too far from the meeting location")
+	ErrOutsideAltitudeGeofence = errors.New("you are not on the meeting floor")
+)
+
+// generateAttendanceSecret creates the per-meeting secret for attendance codes. Replaced in tests for deterministic secrets.
+var generateAttendanceSecret = totp.GenerateSecret
+
+// defaultAttendanceCodePeriod is the attendance code period, in seconds, used when ATTENDANCE_CODE_PERIOD is unset or not positive.
+const defaultAttendanceCodePeriod = 30
+
+// attendanceCodeOptions returns the options for attendance codes. Codes rotate every ATTENDANCE_CODE_PERIOD seconds, and the just-expired code is still accepted.
+func attendanceCodeOptions() totp.Options {
+	period := viper.GetInt("ATTENDANCE_CODE_PERIOD")
+	if period <= 0 {
+		period = defaultAttendanceCodePeriod
+	}
+	return totp.Options{
+		Period: time.Duration(period) * time.Second,
+		Digits: 6,
+		Skew:   1,
+	}
 }
 
 type MeetingServiceInterface interface {
-	CreateMeeting(teamID uint, title, description, venue string, location models.Location, startTime time.Time) (models.Meeting, error)
+	CreateMeeting(actor models.AuditActor, teamID uint, title, description, venue string, location models.Location, geofence models.Geofence, schedule models.MeetingSchedule, policy models.AttendancePolicy, startTime time.Time) (models.Meeting, error)
 	GetMeetingsByTeamID(teamID uint, filterBy string, orderBy string) ([]models.Meeting, error)
 	GetMeetingByID(id uint, teamid uint) (models.Meeting, error)
-	StartMeeting(meetingID uint, teamid uint) (models.Meeting, error)
-	EndMeeting(meetingID uint, teamid uint) (models.Meeting, error)
-	StartAttendance(meetingID uint, teamid uint) (models.Meeting, error)
-	EndAttendance(meetingID uint, teamid uint) (models.Meeting, error)
-	DeleteMeetingByID(meetingID uint, teamid uint) error
-	MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamid uint) (bool, error)
+	UpdateMeeting(actor models.AuditActor, meetingID, teamID uint, update models.MeetingUpdate) (models.Meeting, error)
+	StartMeeting(actor models.AuditActor, meetingID uint, teamid uint) (models.Meeting, error)
+	EndMeeting(actor models.AuditActor, meetingID uint, teamid uint) (models.Meeting, error)
+	StartAttendance(actor models.AuditActor, meetingID uint, teamid uint) (models.Meeting, error)
+	EndAttendance(actor models.AuditActor, meetingID uint, teamid uint) (models.Meeting, error)
+	DeleteMeetingByID(actor models.AuditActor, meetingID uint, teamid uint) error
+	RunScheduledTransitions(now time.Time) error
+	GetAttendanceCode(meetingID, teamID uint, now time.Time) (models.AttendanceCodeResponse, error)
+	MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamid uint, code string, position *models.ReportedLocation) (models.MeetingAttendance, error)
 	GetAttendanceForMeeting(meetingID, teamID uint) ([]models.MeetingAttendanceListResponse, error)
+	GetMeetingSummary(meetingID, teamID uint) (models.MeetingSummaryResponse, error)
+	OverrideAttendance(actor models.AuditActor, userID, meetingID, teamID uint, onTime bool, reason string, now time.Time) (models.MeetingAttendance, error)
+	RemoveAttendance(actor models.AuditActor, userID, meetingID, teamID uint, reason string, now time.Time) error
 	UpcomingUserMeetings(userID uint) ([]models.UserUpcomingMeetingsListResponse, error)
 	GetFullUserAttendanceRecord(userID uint) ([]models.MeetingAttendanceListResponse, error)
 }
 
-// CreateMeeting creates a new meeting in the database.
-func (ms *MeetingService) CreateMeeting(teamID uint, title, description, venue string, location models.Location, startTime time.Time) (models.Meeting, error) {
+// CreateMeeting creates a new meeting in the database. The attendance policy overrides the team's when enabled.
+func (ms *MeetingService) CreateMeeting(actor models.AuditActor, teamID uint, title, description, venue string, location models.Location, geofence models.Geofence, schedule models.MeetingSchedule, policy models.AttendancePolicy, startTime time.Time) (models.Meeting, error) {
 	meeting := models.Meeting{
-		TeamID:      teamID,
-		Title:       title,
-		Description: description,
-		Venue:       venue,
-		Location:    location,
-		StartTime:   startTime,
+		TeamID:           teamID,
+		Title:            title,
+		Description:      description,
+		Venue:            venue,
+		Location:         location,
+		Geofence:         geofence,
+		Schedule:         schedule,
+		AttendancePolicy: policy,
+		StartTime:        startTime,
 	}
 
 	// Create the meeting in the database
@@ -63,6 +113,8 @@ func (ms *MeetingService) CreateMeeting(teamID uint, title, description, venue s
 		return models.Meeting{}, err
 	}
 
+	ms.auditLogService.Record(actor, teamID, models.AuditMeetingCreate, models.AuditTargetMeeting, createdMeeting.ID, nil, createdMeeting)
+
 	// use email service to send email to all team members
 	ms.emailService.SendMeetingNotification(teamID, createdMeeting)
 
@@ -83,12 +135,89 @@ func (ms *MeetingService) GetMeetingByID(id uint, teamid uint) (models.Meeting,
 	return meeting, nil
 }
 
+// UpdateMeeting changes the details of a meeting and notifies team members of what changed.
+// Once the meeting has started or is over, only the title and description can be changed.
+func (ms *MeetingService) UpdateMeeting(actor models.AuditActor, meetingID, teamID uint, update models.MeetingUpdate) (models.Meeting, error) {
+	meeting, err := ms.GetMeetingByID(meetingID, teamID)
+	if err != nil {
+		return models.Meeting{}, err
+	}
+	before := meeting
+
+	started := meeting.MeetingPeriod || meeting.MeetingOver
+	if started && (update.Venue != nil || update.Location != nil || update.Geofence != nil || update.StartTime != nil || update.Schedule != nil || update.AttendancePolicy != nil) {
+		return models.Meeting{}, errors.New("only the title and description can be changed after the meeting has started")
+	}
+
+	var changes []string
+	if update.Title != nil && *update.Title != meeting.Title {
+		changes = append(changes, fmt.Sprintf("Title: %s -> %s", meeting.Title, *update.Title))
+		meeting.Title = *update.Title
+	}
+	if update.Description != nil && *update.Description != meeting.Description {
+		changes = append(changes, fmt.Sprintf("Description: %s -> %s", meeting.Description, *update.Description))
+		meeting.Description = *update.Description
+	}
+	if update.Venue != nil && *update.Venue != meeting.Venue {
+		changes = append(changes, fmt.Sprintf("Venue: %s -> %s", meeting.Venue, *update.Venue))
+		meeting.Venue = *update.Venue
+	}
+	if update.Location != nil && *update.Location != meeting.Location {
+		changes = append(changes, "Location updated")
+		meeting.Location = *update.Location
+	}
+	if update.Geofence != nil && *update.Geofence != meeting.Geofence {
+		changes = append(changes, "Attendance area updated")
+		meeting.Geofence = *update.Geofence
+	}
+	if update.Schedule != nil {
+		schedule := *update.Schedule
+		schedule.State = meeting.Schedule.State
+		if schedule != meeting.Schedule {
+			changes = append(changes, "Schedule updated")
+			meeting.Schedule = schedule
+		}
+	}
+	if update.AttendancePolicy != nil && *update.AttendancePolicy != meeting.AttendancePolicy {
+		changes = append(changes, "Attendance policy updated")
+		meeting.AttendancePolicy = *update.AttendancePolicy
+	}
+	if update.StartTime != nil && !update.StartTime.Equal(meeting.StartTime) {
+		if update.StartTime.Before(time.Now()) {
+			return models.Meeting{}, errors.New("meeting start time cannot be in the past")
+		}
+		changes = append(changes, fmt.Sprintf("Start time: %s -> %s", meeting.StartTime.String(), update.StartTime.String()))
+		meeting.StartTime = *update.StartTime
+	}
+
+	if len(changes) == 0 {
+		return meeting, nil
+	}
+	if err := meeting.ValidateDetails(); err != nil {
+		return models.Meeting{}, err
+	}
+
+	updatedMeeting, err := ms.meetingRepo.UpdateMeeting(meeting)
+	if err != nil {
+		logger.Errorf("Error updating meeting: " + err.Error())
+		return models.Meeting{}, err
+	}
+
+	ms.auditLogService.Record(actor, teamID, models.AuditMeetingUpdate, models.AuditTargetMeeting, meetingID, before, updatedMeeting)
+
+	// use email service to let all team members know what changed
+	ms.emailService.SendMeetingUpdateNotification(teamID, updatedMeeting, changes)
+
+	return updatedMeeting, nil
+}
+
 // StartMeeting starts a meeting by setting MeetingPeriod to true, if not MeetingOver.
-func (ms *MeetingService) StartMeeting(meetingID uint, teamid uint) (models.Meeting, error) {
+func (ms *MeetingService) StartMeeting(actor models.AuditActor, meetingID uint, teamid uint) (models.Meeting, error) {
 	meeting, err := ms.GetMeetingByID(meetingID, teamid)
 	if err != nil {
 		return models.Meeting{}, err
 	}
+	before := meeting
 
 	if meeting.MeetingOver {
 		return models.Meeting{}, errors.New("meeting cannot be started after it has ended once")
@@ -102,15 +231,18 @@ func (ms *MeetingService) StartMeeting(meetingID uint, teamid uint) (models.Meet
 		return models.Meeting{}, err
 	}
 
+	ms.auditLogService.Record(actor, teamid, models.AuditMeetingStart, models.AuditTargetMeeting, meetingID, before, updatedMeeting)
+
 	return updatedMeeting, nil
 }
 
 // StartAttendance starts attendance for a meeting by setting AttendancePeriod to true, if meeting in progress, or if not meeting over.
-func (ms *MeetingService) StartAttendance(meetingID uint, teamID uint) (models.Meeting, error) {
+func (ms *MeetingService) StartAttendance(actor models.AuditActor, meetingID uint, teamID uint) (models.Meeting, error) {
 	meeting, err := ms.GetMeetingByID(meetingID, teamID)
 	if err != nil {
 		return models.Meeting{}, err
 	}
+	before := meeting
 
 	if meeting.MeetingOver {
 		return models.Meeting{}, errors.New("attendance cannot be started after meeting has ended")
@@ -120,8 +252,17 @@ func (ms *MeetingService) StartAttendance(meetingID uint, teamID uint) (models.M
 		return models.Meeting{}, errors.New("attendance cannot be started before meeting has started")
 	}
 
+	// a new secret on every start invalidates codes from an earlier attendance period
+	secret, err := generateAttendanceSecret()
+	if err != nil {
+		return models.Meeting{}, err
+	}
+
+	now := time.Now()
 	meeting.AttendancePeriod = true
 	meeting.AttendanceOver = false
+	meeting.AttendanceSecret = secret
+	meeting.AttendanceStartedAt = &now
 
 	// Update the meeting in the database
 	updatedMeeting, err := ms.meetingRepo.UpdateMeeting(meeting)
@@ -129,15 +270,18 @@ func (ms *MeetingService) StartAttendance(meetingID uint, teamID uint) (models.M
 		return models.Meeting{}, err
 	}
 
+	ms.auditLogService.Record(actor, teamID, models.AuditAttendanceStart, models.AuditTargetMeeting, meetingID, before, updatedMeeting)
+
 	return updatedMeeting, nil
 }
 
 // EndAttendance ends attendance for a meeting by setting AttendancePeriod to false.
-func (ms *MeetingService) EndAttendance(meetingID uint, teamID uint) (models.Meeting, error) {
+func (ms *MeetingService) EndAttendance(actor models.AuditActor, meetingID uint, teamID uint) (models.Meeting, error) {
 	meeting, err := ms.GetMeetingByID(meetingID, teamID)
 	if err != nil {
 		return models.Meeting{}, err
 	}
+	before := meeting
 
 	// // cannot end attendance period before starting it
 	// if !meeting.AttendancePeriod {
@@ -154,15 +298,18 @@ func (ms *MeetingService) EndAttendance(meetingID uint, teamID uint) (models.Mee
 		return models.Meeting{}, err
 	}
 
+	ms.auditLogService.Record(actor, teamID, models.AuditAttendanceEnd, models.AuditTargetMeeting, meetingID, before, updatedMeeting)
+
 	return updatedMeeting, nil
 }
 
 // EndMeeting ends a meeting by setting MeetingOver to true.
-func (ms *MeetingService) EndMeeting(meetingID uint, teamID uint) (models.Meeting, error) {
+func (ms *MeetingService) EndMeeting(actor models.AuditActor, meetingID uint, teamID uint) (models.Meeting, error) {
 	meeting, err := ms.GetMeetingByID(meetingID, teamID)
 	if err != nil {
 		return models.Meeting{}, err
 	}
+	before := meeting
 
 	// If attendance period is still open, close it
 	meeting.AttendancePeriod = false
@@ -177,11 +324,89 @@ func (ms *MeetingService) EndMeeting(meetingID uint, teamID uint) (models.Meetin
 		return models.Meeting{}, err
 	}
 
+	ms.auditLogService.Record(actor, teamID, models.AuditMeetingEnd, models.AuditTargetMeeting, meetingID, before, updatedMeeting)
+
 	return updatedMeeting, nil
 }
 
+// RunScheduledTransitions starts and ends the scheduled meetings and attendance windows that are due at now. Meant to be run periodically.
+// Transitions missed while no server was running are caught up on in order. Each one is saved only if no other replica did it first,
+// and an admin did not start or end the meeting by hand since it was read.
+func (ms *MeetingService) RunScheduledTransitions(now time.Time) error {
+	meetings, err := ms.meetingRepo.GetMeetingsWithPendingTransitions(now)
+	if err != nil {
+		return err
+	}
+
+	for _, meeting := range meetings {
+		for {
+			next, at, ok := meeting.NextScheduledTransition()
+			if !ok || at.After(now) {
+				break
+			}
+			before := meeting
+			if err := ms.applyScheduledTransition(&meeting, next, now); err != nil {
+				logger.Errorf("Error in scheduled transition of meeting %d to %s: %v", meeting.ID, next, err)
+				break
+			}
+
+			meeting.Schedule.State = next
+			done, err := ms.meetingRepo.TransitionMeeting(meeting, before)
+			if err != nil {
+				logger.Errorf("Error in scheduled transition of meeting %d to %s: %v", meeting.ID, next, err)
+				break
+			}
+			if !done {
+				// another replica got to it first
+				break
+			}
+			ms.auditLogService.Record(models.AuditActor{}, meeting.TeamID, scheduledTransitionActions[next], models.AuditTargetMeeting, meeting.ID, before, meeting)
+		}
+	}
+
+	return nil
+}
+
+// scheduledTransitionActions are the audit log actions of scheduled transitions, recorded as done by the app.
+var scheduledTransitionActions = map[string]string{
+	models.ScheduleStateStarted:         models.AuditMeetingStart,
+	models.ScheduleStateAttendanceEnded: models.AuditAttendanceEnd,
+	models.ScheduleStateEnded:           models.AuditMeetingEnd,
+}
+
+// applyScheduledTransition changes the periods of the meeting for a scheduled transition, leaving alone what an admin already did by hand.
+func (ms *MeetingService) applyScheduledTransition(meeting *models.Meeting, transition string, now time.Time) error {
+	switch transition {
+	case models.ScheduleStateStarted:
+		meeting.MeetingPeriod = true
+		attendanceEnd := meeting.StartTime.Add(time.Duration(meeting.Schedule.AttendanceMinutes) * time.Minute)
+		if meeting.Schedule.AttendanceMinutes > 0 && !meeting.AttendancePeriod && !meeting.AttendanceOver && now.Before(attendanceEnd) {
+			secret, err := generateAttendanceSecret()
+			if err != nil {
+				return err
+			}
+			// the window is counted from StartTime, even when caught up on later
+			attendanceStart := meeting.StartTime
+			meeting.AttendancePeriod = true
+			meeting.AttendanceSecret = secret
+			meeting.AttendanceStartedAt = &attendanceStart
+		}
+	case models.ScheduleStateAttendanceEnded:
+		if meeting.AttendancePeriod {
+			meeting.AttendancePeriod = false
+			meeting.AttendanceOver = true
+		}
+	case models.ScheduleStateEnded:
+		meeting.AttendancePeriod = false
+		meeting.AttendanceOver = true
+		meeting.MeetingPeriod = false
+		meeting.MeetingOver = true
+	}
+	return nil
+}
+
 // DeleteMeetingByID deletes a meeting by its ID.
-func (ms *MeetingService) DeleteMeetingByID(meetingID uint, teamID uint) error {
+func (ms *MeetingService) DeleteMeetingByID(actor models.AuditActor, meetingID uint, teamID uint) error {
 	// A meeting can only be deleted if MeetingPeriod = false and AttendancePeriod = false and MeetingOver = false. I.e., meeting hasn't started yet.
 
 	meeting, err := ms.GetMeetingByID(meetingID, teamID)
@@ -193,7 +418,12 @@ func (ms *MeetingService) DeleteMeetingByID(meetingID uint, teamID uint) error {
 		return errors.New("meeting cannot be deleted after it has started or finished")
 	}
 
-	return ms.meetingRepo.DeleteMeetingByID(meetingID)
+	if err := ms.meetingRepo.DeleteMeetingByID(meetingID); err != nil {
+		return err
+	}
+
+	ms.auditLogService.Record(actor, teamID, models.AuditMeetingDelete, models.AuditTargetMeeting, meetingID, meeting, nil)
+	return nil
 }
 
 // GetMeetingsByTeamID retrieves meetings for a team based on filters.
@@ -234,50 +464,253 @@ func (ms *MeetingService) GetMeetingsByTeamID(teamID uint, filterBy string, orde
 	return meetings, nil
 }
 
-// MarkAttendaceForUserInMeeting marks attendance for a user in a meeting. Returns onTime bool.
-func (ms *MeetingService) MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamID uint) (bool, error) {
+// GetAttendanceCode returns the current attendance code of a meeting, which members must submit to mark attendance.
+func (ms *MeetingService) GetAttendanceCode(meetingID, teamID uint, now time.Time) (models.AttendanceCodeResponse, error) {
+	meeting, err := ms.GetMeetingByID(meetingID, teamID)
+	if err != nil {
+		return models.AttendanceCodeResponse{}, err
+	}
+
+	if meeting.MeetingOver {
+		return models.AttendanceCodeResponse{}, errors.New("meeting is over")
+	}
+
+	if !meeting.AttendancePeriod && !meeting.AttendanceOver {
+		return models.AttendanceCodeResponse{}, errors.New("attendance not started")
+	}
+
+	secret, err := ms.attendanceSecret(meeting)
+	if err != nil {
+		return models.AttendanceCodeResponse{}, err
+	}
+
+	opts := attendanceCodeOptions()
+	code, err := totp.GenerateCode(secret, now, opts)
+	if err != nil {
+		return models.AttendanceCodeResponse{}, err
+	}
+
+	return models.AttendanceCodeResponse{
+		Code:      code,
+		Period:    int(opts.Period / time.Second),
+		ExpiresAt: totp.ExpiresAt(now, opts),
+	}, nil
+}
+
+// attendanceSecret returns the attendance secret of a started meeting. Meetings whose attendance was started before codes existed have none,
+// so one is generated and saved on first use.
+func (ms *MeetingService) attendanceSecret(meeting models.Meeting) (string, error) {
+	if meeting.AttendanceSecret != "" {
+		return meeting.AttendanceSecret, nil
+	}
+	secret, err := generateAttendanceSecret()
+	if err != nil {
+		return "", err
+	}
+	return ms.meetingRepo.EnsureAttendanceSecret(meeting.ID, secret)
+}
+
+// checkGeofence verifies that the reported position lies within the meeting's geofence, and returns the distance from the meeting location.
+// Position is optional for meetings without a geofence; if given, its distance is still computed for auditing.
+func checkGeofence(meeting models.Meeting, position *models.ReportedLocation) (float64, error) {
+	if position == nil {
+		if meeting.Geofence.Radius > 0 {
+			return 0, ErrLocationRequired
+		}
+		return 0, nil
+	}
+
+	distance := geo.Distance(meeting.Location.Latitude, meeting.Location.Longitude, position.Latitude, position.Longitude)
+	if meeting.Geofence.Radius == 0 {
+		return distance, nil
+	}
+
+	// a fix less precise than the geofence itself cannot place the member inside it, and one without accuracy is not trusted
+	if position.Accuracy <= 0 || position.Accuracy > meeting.Geofence.Radius {
+		return distance, ErrLocationInaccurate
+	}
+
+	if distance > meeting.Geofence.Radius {
+		return distance, ErrOutsideGeofence
+	}
+
+	if meeting.Geofence.AltitudeTolerance > 0 && math.Abs(position.Altitude-meeting.Location.Altitude) > meeting.Geofence.AltitudeTolerance {
+		return distance, ErrOutsideAltitudeGeofence
+	}
+
+	return distance, nil
+}
+
+// MarkAttendaceForUserInMeeting marks attendance for a user in a meeting. Returns the attendance record, with its status under the attendance policy.
+func (ms *MeetingService) MarkAttendanceForUserInMeeting(userID, meetingID uint, attendanceTime time.Time, teamID uint, code string, position *models.ReportedLocation) (models.MeetingAttendance, error) {
 	// If meeting not started or meeting over, return error
 	meeting, err := ms.GetMeetingByID(meetingID, teamID)
 	if err != nil {
-		return false, err
+		return models.MeetingAttendance{}, err
 	}
 
 	if !meeting.MeetingPeriod || meeting.MeetingOver {
-		return false, errors.New("meeting not started or meeting over")
+		return models.MeetingAttendance{}, ErrMeetingNotOpen
 	}
 
 	// If meeting started but attendance not started (ie, not attendance period, and not attendance ended), return error
 	if !meeting.AttendancePeriod && meeting.MeetingPeriod && !meeting.AttendanceOver {
-		return false, errors.New("attendance not started")
+		return models.MeetingAttendance{}, ErrAttendanceNotOpen
+	}
+
+	secret, err := ms.attendanceSecret(meeting)
+	if err != nil {
+		return models.MeetingAttendance{}, err
+	}
+
+	if !totp.ValidateCode(code, secret, attendanceTime, attendanceCodeOptions()) {
+		return models.MeetingAttendance{}, ErrInvalidAttendanceCode
 	}
 
 	// check if attendance record for user and meeting exists. If it does, return error.
 	_, err = ms.meetingRepo.GetMeetingAttendanceByUserIDAndMeetingID(userID, meetingID)
 	if err == nil {
 		// attendance record exists
-		return false, errors.New("attendance already marked")
+		return models.MeetingAttendance{}, ErrAttendanceMarked
 	}
 
-	var onTime bool
-	// if attendance period ended (but meeting period still on), mark attendance as late
-	if meeting.AttendanceOver && meeting.MeetingPeriod {
-		onTime = false
-	} else {
-		onTime = true
+	distance, err := checkGeofence(meeting, position)
+	if err != nil {
+		return models.MeetingAttendance{}, err
 	}
 
+	policy, err := ms.getAttendancePolicy(meeting)
+	if err != nil {
+		return models.MeetingAttendance{}, err
+	}
+	// meetings whose attendance was started before the start was recorded count from StartTime
+	attendanceStart := meeting.StartTime
+	if meeting.AttendanceStartedAt != nil {
+		attendanceStart = *meeting.AttendanceStartedAt
+	}
+	// if attendance period ended (but meeting period still on), attendance is late at best
+	status, minutesLate := policy.Evaluate(attendanceStart, attendanceTime, !meeting.AttendanceOver)
+
 	meetingAttendance := models.MeetingAttendance{
 		UserID:             userID,
 		MeetingID:          meetingID,
 		AttendanceMarkedAt: attendanceTime,
-		OnTime:             onTime,
+		OnTime:             status == models.AttendanceStatusOnTime,
+		Status:             status,
+		MinutesLate:        minutesLate,
+		Distance:           distance,
+	}
+	if position != nil {
+		meetingAttendance.MarkedLocation = position.Location
+		meetingAttendance.Accuracy = position.Accuracy
 	}
 
 	if err := ms.meetingRepo.AddMeetingAttendance(meetingAttendance); err != nil {
-		return false, err
+		return models.MeetingAttendance{}, err
+	}
+
+	return meetingAttendance, nil
+}
+
+// getAttendancePolicy returns the attendance policy of the meeting if it has one enabled, else that of its team.
+func (ms *MeetingService) getAttendancePolicy(meeting models.Meeting) (models.AttendancePolicy, error) {
+	if meeting.AttendancePolicy.Enabled {
+		return meeting.AttendancePolicy, nil
+	}
+	team, err := ms.teamRepo.GetTeamByID(meeting.TeamID)
+	if err != nil {
+		return models.AttendancePolicy{}, err
+	}
+	return team.AttendancePolicy, nil
+}
+
+// OverrideAttendance lets an admin mark a team member present (onTime = true) or late (onTime = false) in a meeting that has started.
+// If the member already has an attendance record, only its OnTime and Status are changed. The acting admin, reason and time are recorded on the row.
+func (ms *MeetingService) OverrideAttendance(actor models.AuditActor, userID, meetingID, teamID uint, onTime bool, reason string, now time.Time) (models.MeetingAttendance, error) {
+	meeting, err := ms.GetMeetingByID(meetingID, teamID)
+	if err != nil {
+		return models.MeetingAttendance{}, err
+	}
+
+	if !meeting.MeetingPeriod && !meeting.MeetingOver {
+		return models.MeetingAttendance{}, errors.New("attendance cannot be changed before meeting has started")
+	}
+
+	if reason == "" {
+		return models.MeetingAttendance{}, errors.New("reason is required to change attendance")
+	}
+
+	// only members of the team can have attendance in its meetings
+	if _, err := ms.teamMemberRepo.GetTeamMemberByID(teamID, userID); err != nil {
+		return models.MeetingAttendance{}, errors.New("user is not a member of the team")
 	}
 
-	return meetingAttendance.OnTime, nil
+	status := models.AttendanceStatusLate
+	if onTime {
+		status = models.AttendanceStatusOnTime
+	}
+
+	attendance, err := ms.meetingRepo.GetMeetingAttendanceByUserIDAndMeetingID(userID, meetingID)
+	if err != nil {
+		// no record yet, create one on behalf of the member
+		attendance = models.MeetingAttendance{
+			UserID:             userID,
+			MeetingID:          meetingID,
+			AttendanceMarkedAt: now,
+			OnTime:             onTime,
+			Status:             status,
+			OverriddenByID:     actor.UserID,
+			OverrideReason:     reason,
+			OverriddenAt:       &now,
+		}
+		if err := ms.meetingRepo.AddMeetingAttendance(attendance); err != nil {
+			return models.MeetingAttendance{}, err
+		}
+		ms.auditLogService.Record(actor, teamID, models.AuditAttendanceOverride, models.AuditTargetAttendance, userID, nil, attendance)
+		return attendance, nil
+	}
+
+	before := attendance
+	attendance.OnTime = onTime
+	attendance.Status = status
+	attendance.OverriddenByID = actor.UserID
+	attendance.OverrideReason = reason
+	attendance.OverriddenAt = &now
+
+	updated, err := ms.meetingRepo.UpdateMeetingAttendance(attendance)
+	if err != nil {
+		return models.MeetingAttendance{}, err
+	}
+	ms.auditLogService.Record(actor, teamID, models.AuditAttendanceOverride, models.AuditTargetAttendance, userID, before, updated)
+	return updated, nil
+}
+
+// RemoveAttendance lets an admin remove a member's attendance record. The record is soft deleted with the acting admin, reason and time on it.
+func (ms *MeetingService) RemoveAttendance(actor models.AuditActor, userID, meetingID, teamID uint, reason string, now time.Time) error {
+	if _, err := ms.GetMeetingByID(meetingID, teamID); err != nil {
+		return err
+	}
+
+	if reason == "" {
+		return errors.New("reason is required to change attendance")
+	}
+
+	attendance, err := ms.meetingRepo.GetMeetingAttendanceByUserIDAndMeetingID(userID, meetingID)
+	if err != nil {
+		return errors.New("attendance record not found")
+	}
+
+	before := attendance
+	attendance.OverriddenByID = actor.UserID
+	attendance.OverrideReason = reason
+	attendance.OverriddenAt = &now
+
+	if err := ms.meetingRepo.DeleteMeetingAttendance(attendance); err != nil {
+		return err
+	}
+
+	ms.auditLogService.Record(actor, teamID, models.AuditAttendanceRemove, models.AuditTargetAttendance, userID, before, nil)
+	return nil
 }
 
 // GetAttendanceForMeeting retrieves attendance for a meeting.
@@ -303,6 +736,14 @@ func (ms *MeetingService) GetAttendanceForMeeting(meetingID, teamID uint) ([]mod
 			MeetingID:          attendanceRecord.MeetingID,
 			AttendanceMarkedAt: attendanceRecord.AttendanceMarkedAt,
 			OnTime:             attendanceRecord.OnTime,
+			Status:             attendanceRecord.AttendanceStatus(),
+			MinutesLate:        attendanceRecord.MinutesLate,
+			MarkedLocation:     attendanceRecord.MarkedLocation,
+			Accuracy:           attendanceRecord.Accuracy,
+			Distance:           attendanceRecord.Distance,
+			OverriddenByID:     attendanceRecord.OverriddenByID,
+			OverrideReason:     attendanceRecord.OverrideReason,
+			OverriddenAt:       attendanceRecord.OverriddenAt,
 			User:               user,
 		})
 	}
@@ -350,12 +791,21 @@ func (ms *MeetingService) UpcomingUserMeetings(userID uint) ([]models.UserUpcomi
 }
 
 // GetFullUserAttendanceRecord retrieves all attendance records for a user across meetings and teams.
+// Meetings the user did not attend with an approved leave request are listed as excused, without an attendance record.
 func (ms *MeetingService) GetFullUserAttendanceRecord(userID uint) ([]models.MeetingAttendanceListResponse, error) {
 	// Get all attendance records for the user
 	attendance, err := ms.meetingRepo.GetMeetingAttendancesByUserID(userID)
 	if err != nil {
 		return nil, err
 	}
+	leaves, err := ms.leaveRequestRepo.GetApprovedLeaveRequestsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	excused := make(map[uint]bool, len(leaves))
+	for _, leave := range leaves {
+		excused[leave.MeetingID] = true
+	}
 
 	// Get meeting details for each attendance record, and make array of MeetingAttendanceResponse
 	var attendanceResponse []models.MeetingAttendanceListResponse
@@ -368,18 +818,158 @@ func (ms *MeetingService) GetFullUserAttendanceRecord(userID uint) ([]models.Mee
 		if err != nil {
 			return nil, err
 		}
+		status := attendanceRecord.AttendanceStatus()
+		// marked too late to count, but excused
+		if status == models.AttendanceStatusAbsent && excused[attendanceRecord.MeetingID] {
+			status = models.AttendanceStatusExcused
+		}
+		delete(excused, attendanceRecord.MeetingID)
 		attendanceResponse = append(attendanceResponse, models.MeetingAttendanceListResponse{
 			ID:                 attendanceRecord.ID,
 			MeetingID:          attendanceRecord.MeetingID,
 			AttendanceMarkedAt: attendanceRecord.AttendanceMarkedAt,
 			OnTime:             attendanceRecord.OnTime,
+			Status:             status,
+			MinutesLate:        attendanceRecord.MinutesLate,
 			User:               models.User{},
 			TeamName:           team.Name,
 			MeetingName:        meeting.Title,
 		})
 	}
 
+	// meetings the user was excused from and did not attend
+	for _, leave := range leaves {
+		if !excused[leave.MeetingID] {
+			continue
+		}
+		meeting, err := ms.meetingRepo.GetMeetingByID(leave.MeetingID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// meeting deleted since the leave was approved
+				continue
+			}
+			return nil, err
+		}
+		team, err := ms.teamRepo.GetTeamByID(meeting.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		attendanceResponse = append(attendanceResponse, models.MeetingAttendanceListResponse{
+			MeetingID:   leave.MeetingID,
+			Status:      models.AttendanceStatusExcused,
+			User:        models.User{},
+			TeamName:    team.Name,
+			MeetingName: meeting.Title,
+		})
+	}
+
 	return attendanceResponse, nil
 }
 
-// GetMeetingStatsByMeetingID retrieves meeting stats for a given meeting ID. Stats: total attendance, on time attendance, late attendance.
+// GetMeetingSummary retrieves who attended a meeting on time, late, or not at all, and who was excused by an approved leave request.
+// Absentees are computed against the team members as of the meeting's StartTime, so members who joined later are not counted absent,
+// unless an absence was recorded for them, e.g. by an import of the team's history.
+func (ms *MeetingService) GetMeetingSummary(meetingID, teamID uint) (models.MeetingSummaryResponse, error) {
+	meeting, err := ms.GetMeetingByID(meetingID, teamID)
+	if err != nil {
+		return models.MeetingSummaryResponse{}, err
+	}
+	team, err := ms.teamRepo.GetTeamByID(teamID)
+	if err != nil {
+		return models.MeetingSummaryResponse{}, err
+	}
+	attendance, err := ms.GetAttendanceForMeeting(meetingID, teamID)
+	if err != nil {
+		return models.MeetingSummaryResponse{}, err
+	}
+	members, err := ms.teamMemberRepo.GetTeamMembersByTeamIDAsOf(teamID, meeting.StartTime)
+	if err != nil {
+		return models.MeetingSummaryResponse{}, err
+	}
+	leaves, err := ms.leaveRequestRepo.GetApprovedLeaveRequestsByMeetingID(meetingID)
+	if err != nil {
+		return models.MeetingSummaryResponse{}, err
+	}
+	excused := make(map[uint]bool, len(leaves))
+	for _, leave := range leaves {
+		excused[leave.UserID] = true
+	}
+
+	summary := models.MeetingSummaryResponse{
+		MeetingID:   meeting.ID,
+		MeetingName: meeting.Title,
+		TeamName:    team.Name,
+		Present:     []models.MeetingAttendanceListResponse{},
+		OnTime:      []models.MeetingAttendanceListResponse{},
+		Late:        []models.MeetingAttendanceListResponse{},
+		Absent:      []models.User{},
+		Excused:     []models.User{},
+	}
+
+	present := make(map[uint]bool)
+	// recorded absences, of members who may have joined after the meeting
+	var recordedAbsent []models.User
+	for _, record := range attendance {
+		switch record.Status {
+		case models.AttendanceStatusAbsent:
+			// marked too late or imported, listed with the other absentees
+			recordedAbsent = append(recordedAbsent, record.User)
+			continue
+		case models.AttendanceStatusOnTime:
+			summary.OnTime = append(summary.OnTime, record)
+		default:
+			summary.Late = append(summary.Late, record)
+		}
+		present[record.User.ID] = true
+		summary.Present = append(summary.Present, record)
+	}
+
+	// a member who left and rejoined before the meeting has more than one membership
+	expected := make(map[uint]bool)
+	for _, member := range members {
+		if expected[member.UserID] {
+			continue
+		}
+		expected[member.UserID] = true
+		if present[member.UserID] {
+			continue
+		}
+		user, err := ms.userRepo.GetUserByID(member.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// account deleted since the meeting
+				continue
+			}
+			return models.MeetingSummaryResponse{}, err
+		}
+		if excused[member.UserID] {
+			summary.Excused = append(summary.Excused, user)
+			continue
+		}
+		summary.Absent = append(summary.Absent, user)
+	}
+	for _, user := range recordedAbsent {
+		if expected[user.ID] {
+			continue
+		}
+		expected[user.ID] = true
+		if excused[user.ID] {
+			summary.Excused = append(summary.Excused, user)
+			continue
+		}
+		summary.Absent = append(summary.Absent, user)
+	}
+	// attendance imported for members who joined later
+	for userID := range present {
+		expected[userID] = true
+	}
+
+	summary.Expected = len(expected)
+	summary.PresentCount = len(summary.Present)
+	summary.OnTimeCount = len(summary.OnTime)
+	summary.LateCount = len(summary.Late)
+	summary.AbsentCount = len(summary.Absent)
+	summary.ExcusedCount = len(summary.Excused)
+
+	return summary, nil
+}
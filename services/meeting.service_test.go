@@ -8,10 +8,33 @@ import (
 
 	"github.com/GDGVIT/attendance-app-backend/mocks"
 	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/geo"
+	"github.com/GDGVIT/attendance-app-backend/utils/totp"
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
+// newAuditLogServiceMock returns an audit log service that accepts any record.
+func newAuditLogServiceMock(ctrl *gomock.Controller) *mocks.MockAuditLogService {
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	mockAuditLogService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	return mockAuditLogService
+}
+
+// testAttendanceSecret is the attendance secret of started meetings in tests.
+const testAttendanceSecret = "JBSWY3DPEHPK3PXP"
+
+// attendanceCodeAt returns the attendance code of testAttendanceSecret at t.
+func attendanceCodeAt(t *testing.T, at time.Time) string {
+	code, err := totp.GenerateCode(testAttendanceSecret, at, attendanceCodeOptions())
+	if err != nil {
+		t.Fatalf("Failed to generate the attendance code: %v", err)
+	}
+	return code
+}
+
 func TestMeetingService_CreateMeeting(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -21,7 +44,7 @@ func TestMeetingService_CreateMeeting(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	service := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	service := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	// Mock Repository Call
 	meeting := models.Meeting{
@@ -34,7 +57,8 @@ func TestMeetingService_CreateMeeting(t *testing.T) {
 			Longitude: 20.0,
 			Altitude:  30.0,
 		},
-		StartTime: time.Now(),
+		AttendancePolicy: models.AttendancePolicy{Enabled: true, GraceMinutes: 5, LateMinutes: 15},
+		StartTime:        time.Now(),
 	}
 
 	// continue
@@ -44,7 +68,7 @@ func TestMeetingService_CreateMeeting(t *testing.T) {
 	mockEmailService.EXPECT().SendMeetingNotification(meeting.TeamID, meeting).Return(nil)
 
 	// Call the service
-	createdMeeting, err := service.CreateMeeting(meeting.TeamID, meeting.Title, meeting.Description, meeting.Venue, meeting.Location, meeting.StartTime)
+	createdMeeting, err := service.CreateMeeting(models.AuditActor{UserID: 9}, meeting.TeamID, meeting.Title, meeting.Description, meeting.Venue, meeting.Location, meeting.Geofence, meeting.Schedule, meeting.AttendancePolicy, meeting.StartTime)
 
 	// Assert the response for the passing case
 	assert.NoError(t, err)
@@ -68,7 +92,7 @@ func TestMeetingService_CreateMeeting(t *testing.T) {
 	mockRepo.EXPECT().CreateMeeting(failingMeeting).Return(failingMeeting, errors.New("")).Times(1)
 
 	// Call the service for the failing case
-	failingCreatedMeeting, failingErr := service.CreateMeeting(failingMeeting.TeamID, failingMeeting.Title, failingMeeting.Description, failingMeeting.Venue, failingMeeting.Location, failingMeeting.StartTime)
+	failingCreatedMeeting, failingErr := service.CreateMeeting(models.AuditActor{UserID: 9}, failingMeeting.TeamID, failingMeeting.Title, failingMeeting.Description, failingMeeting.Venue, failingMeeting.Location, failingMeeting.Geofence, failingMeeting.Schedule, failingMeeting.AttendancePolicy, failingMeeting.StartTime)
 
 	println(failingErr.Error())
 
@@ -90,7 +114,7 @@ func TestMeetingService_GetMeetingsByTeamIDAndMeetingOver(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	service := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	service := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	// Define test data
 	teamID := uint(1)
@@ -153,7 +177,7 @@ func TestMeetingService_StartMeeting(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	// TC1
 
@@ -171,7 +195,7 @@ func TestMeetingService_StartMeeting(t *testing.T) {
 	mockRepo.EXPECT().GetMeetingByID(meetingID).Return(mockMeeting, nil)
 
 	// Call the StartMeeting function
-	_, err := meetingService.StartMeeting(meetingID, 1)
+	_, err := meetingService.StartMeeting(models.AuditActor{UserID: 9}, meetingID, 1)
 
 	// Assert that an error is returned since MeetingOver is true
 	if err == nil {
@@ -199,7 +223,7 @@ func TestMeetingService_StartMeeting(t *testing.T) {
 	mockRepo.EXPECT().UpdateMeeting(mockMeeting2).Return(mockMeeting2, nil)
 
 	// Call the StartMeeting function
-	startedMeeting, err := meetingService.StartMeeting(meetingID2, 1)
+	startedMeeting, err := meetingService.StartMeeting(models.AuditActor{UserID: 9}, meetingID2, 1)
 
 	// Assert that no error is returned since MeetingOver is false
 	if err != nil {
@@ -225,7 +249,11 @@ func TestMeetingService_StartAttendance(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	// Use a fixed attendance code secret
+	generateAttendanceSecret = func() (string, error) { return testAttendanceSecret, nil }
+	defer func() { generateAttendanceSecret = totp.GenerateSecret }()
 
 	testCases := []struct {
 		name          string
@@ -288,14 +316,21 @@ func TestMeetingService_StartAttendance(t *testing.T) {
 
 				tc.mockMeeting.AttendancePeriod = true
 				tc.mockMeeting.AttendanceOver = false
+				tc.mockMeeting.AttendanceSecret = testAttendanceSecret
 
-				// Mock the UpdateMeeting function to return the mock meeting
-				mockRepo.EXPECT().UpdateMeeting(tc.mockMeeting).Return(tc.mockMeeting, nil)
+				// Mock the UpdateMeeting function to return the mock meeting, the start of attendance is recorded at the current time
+				mockRepo.EXPECT().UpdateMeeting(gomock.Any()).DoAndReturn(func(meeting models.Meeting) (models.Meeting, error) {
+					assert.NotNil(t, meeting.AttendanceStartedAt)
+					assert.WithinDuration(t, time.Now(), *meeting.AttendanceStartedAt, time.Minute)
+					meeting.AttendanceStartedAt = nil
+					assert.Equal(t, tc.mockMeeting, meeting)
+					return meeting, nil
+				})
 
 			}
 
 			// Call the StartMeeting function
-			returnedMockMeeting, err := meetingService.StartAttendance(tc.meetingID, 1)
+			returnedMockMeeting, err := meetingService.StartAttendance(models.AuditActor{UserID: 9}, tc.meetingID, 1)
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -320,7 +355,7 @@ func TestMeetingService_EndAttendance(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	testCases := []struct {
 		name          string
@@ -354,7 +389,7 @@ func TestMeetingService_EndAttendance(t *testing.T) {
 			mockRepo.EXPECT().UpdateMeeting(tc.mockMeeting).Return(tc.mockMeeting, nil)
 
 			// Call the StartMeeting function
-			returnedMockMeeting, err := meetingService.EndAttendance(tc.meetingID, 1)
+			returnedMockMeeting, err := meetingService.EndAttendance(models.AuditActor{UserID: 9}, tc.meetingID, 1)
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -375,7 +410,7 @@ func TestMeetingService_EndMeeting(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	testCases := []struct {
 		name              string
@@ -410,7 +445,7 @@ func TestMeetingService_EndMeeting(t *testing.T) {
 			mockRepo.EXPECT().GetMeetingByID(tc.meetingID).Return(tc.mockMeeting, nil)
 			mockRepo.EXPECT().UpdateMeeting(tc.mockUpdateMeeting).Return(tc.mockUpdateMeeting, nil)
 
-			updatedMeeting, err := meetingService.EndMeeting(tc.meetingID, 1)
+			updatedMeeting, err := meetingService.EndMeeting(models.AuditActor{UserID: 9}, tc.meetingID, 1)
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -435,7 +470,7 @@ func TestMeetingService_DeleteMeetingByID(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	testCases := []struct {
 		name          string
@@ -502,7 +537,7 @@ func TestMeetingService_DeleteMeetingByID(t *testing.T) {
 				mockRepo.EXPECT().DeleteMeetingByID(tc.meetingID).Return(nil)
 			}
 
-			err := meetingService.DeleteMeetingByID(tc.meetingID, 1)
+			err := meetingService.DeleteMeetingByID(models.AuditActor{UserID: 9}, tc.meetingID, 1)
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -526,7 +561,7 @@ func TestMeetingService_MarkAttendanceForUserInMeeting(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	testCases := []struct {
 		name             string
@@ -536,6 +571,8 @@ func TestMeetingService_MarkAttendanceForUserInMeeting(t *testing.T) {
 		mockMeeting      models.Meeting
 		expectedError    bool
 		expectedOnTime   bool
+		expectedStatus   string
+		expectedLate     uint
 		expectedNumCalls int
 	}{
 		{
@@ -596,6 +633,7 @@ func TestMeetingService_MarkAttendanceForUserInMeeting(t *testing.T) {
 			},
 			expectedError:    false,
 			expectedOnTime:   true,
+			expectedStatus:   models.AttendanceStatusOnTime,
 			expectedNumCalls: 1,
 		},
 		{
@@ -608,10 +646,13 @@ func TestMeetingService_MarkAttendanceForUserInMeeting(t *testing.T) {
 				MeetingOver:      false,
 				AttendancePeriod: false,
 				AttendanceOver:   true,
+				StartTime:        time.Now().Add(-5*time.Minute - time.Second),
 				TeamID:           1,
 			},
 			expectedError:    false,
 			expectedOnTime:   false,
+			expectedStatus:   models.AttendanceStatusLate,
+			expectedLate:     5,
 			expectedNumCalls: 1,
 		},
 	}
@@ -619,25 +660,30 @@ func TestMeetingService_MarkAttendanceForUserInMeeting(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Mock the repos's GetMeetingByID function to return the mock meeting
+			tc.mockMeeting.AttendanceSecret = testAttendanceSecret
 			mockRepo.EXPECT().GetMeetingByID(tc.meetingID).Return(tc.mockMeeting, nil)
 
 			// If the function is expected to succeed, we should create a mock MeetingAttendance.
 			if !tc.expectedError {
 				// mock repos get attendance by user id and meeting id
 				mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(tc.userID, tc.meetingID).Return(models.MeetingAttendance{}, errors.New("existing attendance not found"))
+				// the team has no attendance policy
+				mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{}, nil)
 
 				mockAttendance := models.MeetingAttendance{
 					MeetingID:          tc.meetingID,
 					UserID:             tc.userID,
 					AttendanceMarkedAt: tc.attendanceTime,
 					OnTime:             tc.expectedOnTime,
+					Status:             tc.expectedStatus,
+					MinutesLate:        tc.expectedLate,
 				}
 
 				// Mock the repos's AddMeetingAttendance function to return the mock MeetingAttendance
 				mockRepo.EXPECT().AddMeetingAttendance(mockAttendance).Return(nil)
 			}
 
-			_, err := meetingService.MarkAttendanceForUserInMeeting(tc.userID, tc.meetingID, tc.attendanceTime, 1)
+			_, err := meetingService.MarkAttendanceForUserInMeeting(tc.userID, tc.meetingID, tc.attendanceTime, 1, attendanceCodeAt(t, tc.attendanceTime), nil)
 
 			// Assert the error based on the expected result
 			if tc.expectedError {
@@ -649,6 +695,553 @@ func TestMeetingService_MarkAttendanceForUserInMeeting(t *testing.T) {
 	}
 }
 
+func TestMeetingService_MarkAttendanceForUserInMeeting_Geofence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	// meeting with a 50m geofence, and 4m altitude tolerance
+	geofencedMeeting := models.Meeting{
+		TeamID:           1,
+		MeetingPeriod:    true,
+		AttendancePeriod: true,
+		AttendanceSecret: testAttendanceSecret,
+		Location: models.Location{
+			Latitude:  12.969,
+			Longitude: 79.155,
+			Altitude:  10,
+		},
+		Geofence: models.Geofence{
+			Radius:            50,
+			AltitudeTolerance: 4,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		position      *models.ReportedLocation
+		expectedError bool
+	}{
+		{
+			name:          "Missing_location",
+			position:      nil,
+			expectedError: true,
+		},
+		{
+			name:          "Inside_geofence",
+			position:      &models.ReportedLocation{Location: models.Location{Latitude: 12.9692, Longitude: 79.155, Altitude: 12}, Accuracy: 10},
+			expectedError: false,
+		},
+		{
+			name:          "Outside_geofence",
+			position:      &models.ReportedLocation{Location: models.Location{Latitude: 12.975, Longitude: 79.155, Altitude: 10}, Accuracy: 10},
+			expectedError: true,
+		},
+		{
+			name:          "Accuracy_too_low",
+			position:      &models.ReportedLocation{Location: models.Location{Latitude: 12.969, Longitude: 79.155, Altitude: 10}, Accuracy: 500},
+			expectedError: true,
+		},
+		{
+			name:          "Accuracy_missing",
+			position:      &models.ReportedLocation{Location: models.Location{Latitude: 12.969, Longitude: 79.155, Altitude: 10}},
+			expectedError: true,
+		},
+		{
+			name:          "Wrong_floor",
+			position:      &models.ReportedLocation{Location: models.Location{Latitude: 12.969, Longitude: 79.155, Altitude: 30}, Accuracy: 10},
+			expectedError: true,
+		},
+	}
+
+	attendanceTime := time.Now()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(geofencedMeeting, nil)
+			mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(1), uint(1)).Return(models.MeetingAttendance{}, errors.New("existing attendance not found"))
+
+			if !tc.expectedError {
+				mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{}, nil)
+				// submitted position and computed distance are stored for audits
+				mockRepo.EXPECT().AddMeetingAttendance(models.MeetingAttendance{
+					UserID:             1,
+					MeetingID:          1,
+					AttendanceMarkedAt: attendanceTime,
+					OnTime:             true,
+					Status:             models.AttendanceStatusOnTime,
+					MarkedLocation:     tc.position.Location,
+					Accuracy:           tc.position.Accuracy,
+					Distance:           geo.Distance(12.969, 79.155, tc.position.Latitude, tc.position.Longitude),
+				}).Return(nil)
+			}
+
+			_, err := meetingService.MarkAttendanceForUserInMeeting(1, 1, attendanceTime, 1, attendanceCodeAt(t, attendanceTime), tc.position)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMeetingService_MarkAttendanceForUserInMeeting_Policy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	// on time within 10 minutes of attendance start, late up to 30 minutes, absent after
+	teamPolicy := models.AttendancePolicy{Enabled: true, GraceMinutes: 10, LateMinutes: 30}
+	attendanceStart := time.Date(2024, time.January, 2, 18, 5, 0, 0, time.UTC)
+	openMeeting := models.Meeting{
+		TeamID:              1,
+		StartTime:           attendanceStart.Add(-5 * time.Minute),
+		MeetingPeriod:       true,
+		AttendancePeriod:    true,
+		AttendanceStartedAt: &attendanceStart,
+		AttendanceSecret:    testAttendanceSecret,
+	}
+	closedMeeting := openMeeting
+	closedMeeting.AttendancePeriod = false
+	closedMeeting.AttendanceOver = true
+	overriddenMeeting := openMeeting
+	overriddenMeeting.AttendancePolicy = models.AttendancePolicy{Enabled: true, GraceMinutes: 2}
+
+	testCases := []struct {
+		name           string
+		meeting        models.Meeting
+		after          time.Duration
+		expectedStatus string
+		expectedLate   uint
+	}{
+		{"Within_grace_period", openMeeting, 9 * time.Minute, models.AttendanceStatusOnTime, 0},
+		{"After_grace_period", openMeeting, 12*time.Minute + 30*time.Second, models.AttendanceStatusLate, 12},
+		{"Attendance_closed_within_grace_period", closedMeeting, 5 * time.Minute, models.AttendanceStatusLate, 5},
+		{"After_late_period", closedMeeting, 31 * time.Minute, models.AttendanceStatusAbsent, 31},
+		{"Meeting_policy_overrides_team", overriddenMeeting, 5 * time.Minute, models.AttendanceStatusLate, 5},
+		{"Meeting_policy_without_late_limit", overriddenMeeting, 2 * time.Hour, models.AttendanceStatusLate, 120},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uint(i + 1)
+			markedAt := attendanceStart.Add(tc.after)
+			mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(tc.meeting, nil)
+			mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(userID, uint(1)).Return(models.MeetingAttendance{}, errors.New("existing attendance not found"))
+			if !tc.meeting.AttendancePolicy.Enabled {
+				mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{AttendancePolicy: teamPolicy}, nil)
+			}
+			mockRepo.EXPECT().AddMeetingAttendance(models.MeetingAttendance{
+				UserID:             userID,
+				MeetingID:          1,
+				AttendanceMarkedAt: markedAt,
+				OnTime:             tc.expectedStatus == models.AttendanceStatusOnTime,
+				Status:             tc.expectedStatus,
+				MinutesLate:        tc.expectedLate,
+			}).Return(nil)
+
+			attendance, err := meetingService.MarkAttendanceForUserInMeeting(userID, 1, markedAt, 1, attendanceCodeAt(t, markedAt), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, attendance.Status)
+			assert.Equal(t, tc.expectedLate, attendance.MinutesLate)
+		})
+	}
+}
+
+func TestMeetingService_AttendanceCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	meeting := models.Meeting{
+		TeamID:           1,
+		MeetingPeriod:    true,
+		AttendancePeriod: true,
+		AttendanceSecret: testAttendanceSecret,
+	}
+	now := time.Now()
+
+	t.Run("Get_code_before_attendance_start", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(models.Meeting{TeamID: 1, MeetingPeriod: true}, nil)
+
+		_, err := meetingService.GetAttendanceCode(1, 1, now)
+		assert.Error(t, err)
+	})
+
+	// code displayed by the admin
+	mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+	code, err := meetingService.GetAttendanceCode(1, 1, now)
+	assert.NoError(t, err)
+	assert.Len(t, code.Code, 6)
+	assert.True(t, code.ExpiresAt.After(now))
+
+	t.Run("Mark_with_current_code", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(1)).Return(models.MeetingAttendance{}, errors.New("existing attendance not found"))
+		mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{}, nil)
+		mockRepo.EXPECT().AddMeetingAttendance(models.MeetingAttendance{UserID: 2, MeetingID: 1, AttendanceMarkedAt: now, OnTime: true, Status: models.AttendanceStatusOnTime}).Return(nil)
+
+		_, err := meetingService.MarkAttendanceForUserInMeeting(2, 1, now, 1, code.Code, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Mark_with_just_expired_code", func(t *testing.T) {
+		later := now.Add(time.Duration(code.Period) * time.Second)
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(1)).Return(models.MeetingAttendance{}, errors.New("existing attendance not found"))
+		mockTeamRepo.EXPECT().GetTeamByID(uint(1)).Return(models.Team{}, nil)
+		mockRepo.EXPECT().AddMeetingAttendance(models.MeetingAttendance{UserID: 3, MeetingID: 1, AttendanceMarkedAt: later, OnTime: true, Status: models.AttendanceStatusOnTime}).Return(nil)
+
+		_, err := meetingService.MarkAttendanceForUserInMeeting(3, 1, later, 1, code.Code, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Mark_with_stale_code", func(t *testing.T) {
+		later := now.Add(time.Duration(3*code.Period) * time.Second)
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+
+		_, err := meetingService.MarkAttendanceForUserInMeeting(4, 1, later, 1, code.Code, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Mark_without_code", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+
+		_, err := meetingService.MarkAttendanceForUserInMeeting(5, 1, now, 1, "", nil)
+		assert.Error(t, err)
+	})
+
+	// attendance started before codes existed, the secret is generated on first use
+	withoutSecret := meeting
+	withoutSecret.ID = 2
+	withoutSecret.AttendanceSecret = ""
+
+	t.Run("Mark_without_code_and_secret", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(2)).Return(withoutSecret, nil)
+		mockRepo.EXPECT().EnsureAttendanceSecret(uint(2), gomock.Any()).Return(testAttendanceSecret, nil)
+
+		_, err := meetingService.MarkAttendanceForUserInMeeting(6, 2, now, 1, "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("Get_code_without_secret", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(2)).Return(withoutSecret, nil)
+		mockRepo.EXPECT().EnsureAttendanceSecret(uint(2), gomock.Any()).Return(testAttendanceSecret, nil)
+
+		generated, err := meetingService.GetAttendanceCode(2, 1, now)
+		assert.NoError(t, err)
+		assert.Equal(t, code.Code, generated.Code)
+	})
+}
+
+func TestAttendanceCodeOptions(t *testing.T) {
+	defer viper.Set("ATTENDANCE_CODE_PERIOD", nil)
+
+	tests := []struct {
+		period interface{}
+		want   time.Duration
+	}{
+		{nil, 30 * time.Second},
+		{60, 60 * time.Second},
+		{0, 30 * time.Second},
+		{-5, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		viper.Set("ATTENDANCE_CODE_PERIOD", tt.period)
+		if got := attendanceCodeOptions().Period; got != tt.want {
+			t.Errorf("Expected a period of %v for ATTENDANCE_CODE_PERIOD=%v, got %v", tt.want, tt.period, got)
+		}
+	}
+}
+
+func TestMeetingService_OverrideAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	startedMeeting := models.Meeting{TeamID: 1, MeetingPeriod: true}
+	now := time.Now()
+
+	t.Run("Meeting_not_started", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(models.Meeting{TeamID: 1}, nil)
+
+		_, err := meetingService.OverrideAttendance(models.AuditActor{UserID: 9}, 2, 1, 1, true, "phone died", now)
+		assert.Error(t, err)
+	})
+
+	t.Run("Not_a_team_member", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(startedMeeting, nil)
+		mockTeamMemberRepo.EXPECT().GetTeamMemberByID(uint(1), uint(2)).Return(models.TeamMember{}, errors.New("not found"))
+
+		_, err := meetingService.OverrideAttendance(models.AuditActor{UserID: 9}, 2, 1, 1, true, "phone died", now)
+		assert.Error(t, err)
+	})
+
+	t.Run("Mark_present_without_record", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(startedMeeting, nil)
+		mockTeamMemberRepo.EXPECT().GetTeamMemberByID(uint(1), uint(2)).Return(models.TeamMember{TeamID: 1, UserID: 2}, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(1)).Return(models.MeetingAttendance{}, errors.New("not found"))
+		mockRepo.EXPECT().AddMeetingAttendance(models.MeetingAttendance{
+			UserID:             2,
+			MeetingID:          1,
+			AttendanceMarkedAt: now,
+			OnTime:             true,
+			Status:             models.AttendanceStatusOnTime,
+			OverriddenByID:     9,
+			OverrideReason:     "phone died",
+			OverriddenAt:       &now,
+		}).Return(nil)
+
+		attendance, err := meetingService.OverrideAttendance(models.AuditActor{UserID: 9}, 2, 1, 1, true, "phone died", now)
+		assert.NoError(t, err)
+		assert.True(t, attendance.OnTime)
+		assert.Equal(t, uint(9), attendance.OverriddenByID)
+	})
+
+	t.Run("Flip_on_time_of_existing_record", func(t *testing.T) {
+		existing := models.MeetingAttendance{UserID: 2, MeetingID: 1, AttendanceMarkedAt: now.Add(-time.Hour), OnTime: false}
+		updated := existing
+		updated.OnTime = true
+		updated.Status = models.AttendanceStatusOnTime
+		updated.OverriddenByID = 9
+		updated.OverrideReason = "was at the door"
+		updated.OverriddenAt = &now
+
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(startedMeeting, nil)
+		mockTeamMemberRepo.EXPECT().GetTeamMemberByID(uint(1), uint(2)).Return(models.TeamMember{TeamID: 1, UserID: 2}, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(1)).Return(existing, nil)
+		mockRepo.EXPECT().UpdateMeetingAttendance(updated).Return(updated, nil)
+
+		attendance, err := meetingService.OverrideAttendance(models.AuditActor{UserID: 9}, 2, 1, 1, true, "was at the door", now)
+		assert.NoError(t, err)
+		assert.Equal(t, existing.AttendanceMarkedAt, attendance.AttendanceMarkedAt)
+	})
+
+	t.Run("Remove_record", func(t *testing.T) {
+		existing := models.MeetingAttendance{UserID: 2, MeetingID: 1, AttendanceMarkedAt: now, OnTime: true}
+		removed := existing
+		removed.OverriddenByID = 9
+		removed.OverrideReason = "marked by proxy"
+		removed.OverriddenAt = &now
+
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(startedMeeting, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(1)).Return(existing, nil)
+		mockRepo.EXPECT().DeleteMeetingAttendance(removed).Return(nil)
+
+		err := meetingService.RemoveAttendance(models.AuditActor{UserID: 9}, 2, 1, 1, "marked by proxy", now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Remove_missing_record", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(startedMeeting, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(3), uint(1)).Return(models.MeetingAttendance{}, errors.New("not found"))
+
+		err := meetingService.RemoveAttendance(models.AuditActor{UserID: 9}, 3, 1, 1, "marked by proxy", now)
+		assert.Error(t, err)
+	})
+}
+
+func TestMeetingService_UpdateMeeting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	meeting := models.Meeting{
+		TeamID:      1,
+		Title:       "Weekly sync",
+		Description: "Sync",
+		Venue:       "Room 1",
+		StartTime:   startTime,
+	}
+	venue := "Room 2"
+	title := "Weekly sync (moved)"
+
+	t.Run("Update_before_start", func(t *testing.T) {
+		newStartTime := startTime.Add(time.Hour)
+		updated := meeting
+		updated.Venue = venue
+		updated.StartTime = newStartTime
+		changes := []string{
+			"Venue: Room 1 -> Room 2",
+			"Start time: " + startTime.String() + " -> " + newStartTime.String(),
+		}
+
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+		mockRepo.EXPECT().UpdateMeeting(updated).Return(updated, nil)
+		mockEmailService.EXPECT().SendMeetingUpdateNotification(uint(1), updated, changes).Return(nil)
+
+		result, err := meetingService.UpdateMeeting(models.AuditActor{UserID: 9}, 1, 1, models.MeetingUpdate{Venue: &venue, StartTime: &newStartTime})
+		assert.NoError(t, err)
+		assert.Equal(t, updated, result)
+	})
+
+	t.Run("Nothing_changed", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+
+		result, err := meetingService.UpdateMeeting(models.AuditActor{UserID: 9}, 1, 1, models.MeetingUpdate{Title: &meeting.Title})
+		assert.NoError(t, err)
+		assert.Equal(t, meeting, result)
+	})
+
+	t.Run("Start_time_in_past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+
+		_, err := meetingService.UpdateMeeting(models.AuditActor{UserID: 9}, 1, 1, models.MeetingUpdate{StartTime: &past})
+		assert.Error(t, err)
+	})
+
+	t.Run("Empty_title", func(t *testing.T) {
+		empty := ""
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+
+		_, err := meetingService.UpdateMeeting(models.AuditActor{UserID: 9}, 1, 1, models.MeetingUpdate{Title: &empty})
+		assert.Error(t, err)
+	})
+
+	t.Run("Venue_after_start", func(t *testing.T) {
+		started := meeting
+		started.MeetingPeriod = true
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(started, nil)
+
+		_, err := meetingService.UpdateMeeting(models.AuditActor{UserID: 9}, 1, 1, models.MeetingUpdate{Venue: &venue})
+		assert.Error(t, err)
+	})
+
+	t.Run("Title_after_start", func(t *testing.T) {
+		started := meeting
+		started.MeetingPeriod = true
+		updated := started
+		updated.Title = title
+
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(started, nil)
+		mockRepo.EXPECT().UpdateMeeting(updated).Return(updated, nil)
+		mockEmailService.EXPECT().SendMeetingUpdateNotification(uint(1), updated, []string{"Title: Weekly sync -> Weekly sync (moved)"}).Return(nil)
+
+		result, err := meetingService.UpdateMeeting(models.AuditActor{UserID: 9}, 1, 1, models.MeetingUpdate{Title: &title})
+		assert.NoError(t, err)
+		assert.Equal(t, title, result.Title)
+	})
+}
+
+func TestMeetingService_RunScheduledTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
+
+	generateAttendanceSecret = func() (string, error) { return testAttendanceSecret, nil }
+	defer func() { generateAttendanceSecret = totp.GenerateSecret }()
+
+	startTime := time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC)
+	meeting := models.Meeting{
+		TeamID:    1,
+		StartTime: startTime,
+		Schedule:  models.MeetingSchedule{AutoStart: true, AttendanceMinutes: 15, DurationMinutes: 60},
+	}
+	meeting.ID = 1
+
+	t.Run("Start_with_attendance", func(t *testing.T) {
+		now := startTime.Add(time.Minute)
+		started := meeting
+		started.MeetingPeriod = true
+		started.AttendancePeriod = true
+		started.AttendanceSecret = testAttendanceSecret
+		started.AttendanceStartedAt = &startTime
+		started.Schedule.State = models.ScheduleStateStarted
+
+		mockRepo.EXPECT().GetMeetingsWithPendingTransitions(now).Return([]models.Meeting{meeting}, nil)
+		mockRepo.EXPECT().TransitionMeeting(started, meeting).Return(true, nil)
+
+		assert.NoError(t, meetingService.RunScheduledTransitions(now))
+	})
+
+	t.Run("Another_replica_started_it", func(t *testing.T) {
+		now := startTime.Add(time.Minute)
+		mockRepo.EXPECT().GetMeetingsWithPendingTransitions(now).Return([]models.Meeting{meeting}, nil)
+		mockRepo.EXPECT().TransitionMeeting(gomock.Any(), meeting).Return(false, nil)
+
+		assert.NoError(t, meetingService.RunScheduledTransitions(now))
+	})
+
+	t.Run("Catch_up_after_downtime", func(t *testing.T) {
+		// no replica ran during the meeting, so it starts without attendance and ends right away
+		now := startTime.Add(2 * time.Hour)
+		started := meeting
+		started.MeetingPeriod = true
+		started.Schedule.State = models.ScheduleStateStarted
+		attendanceEnded := started
+		attendanceEnded.Schedule.State = models.ScheduleStateAttendanceEnded
+		ended := attendanceEnded
+		ended.MeetingPeriod = false
+		ended.MeetingOver = true
+		ended.AttendanceOver = true
+		ended.Schedule.State = models.ScheduleStateEnded
+
+		mockRepo.EXPECT().GetMeetingsWithPendingTransitions(now).Return([]models.Meeting{meeting}, nil)
+		gomock.InOrder(
+			mockRepo.EXPECT().TransitionMeeting(started, meeting).Return(true, nil),
+			mockRepo.EXPECT().TransitionMeeting(attendanceEnded, started).Return(true, nil),
+			mockRepo.EXPECT().TransitionMeeting(ended, attendanceEnded).Return(true, nil),
+		)
+
+		assert.NoError(t, meetingService.RunScheduledTransitions(now))
+	})
+
+	t.Run("Close_attendance_window", func(t *testing.T) {
+		now := startTime.Add(20 * time.Minute)
+		started := meeting
+		started.MeetingPeriod = true
+		started.AttendancePeriod = true
+		started.Schedule.State = models.ScheduleStateStarted
+		attendanceEnded := started
+		attendanceEnded.AttendancePeriod = false
+		attendanceEnded.AttendanceOver = true
+		attendanceEnded.Schedule.State = models.ScheduleStateAttendanceEnded
+
+		mockRepo.EXPECT().GetMeetingsWithPendingTransitions(now).Return([]models.Meeting{started}, nil)
+		mockRepo.EXPECT().TransitionMeeting(attendanceEnded, started).Return(true, nil)
+
+		assert.NoError(t, meetingService.RunScheduledTransitions(now))
+	})
+}
+
 func TestMeetingService_GetAttendanceForMeeting(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -658,7 +1251,7 @@ func TestMeetingService_GetAttendanceForMeeting(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	// Define common test data
 	meetingID := uint(1)
@@ -736,7 +1329,7 @@ func TestMeetingService_UpcomingUserMeetings(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mocks.NewMockLeaveRequestRepository(ctrl), newAuditLogServiceMock(ctrl))
 
 	// Define test data
 	userID := uint(1)
@@ -787,13 +1380,16 @@ func TestMeetingService_GetFullUserAttendanceRecord(t *testing.T) {
 	mockUserRepo := mocks.NewMockUserRepository(ctrl)
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
 
-	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mockLeaveRequestRepo, newAuditLogServiceMock(ctrl))
 
 	// Define test data
 	userID := uint(1)
 	meetingID := uint(2)
 	meeting2ID := uint(3)
+	meeting3ID := uint(4) // excused, not attended
+	meeting4ID := uint(5) // excused, marked too late
 	teamID := uint(3)
 
 	// Mock GetMeetingAttendancesByUserID
@@ -810,6 +1406,19 @@ func TestMeetingService_GetFullUserAttendanceRecord(t *testing.T) {
 			AttendanceMarkedAt: time.Now(),
 			OnTime:             false,
 		},
+		{
+			UserID:             userID,
+			MeetingID:          meeting4ID,
+			AttendanceMarkedAt: time.Now(),
+			Status:             models.AttendanceStatusAbsent,
+		},
+	}, nil)
+
+	// Mock GetApprovedLeaveRequestsByUserID, a leave for an attended meeting is ignored
+	mockLeaveRequestRepo.EXPECT().GetApprovedLeaveRequestsByUserID(userID).Return([]models.LeaveRequest{
+		{MeetingID: meeting2ID, UserID: userID, Status: models.LeaveRequestApproved},
+		{MeetingID: meeting3ID, UserID: userID, Status: models.LeaveRequestApproved},
+		{MeetingID: meeting4ID, UserID: userID, Status: models.LeaveRequestApproved},
 	}, nil)
 
 	// Mock GetMeetingByID
@@ -823,8 +1432,18 @@ func TestMeetingService_GetFullUserAttendanceRecord(t *testing.T) {
 		Title:  "Sample Meeting 2",
 	}, nil)
 
-	// Mock GetTeamByID for both invocations
-	for i := 0; i < 2; i++ {
+	mockRepo.EXPECT().GetMeetingByID(meeting3ID).Return(models.Meeting{
+		TeamID: teamID,
+		Title:  "Sample Meeting 3",
+	}, nil)
+
+	mockRepo.EXPECT().GetMeetingByID(meeting4ID).Return(models.Meeting{
+		TeamID: teamID,
+		Title:  "Sample Meeting 4",
+	}, nil)
+
+	// Mock GetTeamByID for every invocation
+	for i := 0; i < 4; i++ {
 		mockTeamRepo.EXPECT().GetTeamByID(teamID).Return(models.Team{
 			Name: "Sample Team",
 			// Add other team details as needed
@@ -837,11 +1456,138 @@ func TestMeetingService_GetFullUserAttendanceRecord(t *testing.T) {
 	// Check the results
 	assert.NoError(t, err)
 	assert.NotNil(t, attendanceRecords)
-	assert.Equal(t, 2, len(attendanceRecords))
+	assert.Equal(t, 4, len(attendanceRecords))
 	assert.Equal(t, meetingID, attendanceRecords[0].MeetingID)
 	assert.Equal(t, "Sample Team", attendanceRecords[0].TeamName)
 	assert.Equal(t, "Sample Meeting", attendanceRecords[0].MeetingName)
 	assert.Equal(t, meeting2ID, attendanceRecords[1].MeetingID)
 	assert.Equal(t, "Sample Team", attendanceRecords[1].TeamName)
 	assert.Equal(t, "Sample Meeting 2", attendanceRecords[1].MeetingName)
+	assert.Equal(t, models.AttendanceStatusLate, attendanceRecords[1].Status)
+	assert.Equal(t, meeting4ID, attendanceRecords[2].MeetingID)
+	assert.Equal(t, models.AttendanceStatusExcused, attendanceRecords[2].Status)
+	assert.Equal(t, meeting3ID, attendanceRecords[3].MeetingID)
+	assert.Equal(t, "Sample Meeting 3", attendanceRecords[3].MeetingName)
+	assert.Equal(t, models.AttendanceStatusExcused, attendanceRecords[3].Status)
+}
+
+func TestMeetingService_GetMeetingSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockTeamMemberRepo := mocks.NewMockTeamMemberRepository(ctrl)
+	mockLeaveRequestRepo := mocks.NewMockLeaveRequestRepository(ctrl)
+	meetingService := NewMeetingService(mockRepo, mockEmailService, mockUserRepo, mockTeamRepo, mockTeamMemberRepo, mockLeaveRequestRepo, newAuditLogServiceMock(ctrl))
+
+	meetingID := uint(1)
+	teamID := uint(2)
+	startTime := time.Now().Add(-time.Hour)
+	meeting := models.Meeting{TeamID: teamID, Title: "Weekly sync", StartTime: startTime, MeetingOver: true}
+	meeting.ID = meetingID
+
+	onTimeUser := models.User{Name: "On Time"}
+	onTimeUser.ID = 3
+	lateUser := models.User{Name: "Late"}
+	lateUser.ID = 4
+	absentUser := models.User{Name: "Absent"}
+	absentUser.ID = 5
+	tooLateUser := models.User{Name: "Too Late"}
+	tooLateUser.ID = 6
+	excusedUser := models.User{Name: "Excused"}
+	excusedUser.ID = 7
+
+	mockRepo.EXPECT().GetMeetingByID(meetingID).Return(meeting, nil).Times(2)
+	mockTeamRepo.EXPECT().GetTeamByID(teamID).Return(models.Team{Name: "Team"}, nil)
+	mockRepo.EXPECT().GetMeetingAttendanceByMeetingID(meetingID).Return([]models.MeetingAttendance{
+		{MeetingID: meetingID, UserID: 3, AttendanceMarkedAt: startTime, OnTime: true},
+		{MeetingID: meetingID, UserID: 4, AttendanceMarkedAt: startTime.Add(30 * time.Minute), OnTime: false},
+		// marked after the late period of the attendance policy
+		{MeetingID: meetingID, UserID: 6, AttendanceMarkedAt: startTime.Add(50 * time.Minute), Status: models.AttendanceStatusAbsent, MinutesLate: 50},
+	}, nil)
+	mockUserRepo.EXPECT().GetUserByID(uint(3)).Return(onTimeUser, nil)
+	mockUserRepo.EXPECT().GetUserByID(uint(4)).Return(lateUser, nil)
+	mockUserRepo.EXPECT().GetUserByID(uint(6)).Return(tooLateUser, nil).Times(2)
+	// members who joined after the meeting are not returned as of its start time
+	mockTeamMemberRepo.EXPECT().GetTeamMembersByTeamIDAsOf(teamID, startTime).Return([]models.TeamMember{
+		{TeamID: teamID, UserID: 3},
+		{TeamID: teamID, UserID: 4},
+		{TeamID: teamID, UserID: 5},
+		{TeamID: teamID, UserID: 5},
+		{TeamID: teamID, UserID: 6},
+		{TeamID: teamID, UserID: 7},
+	}, nil)
+	mockUserRepo.EXPECT().GetUserByID(uint(5)).Return(absentUser, nil)
+	// a member who attended anyway stays present
+	mockLeaveRequestRepo.EXPECT().GetApprovedLeaveRequestsByMeetingID(meetingID).Return([]models.LeaveRequest{
+		{TeamID: teamID, MeetingID: meetingID, UserID: 3, Status: models.LeaveRequestApproved},
+		{TeamID: teamID, MeetingID: meetingID, UserID: 7, Status: models.LeaveRequestApproved},
+	}, nil)
+	mockUserRepo.EXPECT().GetUserByID(uint(7)).Return(excusedUser, nil)
+
+	summary, err := meetingService.GetMeetingSummary(meetingID, teamID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Weekly sync", summary.MeetingName)
+	assert.Equal(t, "Team", summary.TeamName)
+	assert.Equal(t, 5, summary.Expected)
+	assert.Equal(t, 2, summary.PresentCount)
+	assert.Equal(t, 1, summary.OnTimeCount)
+	assert.Equal(t, uint(3), summary.OnTime[0].User.ID)
+	assert.Equal(t, 1, summary.LateCount)
+	assert.Equal(t, uint(4), summary.Late[0].User.ID)
+	assert.Equal(t, models.AttendanceStatusLate, summary.Late[0].Status)
+	assert.Equal(t, 2, summary.AbsentCount)
+	assert.Equal(t, []models.User{absentUser, tooLateUser}, summary.Absent)
+	assert.Equal(t, 1, summary.ExcusedCount)
+	assert.Equal(t, []models.User{excusedUser}, summary.Excused)
+}
+
+// test that admin actions on meetings are recorded in the audit log, with snapshots
+func TestMeetingService_AuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	meetingService := NewMeetingService(mockRepo, mocks.NewMockEmailService(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockTeamRepository(ctrl), mocks.NewMockTeamMemberRepository(ctrl), mocks.NewMockLeaveRequestRepository(ctrl), mockAuditLogService)
+
+	actor := models.AuditActor{UserID: 9, IP: "10.0.0.1"}
+	now := time.Now()
+	meeting := models.Meeting{Model: gorm.Model{ID: 1}, TeamID: 1, Title: "Weekly sync"}
+
+	t.Run("Delete_meeting", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+		mockRepo.EXPECT().DeleteMeetingByID(uint(1)).Return(nil)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditMeetingDelete, models.AuditTargetMeeting, uint(1), meeting, nil)
+
+		err := meetingService.DeleteMeetingByID(actor, 1, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed_delete_is_not_recorded", func(t *testing.T) {
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(meeting, nil)
+		mockRepo.EXPECT().DeleteMeetingByID(uint(1)).Return(errors.New("database error"))
+
+		err := meetingService.DeleteMeetingByID(actor, 1, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Remove_attendance", func(t *testing.T) {
+		started := meeting
+		started.MeetingPeriod = true
+		attendance := models.MeetingAttendance{MeetingID: 1, UserID: 2, OnTime: true}
+		mockRepo.EXPECT().GetMeetingByID(uint(1)).Return(started, nil)
+		mockRepo.EXPECT().GetMeetingAttendanceByUserIDAndMeetingID(uint(2), uint(1)).Return(attendance, nil)
+		removed := attendance
+		removed.OverriddenByID, removed.OverrideReason, removed.OverriddenAt = 9, "marked by proxy", &now
+		mockRepo.EXPECT().DeleteMeetingAttendance(removed).Return(nil)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditAttendanceRemove, models.AuditTargetAttendance, uint(2), attendance, nil)
+
+		err := meetingService.RemoveAttendance(actor, 2, 1, 1, "marked by proxy", now)
+		assert.NoError(t, err)
+	})
 }
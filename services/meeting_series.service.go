@@ -0,0 +1,293 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/rrule"
+	"github.com/spf13/viper"
+)
+
+// MeetingSeriesService handles business logic related to recurring meetings.
+type MeetingSeriesService struct {
+	seriesRepo      repository.MeetingSeriesRepositoryInterface
+	meetingRepo     repository.MeetingRepositoryInterface
+	meetingService  MeetingServiceInterface
+	emailService    EmailServiceInterface
+	auditLogService AuditLogServiceInterface
+}
+
+// NewMeetingSeriesService creates a new MeetingSeriesService.
+func NewMeetingSeriesService(
+	seriesRepo repository.MeetingSeriesRepositoryInterface,
+	meetingRepo repository.MeetingRepositoryInterface,
+	meetingService MeetingServiceInterface,
+	emailService EmailServiceInterface,
+	auditLogService AuditLogServiceInterface,
+) *MeetingSeriesService {
+	return &MeetingSeriesService{seriesRepo, meetingRepo, meetingService, emailService, auditLogService}
+}
+
+// meetingSeriesHorizon returns how far ahead occurrences of a series are created as meetings, MEETING_SERIES_HORIZON_DAYS days.
+func meetingSeriesHorizon() time.Duration {
+	viper.SetDefault("MEETING_SERIES_HORIZON_DAYS", 28)
+	return time.Duration(viper.GetInt("MEETING_SERIES_HORIZON_DAYS")) * 24 * time.Hour
+}
+
+type MeetingSeriesServiceInterface interface {
+	CreateMeetingSeries(actor models.AuditActor, series models.MeetingSeries, now time.Time) (models.MeetingSeries, error)
+	GetMeetingSeriesByID(seriesID, teamID uint) (models.MeetingSeries, error)
+	GetMeetingSeriesByTeamID(teamID uint) ([]models.MeetingSeries, error)
+	UpdateOccurrence(actor models.AuditActor, seriesID, meetingID, teamID uint, update models.MeetingUpdate) (models.Meeting, error)
+	UpdateFollowingOccurrences(actor models.AuditActor, seriesID, meetingID, teamID uint, update models.MeetingUpdate, now time.Time) (models.MeetingSeries, error)
+	DeleteMeetingSeries(actor models.AuditActor, seriesID, teamID uint, now time.Time) error
+	MaterializeMeetingSeries(now time.Time) error
+}
+
+// CreateMeetingSeries creates a new meeting series, and its meetings up to the horizon.
+// Members get a single notification for the series, not one per meeting.
+func (mss *MeetingSeriesService) CreateMeetingSeries(actor models.AuditActor, series models.MeetingSeries, now time.Time) (models.MeetingSeries, error) {
+	if series.StartTime.Before(now) {
+		return models.MeetingSeries{}, errors.New("meeting series start time cannot be in the past")
+	}
+
+	createdSeries, err := mss.seriesRepo.CreateMeetingSeries(series)
+	if err != nil {
+		logger.Errorf("Error creating meeting series: " + err.Error())
+		return models.MeetingSeries{}, err
+	}
+
+	createdSeries, err = mss.materialize(createdSeries, now)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+
+	mss.auditLogService.Record(actor, createdSeries.TeamID, models.AuditSeriesCreate, models.AuditTargetSeries, createdSeries.ID, nil, createdSeries)
+
+	// use email service to send email to all team members
+	mss.emailService.SendMeetingSeriesNotification(createdSeries.TeamID, createdSeries)
+
+	return createdSeries, nil
+}
+
+// GetMeetingSeriesByID retrieves a meeting series by its ID.
+func (mss *MeetingSeriesService) GetMeetingSeriesByID(seriesID, teamID uint) (models.MeetingSeries, error) {
+	series, err := mss.seriesRepo.GetMeetingSeriesByID(seriesID)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	// check if series teamid is same as teamid
+	if series.TeamID != teamID {
+		return models.MeetingSeries{}, errors.New("meeting series not found")
+	}
+	return series, nil
+}
+
+// GetMeetingSeriesByTeamID retrieves all meeting series of a team.
+func (mss *MeetingSeriesService) GetMeetingSeriesByTeamID(teamID uint) ([]models.MeetingSeries, error) {
+	series, err := mss.seriesRepo.GetMeetingSeriesByTeamID(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if len(series) <= 0 {
+		series = []models.MeetingSeries{}
+	}
+	return series, nil
+}
+
+// getOccurrence retrieves a meeting of the series.
+func (mss *MeetingSeriesService) getOccurrence(series models.MeetingSeries, meetingID uint) (models.Meeting, error) {
+	meeting, err := mss.meetingRepo.GetMeetingByID(meetingID)
+	if err != nil {
+		return models.Meeting{}, err
+	}
+	if meeting.SeriesID != series.ID || meeting.TeamID != series.TeamID {
+		return models.Meeting{}, errors.New("meeting not found in series")
+	}
+	return meeting, nil
+}
+
+// UpdateOccurrence edits a single meeting of a series, the rest of the series is unchanged.
+// The meeting keeps its OccurrenceTime, so it is not created again by the series.
+func (mss *MeetingSeriesService) UpdateOccurrence(actor models.AuditActor, seriesID, meetingID, teamID uint, update models.MeetingUpdate) (models.Meeting, error) {
+	series, err := mss.GetMeetingSeriesByID(seriesID, teamID)
+	if err != nil {
+		return models.Meeting{}, err
+	}
+	if _, err := mss.getOccurrence(series, meetingID); err != nil {
+		return models.Meeting{}, err
+	}
+	return mss.meetingService.UpdateMeeting(actor, meetingID, teamID, update)
+}
+
+// UpdateFollowingOccurrences edits a meeting of a series and all the ones after it.
+// The series is ended before the meeting and a new series with the changes continues from it, keeping the remaining COUNT.
+// Meetings of the old series from then on that have not started are replaced by meetings of the new series.
+func (mss *MeetingSeriesService) UpdateFollowingOccurrences(actor models.AuditActor, seriesID, meetingID, teamID uint, update models.MeetingUpdate, now time.Time) (models.MeetingSeries, error) {
+	series, err := mss.GetMeetingSeriesByID(seriesID, teamID)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	meeting, err := mss.getOccurrence(series, meetingID)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	if meeting.MeetingPeriod || meeting.MeetingOver {
+		return models.MeetingSeries{}, errors.New("meetings that have started cannot be edited along with the following ones")
+	}
+
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	from := meeting.OccurrenceTime
+
+	following := models.MeetingSeries{
+		TeamID:           series.TeamID,
+		Title:            series.Title,
+		Description:      series.Description,
+		Venue:            series.Venue,
+		Location:         series.Location,
+		Geofence:         series.Geofence,
+		Schedule:         series.Schedule,
+		AttendancePolicy: series.AttendancePolicy,
+		StartTime:        from,
+		ExDates:          series.ExDates,
+	}
+	if update.Title != nil {
+		following.Title = *update.Title
+	}
+	if update.Description != nil {
+		following.Description = *update.Description
+	}
+	if update.Venue != nil {
+		following.Venue = *update.Venue
+	}
+	if update.Location != nil {
+		following.Location = *update.Location
+	}
+	if update.Geofence != nil {
+		following.Geofence = *update.Geofence
+	}
+	if update.Schedule != nil {
+		following.Schedule = *update.Schedule
+		following.Schedule.State = models.ScheduleStatePending
+	}
+	if update.AttendancePolicy != nil {
+		following.AttendancePolicy = *update.AttendancePolicy
+	}
+	if update.StartTime != nil {
+		if update.StartTime.Before(now) {
+			return models.MeetingSeries{}, errors.New("meeting start time cannot be in the past")
+		}
+		following.StartTime = *update.StartTime
+	}
+
+	followingRule := rule
+	if rule.Count > 0 {
+		followingRule.Count = rule.Count - rule.CountBefore(series.StartTime, from)
+	}
+	following.RRule = followingRule.String()
+
+	// end the series right before the edited meeting
+	before := series
+	rule.Count = 0
+	rule.Until = from.Add(-time.Second)
+	series.RRule = rule.String()
+
+	createdSeries, err := mss.seriesRepo.SplitMeetingSeries(series, following, from)
+	if err != nil {
+		logger.Errorf("Error splitting meeting series: " + err.Error())
+		return models.MeetingSeries{}, err
+	}
+
+	createdSeries, err = mss.materialize(createdSeries, now)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+
+	// the old series is ended and the new one created
+	mss.auditLogService.Record(actor, teamID, models.AuditSeriesUpdate, models.AuditTargetSeries, series.ID, before, series)
+	mss.auditLogService.Record(actor, teamID, models.AuditSeriesCreate, models.AuditTargetSeries, createdSeries.ID, nil, createdSeries)
+
+	// use email service to let all team members know of the new schedule
+	mss.emailService.SendMeetingSeriesNotification(createdSeries.TeamID, createdSeries)
+
+	return createdSeries, nil
+}
+
+// DeleteMeetingSeries deletes a meeting series and its meetings that have not started yet.
+func (mss *MeetingSeriesService) DeleteMeetingSeries(actor models.AuditActor, seriesID, teamID uint, now time.Time) error {
+	series, err := mss.GetMeetingSeriesByID(seriesID, teamID)
+	if err != nil {
+		return err
+	}
+	if err := mss.seriesRepo.DeleteMeetingSeries(series, now); err != nil {
+		return err
+	}
+
+	mss.auditLogService.Record(actor, teamID, models.AuditSeriesDelete, models.AuditTargetSeries, series.ID, series, nil)
+	return nil
+}
+
+// MaterializeMeetingSeries creates the meetings of every series up to the horizon from now. Meant to be run periodically.
+func (mss *MeetingSeriesService) MaterializeMeetingSeries(now time.Time) error {
+	seriesList, err := mss.seriesRepo.GetMeetingSeriesMaterializedBefore(now.Add(meetingSeriesHorizon()))
+	if err != nil {
+		return err
+	}
+	for _, series := range seriesList {
+		if _, err := mss.materialize(series, now); err != nil {
+			logger.Errorf("Error materializing meeting series %d: %v", series.ID, err)
+		}
+	}
+	return nil
+}
+
+// materialize creates the meetings of a series between now and the horizon that do not exist yet.
+// Occurrences whose meeting was deleted are not created again, nor are those on exception dates.
+func (mss *MeetingSeriesService) materialize(series models.MeetingSeries, now time.Time) (models.MeetingSeries, error) {
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	horizon := now.Add(meetingSeriesHorizon())
+
+	existing, err := mss.meetingRepo.GetMeetingsBySeriesID(series.ID)
+	if err != nil {
+		return models.MeetingSeries{}, err
+	}
+	created := make(map[int64]bool)
+	for _, meeting := range existing {
+		created[meeting.OccurrenceTime.Unix()] = true
+	}
+
+	for _, occurrence := range rule.Between(series.StartTime, now, horizon) {
+		if created[occurrence.Unix()] || series.IsException(occurrence) {
+			continue
+		}
+		meeting := models.Meeting{
+			TeamID:           series.TeamID,
+			Title:            series.Title,
+			Description:      series.Description,
+			Venue:            series.Venue,
+			Location:         series.Location,
+			Geofence:         series.Geofence,
+			Schedule:         series.Schedule,
+			AttendancePolicy: series.AttendancePolicy,
+			StartTime:        occurrence,
+			SeriesID:         series.ID,
+			OccurrenceTime:   occurrence,
+		}
+		// the occurrence is unique per series, so this fails if another instance created it first
+		if _, err := mss.meetingRepo.CreateMeeting(meeting); err != nil {
+			logger.Warnf("Could not create meeting of series %d at %v: %v", series.ID, occurrence, err)
+		}
+	}
+
+	series.MaterializedUntil = horizon
+	return mss.seriesRepo.UpdateMeetingSeries(series)
+}
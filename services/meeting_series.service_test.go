@@ -0,0 +1,196 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/mocks"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeetingSeriesService_CreateMeetingSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesRepo := mocks.NewMockMeetingSeriesRepository(ctrl)
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockMeetingService := mocks.NewMockMeetingService(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	seriesService := NewMeetingSeriesService(mockSeriesRepo, mockMeetingRepo, mockMeetingService, mockEmailService, mockAuditLogService)
+	actor := models.AuditActor{UserID: 9}
+
+	// Monday 1 January 2024
+	now := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	series := models.MeetingSeries{
+		TeamID:      1,
+		Title:       "Sync",
+		Description: "Weekly sync",
+		Venue:       "Room 1",
+		StartTime:   time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC),
+		RRule:       "FREQ=WEEKLY;BYDAY=TU,TH",
+		ExDates:     "2024-01-04",
+	}
+	createdSeries := series
+	createdSeries.ID = 7
+
+	t.Run("Start_time_in_past", func(t *testing.T) {
+		past := series
+		past.StartTime = now.Add(-time.Hour)
+
+		_, err := seriesService.CreateMeetingSeries(actor, past, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("Materialize_up_to_horizon", func(t *testing.T) {
+		mockSeriesRepo.EXPECT().CreateMeetingSeries(series).Return(createdSeries, nil)
+		// the occurrence of 9 January already exists
+		mockMeetingRepo.EXPECT().GetMeetingsBySeriesID(uint(7)).Return([]models.Meeting{
+			{SeriesID: 7, OccurrenceTime: time.Date(2024, time.January, 9, 18, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		// within 28 days, without the exception of 4 January: 2, 11, 16, 18, 23, 25 January
+		var created []time.Time
+		mockMeetingRepo.EXPECT().CreateMeeting(gomock.Any()).DoAndReturn(func(meeting models.Meeting) (models.Meeting, error) {
+			assert.Equal(t, uint(7), meeting.SeriesID)
+			assert.Equal(t, "Sync", meeting.Title)
+			assert.Equal(t, meeting.OccurrenceTime, meeting.StartTime)
+			created = append(created, meeting.OccurrenceTime)
+			return meeting, nil
+		}).Times(6)
+
+		materializedSeries := createdSeries
+		materializedSeries.MaterializedUntil = now.Add(28 * 24 * time.Hour)
+		mockSeriesRepo.EXPECT().UpdateMeetingSeries(materializedSeries).Return(materializedSeries, nil)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditSeriesCreate, models.AuditTargetSeries, uint(7), nil, materializedSeries)
+		mockEmailService.EXPECT().SendMeetingSeriesNotification(uint(1), materializedSeries).Return(nil)
+
+		result, err := seriesService.CreateMeetingSeries(actor, series, now)
+		assert.NoError(t, err)
+		assert.Equal(t, materializedSeries, result)
+		assert.Equal(t, 2, created[0].Day())
+		assert.Equal(t, 11, created[1].Day())
+	})
+}
+
+func TestMeetingSeriesService_UpdateOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesRepo := mocks.NewMockMeetingSeriesRepository(ctrl)
+	mockMeetingRepo := mocks.NewMockMeetingRepository(ctrl)
+	mockMeetingService := mocks.NewMockMeetingService(ctrl)
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	seriesService := NewMeetingSeriesService(mockSeriesRepo, mockMeetingRepo, mockMeetingService, mockEmailService, mockAuditLogService)
+	actor := models.AuditActor{UserID: 9}
+
+	now := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	first := time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC)
+	series := models.MeetingSeries{
+		TeamID:      1,
+		Title:       "Sync",
+		Description: "Weekly sync",
+		Venue:       "Room 1",
+		StartTime:   first,
+		RRule:       "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10",
+	}
+	series.ID = 7
+	// third occurrence, Tuesday 9 January
+	from := time.Date(2024, time.January, 9, 18, 0, 0, 0, time.UTC)
+	meeting := models.Meeting{TeamID: 1, Title: "Sync", SeriesID: 7, OccurrenceTime: from, StartTime: from}
+	meeting.ID = 3
+	venue := "Room 2"
+
+	t.Run("This_occurrence", func(t *testing.T) {
+		update := models.MeetingUpdate{Venue: &venue}
+		mockSeriesRepo.EXPECT().GetMeetingSeriesByID(uint(7)).Return(series, nil)
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(3)).Return(meeting, nil)
+		mockMeetingService.EXPECT().UpdateMeeting(actor, uint(3), uint(1), update).Return(models.Meeting{Venue: venue}, nil)
+
+		result, err := seriesService.UpdateOccurrence(actor, 7, 3, 1, update)
+		assert.NoError(t, err)
+		assert.Equal(t, venue, result.Venue)
+	})
+
+	t.Run("Meeting_of_another_series", func(t *testing.T) {
+		mockSeriesRepo.EXPECT().GetMeetingSeriesByID(uint(7)).Return(series, nil)
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(4)).Return(models.Meeting{TeamID: 1, SeriesID: 8}, nil)
+
+		_, err := seriesService.UpdateOccurrence(actor, 7, 4, 1, models.MeetingUpdate{Venue: &venue})
+		assert.Error(t, err)
+	})
+
+	t.Run("This_and_following", func(t *testing.T) {
+		endedSeries := series
+		endedSeries.RRule = "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20240109T175959Z"
+		following := models.MeetingSeries{
+			TeamID:      1,
+			Title:       "Sync",
+			Description: "Weekly sync",
+			Venue:       venue,
+			StartTime:   from,
+			RRule:       "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=8",
+		}
+		createdFollowing := following
+		createdFollowing.ID = 8
+		materializedFollowing := createdFollowing
+		materializedFollowing.MaterializedUntil = now.Add(28 * 24 * time.Hour)
+
+		mockSeriesRepo.EXPECT().GetMeetingSeriesByID(uint(7)).Return(series, nil)
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(3)).Return(meeting, nil)
+		mockSeriesRepo.EXPECT().SplitMeetingSeries(endedSeries, following, from).Return(createdFollowing, nil)
+		mockMeetingRepo.EXPECT().GetMeetingsBySeriesID(uint(8)).Return([]models.Meeting{}, nil)
+		mockMeetingRepo.EXPECT().CreateMeeting(gomock.Any()).Return(models.Meeting{}, nil).AnyTimes()
+		mockSeriesRepo.EXPECT().UpdateMeetingSeries(materializedFollowing).Return(materializedFollowing, nil)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditSeriesUpdate, models.AuditTargetSeries, uint(7), series, endedSeries)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditSeriesCreate, models.AuditTargetSeries, uint(8), nil, materializedFollowing)
+		mockEmailService.EXPECT().SendMeetingSeriesNotification(uint(1), materializedFollowing).Return(nil)
+
+		result, err := seriesService.UpdateFollowingOccurrences(actor, 7, 3, 1, models.MeetingUpdate{Venue: &venue}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(8), result.ID)
+	})
+
+	t.Run("Following_of_started_meeting", func(t *testing.T) {
+		started := meeting
+		started.MeetingPeriod = true
+		mockSeriesRepo.EXPECT().GetMeetingSeriesByID(uint(7)).Return(series, nil)
+		mockMeetingRepo.EXPECT().GetMeetingByID(uint(3)).Return(started, nil)
+
+		_, err := seriesService.UpdateFollowingOccurrences(actor, 7, 3, 1, models.MeetingUpdate{Venue: &venue}, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestMeetingSeriesService_DeleteMeetingSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesRepo := mocks.NewMockMeetingSeriesRepository(ctrl)
+	mockAuditLogService := mocks.NewMockAuditLogService(ctrl)
+	seriesService := NewMeetingSeriesService(mockSeriesRepo, mocks.NewMockMeetingRepository(ctrl), mocks.NewMockMeetingService(ctrl), mocks.NewMockEmailService(ctrl), mockAuditLogService)
+	actor := models.AuditActor{UserID: 9}
+
+	now := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	series := models.MeetingSeries{TeamID: 1, Title: "Sync", RRule: "FREQ=WEEKLY;BYDAY=TU"}
+	series.ID = 7
+
+	t.Run("Series_of_another_team", func(t *testing.T) {
+		mockSeriesRepo.EXPECT().GetMeetingSeriesByID(uint(7)).Return(series, nil)
+
+		err := seriesService.DeleteMeetingSeries(actor, 7, 2, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockSeriesRepo.EXPECT().GetMeetingSeriesByID(uint(7)).Return(series, nil)
+		mockSeriesRepo.EXPECT().DeleteMeetingSeries(series, now).Return(nil)
+		mockAuditLogService.EXPECT().Record(actor, uint(1), models.AuditSeriesDelete, models.AuditTargetSeries, uint(7), series, nil)
+
+		err := seriesService.DeleteMeetingSeries(actor, 7, 1, now)
+		assert.NoError(t, err)
+	})
+}
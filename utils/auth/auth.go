@@ -5,7 +5,6 @@ import (
 
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/repository"
-	"github.com/GDGVIT/attendance-app-backend/utils/token"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -46,34 +45,23 @@ func VerifyPassword(password, hashedPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-// LoginCheck checks validity of given email/password, and returns token in user exists and password is correct.
-func LoginCheck(email, password string) (t string, u models.User, e error) {
-	var err error
-
+// LoginCheck checks validity of given email/password, and returns the user if they exist and the password is correct.
+func LoginCheck(email, password string) (models.User, error) {
 	userRepo := repository.NewUserRepository()
 	pwdAuthRepo := repository.NewPasswordAuthRepository()
 
 	user, err := userRepo.GetUserByEmail(email)
 	if err != nil {
-		return "", user, err
+		return user, err
 	}
 	pwdAuth, err := pwdAuthRepo.GetPwdAuthItemByEmail(email)
 	if err != nil {
-		return "", user, err
+		return user, err
 	}
 
-	err = VerifyPassword(password, pwdAuth.Password)
-
-	if err != nil && err == bcrypt.ErrMismatchedHashAndPassword {
-		return "", user, err
+	if err := VerifyPassword(password, pwdAuth.Password); err != nil {
+		return user, err
 	}
 
-	token, err := token.GenerateToken(user)
-
-	if err != nil {
-		return "", user, err
-	}
-
-	return token, user, nil
-
+	return user, nil
 }
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/utils/token"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GoogleCertsURL is where Google publishes the keys its ID tokens are signed with.
+const GoogleCertsURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// OAuthStateLifespan is how long a Google login can take between starting and finishing.
+const OAuthStateLifespan = 10 * time.Minute
+
+// googleIssuers are the issuers of Google ID tokens.
+var googleIssuers = []string{"accounts.google.com", "https://accounts.google.com"}
+
+// codeVerifierPattern is the format of PKCE code verifiers, RFC 7636 section 4.1.
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// GoogleIDToken is the identity of a user logging in with Google, read from a verified ID token.
+type GoogleIDToken struct {
+	Subject       string // Google account ID, the provider key
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+type googleClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	Nonce         string `json:"nonce"`
+}
+
+// NewOAuthState returns a state to start a Google login with, and the random state string to send with it,
+// of which only the hash is stored. codeChallenge is the S256 PKCE challenge of an app, empty for the web login.
+func NewOAuthState(codeChallenge string, now time.Time) (models.OAuthState, string, error) {
+	state, err := randomToken()
+	if err != nil {
+		return models.OAuthState{}, "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return models.OAuthState{}, "", err
+	}
+	return models.OAuthState{
+		StateHash:     HashOAuthState(state),
+		Nonce:         nonce,
+		CodeChallenge: codeChallenge,
+		ExpiresAt:     now.Add(OAuthStateLifespan),
+	}, state, nil
+}
+
+// HashOAuthState returns the hash under which a state is stored.
+func HashOAuthState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidCodeChallenge reports whether challenge can be an S256 PKCE challenge, an unpadded base64url SHA-256 hash.
+func ValidCodeChallenge(challenge string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// VerifyCodeVerifier reports whether verifier is well formed and is the verifier of the S256 challenge.
+func VerifyCodeVerifier(verifier, challenge string) bool {
+	if !codeVerifierPattern.MatchString(verifier) {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// googleKeySet caches the keys of a JWKS, refetching them when the cache expires or a token names an unknown key.
+type googleKeySet struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+var googleKeys = newGoogleKeySet(GoogleCertsURL)
+
+func newGoogleKeySet(url string) *googleKeySet {
+	return &googleKeySet{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// VerifyGoogleIDToken verifies the signature of a Google ID token with Google's published keys, and that it was
+// issued by Google to clientID for the login started with nonce, and is not expired at now.
+func VerifyGoogleIDToken(idToken, clientID, nonce string, now time.Time) (GoogleIDToken, error) {
+	return googleKeys.verify(idToken, clientID, nonce, now)
+}
+
+func (ks *googleKeySet) verify(idToken, clientID, nonce string, now time.Time) (GoogleIDToken, error) {
+	if clientID == "" {
+		return GoogleIDToken{}, errors.New("google client ID is not configured")
+	}
+
+	claims := &googleClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return ks.key(kid, now)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(clientID),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return GoogleIDToken{}, err
+	}
+
+	if claims.ExpiresAt == nil {
+		return GoogleIDToken{}, errors.New("ID token has no expiry")
+	}
+	issuerValid := false
+	for _, issuer := range googleIssuers {
+		if claims.Issuer == issuer {
+			issuerValid = true
+		}
+	}
+	if !issuerValid {
+		return GoogleIDToken{}, errors.New("ID token was not issued by Google")
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return GoogleIDToken{}, errors.New("ID token was issued for another login")
+	}
+	if claims.Subject == "" {
+		return GoogleIDToken{}, errors.New("ID token has no subject")
+	}
+
+	return GoogleIDToken{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+// key returns the key with the ID kid. Unknown keys refetch the set, at most once a minute, as Google rotates its keys.
+func (ks *googleKeySet) key(kid string, now time.Time) (*rsa.PublicKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	key, ok := ks.keys[kid]
+	if ok && now.Before(ks.expiresAt) {
+		return key, nil
+	}
+	if !now.Before(ks.expiresAt) || !now.Before(ks.fetchedAt.Add(time.Minute)) {
+		if err := ks.fetch(now); err != nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errors.New("unknown signing key")
+}
+
+// fetch replaces the cached keys, keeping them for the max-age of the response, an hour if it has none.
+func (ks *googleKeySet) fetch(now time.Time) error {
+	res, err := ks.client.Get(ks.url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching google keys: %s", res.Status)
+	}
+
+	var set token.JSONWebKeySet
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return err
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	ks.keys = keys
+	ks.fetchedAt = now
+	ks.expiresAt = now.Add(maxAge(res.Header.Get("Cache-Control"), time.Hour))
+	return nil
+}
+
+// maxAge returns the max-age of a Cache-Control header, or fallback if it has none.
+func maxAge(cacheControl string, fallback time.Duration) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+		if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return fallback
+}
+
+// randomToken returns a random unguessable string.
+func randomToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/utils/token"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// googleKeyServer serves the public part of key under kid as a JWKS, counting the requests.
+func googleKeyServer(t *testing.T, key *rsa.PrivateKey, kid string, fetches *int) *httptest.Server {
+	set := token.JSONWebKeySet{Keys: []token.JSONWebKey{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*fetches++
+		w.Header().Set("Cache-Control", "public, max-age=600, must-revalidate")
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func signGoogleIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = kid
+	signed, err := idToken.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign ID token: %v", err)
+	}
+	return signed
+}
+
+func TestGoogleKeySet_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	fetches := 0
+	keySet := newGoogleKeySet(googleKeyServer(t, key, "google-1", &fetches).URL)
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            "https://accounts.google.com",
+			"aud":            "client-id",
+			"sub":            "1234567890",
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"name":           "Jane",
+			"nonce":          "nonce",
+			"iat":            now.Unix(),
+			"exp":            now.Add(time.Hour).Unix(),
+		}
+	}
+
+	identity, err := keySet.verify(signGoogleIDToken(t, key, "google-1", claims()), "client-id", "nonce", now)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if identity.Subject != "1234567890" || identity.Email != "jane@example.com" || !identity.EmailVerified || identity.Name != "Jane" {
+		t.Errorf("Expected the identity of the token, got: %+v", identity)
+	}
+
+	// keys are cached for the max-age of the JWKS
+	keySet.verify(signGoogleIDToken(t, key, "google-1", claims()), "client-id", "nonce", now.Add(time.Minute))
+	if fetches != 1 {
+		t.Errorf("Expected the keys to be fetched once, got %d fetches", fetches)
+	}
+
+	wrongIssuer := claims()
+	wrongIssuer["iss"] = "https://evil.example.com"
+	expired := claims()
+	expired["exp"] = now.Add(-time.Hour).Unix()
+	noExpiry := claims()
+	delete(noExpiry, "exp")
+
+	tests := []struct {
+		name     string
+		idToken  string
+		clientID string
+		nonce    string
+	}{
+		{"another client", signGoogleIDToken(t, key, "google-1", claims()), "other-client-id", "nonce"},
+		{"another login", signGoogleIDToken(t, key, "google-1", claims()), "client-id", "other-nonce"},
+		{"no nonce", signGoogleIDToken(t, key, "google-1", claims()), "client-id", ""},
+		{"wrong issuer", signGoogleIDToken(t, key, "google-1", wrongIssuer), "client-id", "nonce"},
+		{"expired", signGoogleIDToken(t, key, "google-1", expired), "client-id", "nonce"},
+		{"no expiry", signGoogleIDToken(t, key, "google-1", noExpiry), "client-id", "nonce"},
+		{"signed by another key", signGoogleIDToken(t, otherKey, "google-1", claims()), "client-id", "nonce"},
+		{"unknown key", signGoogleIDToken(t, key, "google-2", claims()), "client-id", "nonce"},
+		{"unsigned", strings.Join(strings.Split(signGoogleIDToken(t, key, "google-1", claims()), ".")[:2], ".") + ".", "client-id", "nonce"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := keySet.verify(tt.idToken, tt.clientID, tt.nonce, now); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestVerifyCodeVerifier(t *testing.T) {
+	verifier := strings.Repeat("a1-._~", 8)
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	if !ValidCodeChallenge(challenge) {
+		t.Errorf("Expected %q to be a valid challenge", challenge)
+	}
+	if ValidCodeChallenge(verifier) {
+		t.Errorf("Expected a plain verifier not to be a valid challenge")
+	}
+	if !VerifyCodeVerifier(verifier, challenge) {
+		t.Errorf("Expected the verifier to match its challenge")
+	}
+	if VerifyCodeVerifier(strings.Repeat("b", 48), challenge) {
+		t.Errorf("Expected another verifier not to match")
+	}
+	if VerifyCodeVerifier("short", challenge) {
+		t.Errorf("Expected a verifier shorter than 43 characters to be rejected")
+	}
+}
+
+func TestNewOAuthState(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	oauthState, state, err := NewOAuthState("", now)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if oauthState.StateHash != HashOAuthState(state) || oauthState.StateHash == state {
+		t.Errorf("Expected only the hash of the state to be stored")
+	}
+	if oauthState.Nonce == "" || oauthState.Nonce == state || oauthState.Mobile() {
+		t.Errorf("Expected a separate nonce for a web login, got: %+v", oauthState)
+	}
+	if !oauthState.ExpiresAt.Equal(now.Add(OAuthStateLifespan)) {
+		t.Errorf("Expected the state to expire after %v, got: %v", OAuthStateLifespan, oauthState.ExpiresAt)
+	}
+}
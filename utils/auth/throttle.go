@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/spf13/viper"
+)
+
+// ThrottleKey is what failed attempts are counted by, with the policy they are throttled under.
+type ThrottleKey struct {
+	Key    string
+	Policy models.ThrottlePolicy
+}
+
+// AccountLockoutDuration returns how long an account cannot be logged in to after too many failures, AUTH_LOCKOUT_MINUTES.
+func AccountLockoutDuration() time.Duration {
+	viper.SetDefault("AUTH_LOCKOUT_MINUTES", 15)
+	return time.Duration(viper.GetInt("AUTH_LOCKOUT_MINUTES")) * time.Minute
+}
+
+// AccountThrottle counts failed logins to an account, whether it exists or not. It backs off after 3 failures,
+// and locks the account out after AUTH_LOCKOUT_FAILURES.
+func AccountThrottle(email string) ThrottleKey {
+	viper.SetDefault("AUTH_LOCKOUT_FAILURES", 10)
+	return ThrottleKey{
+		Key: "account:" + strings.ToLower(strings.TrimSpace(email)),
+		Policy: models.ThrottlePolicy{
+			FreeFailures:    3,
+			BaseDelay:       time.Second,
+			MaxDelay:        5 * time.Minute,
+			LockoutFailures: viper.GetInt("AUTH_LOCKOUT_FAILURES"),
+			LockoutDuration: AccountLockoutDuration(),
+			Window:          time.Hour,
+		},
+	}
+}
+
+// IPThrottle counts failed attempts from an IP address at any account or OTP. Many users can share an address,
+// so it only backs off, after AUTH_IP_FREE_FAILURES.
+func IPThrottle(ip string) ThrottleKey {
+	viper.SetDefault("AUTH_IP_FREE_FAILURES", 50)
+	return ThrottleKey{
+		Key: "ip:" + ip,
+		Policy: models.ThrottlePolicy{
+			FreeFailures: viper.GetInt("AUTH_IP_FREE_FAILURES"),
+			BaseDelay:    time.Second,
+			MaxDelay:     5 * time.Minute,
+			Window:       time.Hour,
+		},
+	}
+}
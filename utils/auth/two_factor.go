@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/totp"
+	"github.com/spf13/viper"
+)
+
+// ErrInvalidSecondFactor is returned when neither the two-factor code nor the recovery code is correct.
+var ErrInvalidSecondFactor = errors.New("invalid two-factor code")
+
+// recoveryCodeCount is how many recovery codes a user gets on enrolling or regenerating them.
+const recoveryCodeCount = 10
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorOptions returns the TOTP options of account second factors, those authenticator apps use by default.
+func TwoFactorOptions() totp.Options {
+	return totp.Options{Period: 30 * time.Second, Digits: 6, Skew: 1}
+}
+
+// TwoFactorIssuer returns the name accounts are listed under in authenticator apps, TWO_FACTOR_ISSUER.
+func TwoFactorIssuer() string {
+	viper.SetDefault("TWO_FACTOR_ISSUER", "GDSC Attendance App")
+	return viper.GetString("TWO_FACTOR_ISSUER")
+}
+
+// GenerateRecoveryCodes returns new recovery codes of the form xxxxx-xxxxx, shown to the user once.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		secret := make([]byte, 10)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(secret))[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash under which a recovery code is stored, ignoring case, spaces and dashes.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckSecondFactor checks the code from the authenticator app of a user with two-factor authentication enabled,
+// or a recovery code when no code is given. Either can only be used once.
+func CheckSecondFactor(twoFactorRepo repository.TwoFactorRepositoryInterface, userID uint, code, recoveryCode string, now time.Time) error {
+	if code == "" {
+		if recoveryCode == "" {
+			return ErrInvalidSecondFactor
+		}
+		if err := twoFactorRepo.UseRecoveryCode(userID, HashRecoveryCode(recoveryCode), now); err != nil {
+			return ErrInvalidSecondFactor
+		}
+		return nil
+	}
+
+	twoFactor, err := twoFactorRepo.GetTwoFactorByUserID(userID)
+	if err != nil || !twoFactor.Enabled() {
+		return ErrInvalidSecondFactor
+	}
+	opts := TwoFactorOptions()
+	if !totp.ValidateCode(code, twoFactor.Secret, now, opts) {
+		return ErrInvalidSecondFactor
+	}
+	// a code is valid for its period and the skew after it
+	reusableBefore := now.Add(-opts.Period * time.Duration(opts.Skew+1))
+	return twoFactorRepo.UseTwoFactorCode(userID, code, now, reusableBefore)
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+	"github.com/GDGVIT/attendance-app-backend/utils/totp"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes returned an error: %v", err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("Expected %d codes, got %d", recoveryCodeCount, len(codes))
+	}
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' || seen[code] {
+			t.Errorf("Unexpected recovery code %s", code)
+		}
+		seen[code] = true
+	}
+
+	if HashRecoveryCode(codes[0]) != HashRecoveryCode(strings.ToUpper(strings.Replace(codes[0], "-", " ", 1))) {
+		t.Errorf("Expected the hash to ignore case, spaces and dashes")
+	}
+}
+
+func TestCheckSecondFactor(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.TwoFactor{}, &models.RecoveryCode{})
+	database.DB = db
+	twoFactorRepo := repository.NewTwoFactorRepository()
+
+	now := time.Date(2026, 3, 1, 10, 0, 5, 0, time.UTC)
+	secret, _ := totp.GenerateSecret()
+	twoFactorRepo.SaveTwoFactorSecret(1, secret)
+	code, _ := totp.GenerateCode(secret, now, TwoFactorOptions())
+
+	// not confirmed yet
+	if err := CheckSecondFactor(twoFactorRepo, 1, code, "", now); err != ErrInvalidSecondFactor {
+		t.Errorf("Expected ErrInvalidSecondFactor before confirming, got: %v", err)
+	}
+
+	twoFactorRepo.ConfirmTwoFactor(1, []string{HashRecoveryCode("abcde-fghij")}, now)
+	if err := CheckSecondFactor(twoFactorRepo, 1, code, "", now); err != nil {
+		t.Errorf("Expected the code to be accepted, got: %v", err)
+	}
+	if err := CheckSecondFactor(twoFactorRepo, 1, code, "", now.Add(20*time.Second)); err != repository.ErrTwoFactorCodeUsed {
+		t.Errorf("Expected the code to be refused a second time, got: %v", err)
+	}
+	if err := CheckSecondFactor(twoFactorRepo, 1, "", "", now); err != ErrInvalidSecondFactor {
+		t.Errorf("Expected ErrInvalidSecondFactor without a code, got: %v", err)
+	}
+	if err := CheckSecondFactor(twoFactorRepo, 1, "", "ABCDE FGHIJ", now); err != nil {
+		t.Errorf("Expected the recovery code to be accepted, got: %v", err)
+	}
+	if err := CheckSecondFactor(twoFactorRepo, 1, "", "abcde-fghij", now); err != ErrInvalidSecondFactor {
+		t.Errorf("Expected the recovery code to be refused a second time, got: %v", err)
+	}
+}
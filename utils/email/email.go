@@ -2,31 +2,19 @@ package email
 
 import (
 	"bytes"
-	"crypto/rand"
 	"encoding/json"
 	"fmt"
-	"math"
-	"math/big"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/GDGVIT/attendance-app-backend/infra/logger"
 	"github.com/GDGVIT/attendance-app-backend/models"
 	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/otp"
 	"github.com/spf13/viper"
 )
 
-func GenerateOTP(maxDigits uint32) string {
-	bi, err := rand.Int(
-		rand.Reader,
-		big.NewInt(int64(math.Pow(10, float64(maxDigits)))),
-	)
-	if err != nil {
-		panic(err)
-	}
-	return fmt.Sprintf("%0*d", maxDigits, bi)
-}
-
 type EmailAddress struct {
 	Email string `json:"email"`
 	Name  string `json:"name"`
@@ -89,72 +77,60 @@ func GenericSendMail(subject string, content string, toEmail string, userName st
 }
 
 func SendRegistrationMail(subject string, content string, toEmail string, userID uint, userName string, newUser bool) error {
-	otp := ""
-	if newUser {
-		otp = GenerateOTP(6)
-		content += viper.GetString("FRONTEND_BASE") + "/verify?email=" + toEmail + "&otp=" + otp + " . Or, type in the OTP manually: " + otp
-	}
-
-	err := GenericSendMail(subject, content, toEmail, userName)
-	if err != nil {
-		return err
-	}
-
 	if newUser {
-		entry := models.VerificationEntry{
-			Email: toEmail,
-			OTP:   otp,
+		code, err := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeVerification, toEmail, time.Now())
+		if err != nil {
+			logger.Errorf("Email Error: %v", err)
+			return err
 		}
-		verifRepo := repository.NewVerificationEntryRepository()
-		verifRepo.CreateVerificationEntry(entry)
+		content += viper.GetString("FRONTEND_BASE") + "/verify?email=" + url.QueryEscape(toEmail) + "&otp=" + code + " . Or, type in the OTP manually: " + code
 	}
-	return nil
+
+	return GenericSendMail(subject, content, toEmail, userName)
 }
 
 func SendDeletionMail(toEmail string, userID uint, userName string) error {
-	otp := ""
-	otp = GenerateOTP(6)
-	confirmationURL := ""
-	confirmationURL += viper.GetString("FRONTEND_BASE") + "/delete-account?email=" + toEmail + "&otp=" + otp
-	content := "A request for the deletion of the nock account associated with your user has been made. If this was not you, please change your password. Otherwise, click on this link to confirm account deletion: " + confirmationURL + " . This link will be active for 3 minutes."
-	subject := "Request for account deletion."
-
-	err := GenericSendMail(subject, content, toEmail, userName)
+	code, err := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeDeletion, toEmail, time.Now())
 	if err != nil {
+		logger.Errorf("Email Error: %v", err)
 		return err
 	}
+	confirmationURL := viper.GetString("FRONTEND_BASE") + "/delete-account?email=" + url.QueryEscape(toEmail) + "&otp=" + code
+	content := "A request for the deletion of the nock account associated with your user has been made. If this was not you, please change your password. Otherwise, click on this link to confirm account deletion: " + confirmationURL + " . This link will be active for " + minutes(otp.Lifespan(models.OneTimeCodeDeletion)) + "."
+	subject := "Request for account deletion."
 
-	entry := models.DeletionConfirmation{
-		Email:     toEmail,
-		OTP:       otp,
-		ValidTill: time.Now().Add(3 * time.Minute),
-	}
-	deletionRepo := repository.NewDeletionConfirmationRepository()
-	deletionRepo.CreateDeletionConfirmation(entry)
-	return nil
+	return GenericSendMail(subject, content, toEmail, userName)
 }
 
 func SendForgotPasswordMail(toEmail string, userID uint, userName string) error {
-	otp := ""
-	otp = GenerateOTP(6)
-	verificationURL := ""
-	verificationURL += viper.GetString("FRONTEND_BASE") + "/set-forgotten-password?email=" + toEmail + "&otp=" + otp
-	content := "A forgot password request was made for the email associated with your account. If this was not you, feel free to ignore this email. Otherwise, click on this link to post your new password: " + verificationURL + " . This link will be active for 3 minutes."
+	code, err := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeForgot, toEmail, time.Now())
+	if err != nil {
+		logger.Errorf("Email Error: %v", err)
+		return err
+	}
+	verificationURL := viper.GetString("FRONTEND_BASE") + "/set-forgotten-password?email=" + url.QueryEscape(toEmail) + "&otp=" + code
+	content := "A forgot password request was made for the email associated with your account. If this was not you, feel free to ignore this email. Otherwise, click on this link to post your new password: " + verificationURL + " . This link will be active for " + minutes(otp.Lifespan(models.OneTimeCodeForgot)) + "."
 	subject := "Forgot Password."
 
-	err := GenericSendMail(subject, content, toEmail, userName)
+	return GenericSendMail(subject, content, toEmail, userName)
+}
+
+func SendMagicLinkMail(toEmail string, userName string) error {
+	code, err := otp.Issue(repository.NewOneTimeCodeRepository(), models.OneTimeCodeLogin, toEmail, time.Now())
 	if err != nil {
+		logger.Errorf("Email Error: %v", err)
 		return err
 	}
+	loginURL := viper.GetString("FRONTEND_BASE") + "/magic-login?email=" + url.QueryEscape(toEmail) + "&otp=" + code
+	content := "Click on this link to log in to your account: " + loginURL + " . Or, type in the OTP manually: " + code + " . This link will be active for " + minutes(otp.Lifespan(models.OneTimeCodeLogin)) + " and can only be used once. If this was not you, feel free to ignore this email."
+	subject := "Log In."
 
-	entry := models.ForgotPassword{
-		Email:     toEmail,
-		OTP:       otp,
-		ValidTill: time.Now().Add(3 * time.Minute),
-	}
-	forgotRepo := repository.NewForgotPasswordRepository()
-	forgotRepo.CreateForgotPassword(entry)
-	return nil
+	return GenericSendMail(subject, content, toEmail, userName)
+}
+
+// minutes formats a duration in minutes for emails.
+func minutes(d time.Duration) string {
+	return fmt.Sprintf("%d minutes", int(d.Minutes()))
 }
 
 // SendRequestNotifToTeamAdmins sends a notification email to all admins of a team
@@ -208,3 +184,48 @@ func SendMeetingNotifToTeamMembers(toEmail []string, teamName string, meetingNam
 	}
 	return nil
 }
+
+// SendTeamDeletedNotifToMembers lets the members of a team know it has been deleted
+func SendTeamDeletedNotifToMembers(toEmail []string, teamName string) error {
+	content := "The team " + teamName + " has been deleted by its Super Admin, along with its meetings and attendance."
+	subject := "Team Deleted."
+
+	for _, email := range toEmail {
+		err := GenericSendMail(subject, content, email, teamName+" Team")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendTeamArchivedNotifToMembers lets the members of a team know it has been archived
+func SendTeamArchivedNotifToMembers(toEmail []string, teamName string) error {
+	content := "The team " + teamName + " has been archived by its Super Admin. Its past meetings and your attendance are still available on the Attendance App, but no new meetings will be held."
+	subject := "Team Archived."
+
+	for _, email := range toEmail {
+		err := GenericSendMail(subject, content, email, teamName+" Team")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendTeamInviteNotifToUser lets a user know they have been invited to a team
+func SendTeamInviteNotifToUser(toEmail string, userName string, teamName string) error {
+	content := "You have been invited to join the team " + teamName + ". Please accept or decline the invitation on the Attendance App."
+	subject := "Team Invitation."
+
+	return GenericSendMail(subject, content, toEmail, userName)
+}
+
+// SendTeamInviteSignupMail invites an email without an account to sign up and join a team
+func SendTeamInviteSignupMail(toEmail string, teamName string) error {
+	signupURL := viper.GetString("FRONTEND_BASE") + "/register?email=" + url.QueryEscape(toEmail)
+	content := "You have been invited to join the team " + teamName + " on the Attendance App. Sign up with this email address using this link, and you will join the team once your account is verified: " + signupURL
+	subject := "Team Invitation."
+
+	return GenericSendMail(subject, content, toEmail, toEmail)
+}
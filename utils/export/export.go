@@ -0,0 +1,85 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Supported export formats.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Writer writes a table row by row, so that it never has to be held in memory as a whole.
+// Cells can be strings or numbers (int, uint, float64), anything else is written with fmt.
+type Writer interface {
+	WriteRow(cells ...interface{}) error
+	// Close writes whatever the format needs after the last row. The underlying io.Writer is not closed.
+	Close() error
+}
+
+// NewWriter returns a Writer for the format. sheet names the worksheet of XLSX files and is unused for CSV.
+func NewWriter(format string, w io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case CSV:
+		return NewCSVWriter(w), nil
+	case XLSX:
+		return NewXLSXWriter(w, sheet)
+	default:
+		return nil, errors.New("export format must be csv or xlsx")
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format string) string {
+	if format == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter returns a Writer of comma separated values.
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) WriteRow(cells ...interface{}) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = formatCell(cell)
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// formulaPrefixes start cells spreadsheets read as formulas.
+const formulaPrefixes = "=+-@\t\r"
+
+// formatCell formats a cell as text. Strings that would be read as a formula, such as a member named "=HYPERLINK(...)",
+// are prefixed with a quote so they are shown as written instead of being evaluated.
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case string:
+		if v != "" && strings.ContainsRune(formulaPrefixes, rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
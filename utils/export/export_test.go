@@ -0,0 +1,96 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(CSV, &buf, "")
+	if err != nil {
+		t.Fatalf("NewWriter returned an error: %v", err)
+	}
+	w.WriteRow("Name", "Weekly, sync", "Attendance %")
+	w.WriteRow("Alice", "present", 87.5)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	expected := "Name,\"Weekly, sync\",Attendance %\nAlice,present,87.5\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSVWriter_Formulas(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	w.WriteRow("=HYPERLINK(\"http://evil.example.com\")", "+1", "-1", "@SUM(A1)", "\tTab", "Alice", -1.5)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	expected := "\"'=HYPERLINK(\"\"http://evil.example.com\"\")\",'+1,'-1,'@SUM(A1),'\tTab,Alice,-1.5\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(XLSX, &buf, "Team: A/B")
+	if err != nil {
+		t.Fatalf("NewWriter returned an error: %v", err)
+	}
+	w.WriteRow("Name", "<Meeting>")
+	w.WriteRow("Alice", 3)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Output is not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Expected part %s in workbook", name)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Team- A-B"`) {
+		t.Errorf("Expected invalid characters to be replaced in sheet name, got %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">&lt;Meeting&gt;</t></is></c>`) {
+		t.Errorf("Expected escaped string cell, got %s", sheet)
+	}
+	if !strings.Contains(sheet, `<c r="B2"><v>3</v></c>`) {
+		t.Errorf("Expected number cell, got %s", sheet)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, expected := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if name := columnName(i); name != expected {
+			t.Errorf("Expected column %d to be %s, got %s", i, expected, name)
+		}
+	}
+}
+
+func TestNewWriter_InvalidFormat(t *testing.T) {
+	if _, err := NewWriter("pdf", io.Discard, ""); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,28 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		lat1, long1, lat2, long2 float64
+		expected                 float64
+		tolerance                float64
+	}{
+		{"Same point", 12.969, 79.155, 12.969, 79.155, 0, 0.001},
+		{"One degree of latitude", 0, 0, 1, 0, 111195, 1},
+		{"Chennai to Vellore", 13.0827, 80.2707, 12.9165, 79.1325, 124500, 1000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Distance(tc.lat1, tc.long1, tc.lat2, tc.long2)
+			if math.Abs(got-tc.expected) > tc.tolerance {
+				t.Errorf("Expected distance %v (±%v), got %v", tc.expected, tc.tolerance, got)
+			}
+		})
+	}
+}
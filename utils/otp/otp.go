@@ -0,0 +1,65 @@
+package otp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/spf13/viper"
+)
+
+// digits is the length of one-time codes.
+const digits = 6
+
+// lifespans are how long the codes of each purpose can be used for.
+var lifespans = map[string]time.Duration{
+	models.OneTimeCodeVerification: 24 * time.Hour,
+	models.OneTimeCodeForgot:       3 * time.Minute,
+	models.OneTimeCodeDeletion:     3 * time.Minute,
+	models.OneTimeCodeLogin:        10 * time.Minute,
+}
+
+// Lifespan returns how long a code for the purpose can be used for.
+func Lifespan(purpose string) time.Duration {
+	return lifespans[purpose]
+}
+
+// MaxAttempts returns how many wrong codes can be tried before a code cannot be used anymore, OTP_MAX_ATTEMPTS.
+func MaxAttempts() int {
+	viper.SetDefault("OTP_MAX_ATTEMPTS", 5)
+	return viper.GetInt("OTP_MAX_ATTEMPTS")
+}
+
+// generate returns a random code of the given number of digits.
+func generate(digits int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// Issue creates a code for the purpose to send to the email, replacing the one sent before, and returns it.
+func Issue(oneTimeCodeRepo repository.OneTimeCodeRepositoryInterface, purpose, email string, now time.Time) (string, error) {
+	code, err := generate(digits)
+	if err != nil {
+		return "", err
+	}
+	oneTimeCode := models.OneTimeCode{Purpose: purpose, Email: email, ExpiresAt: now.Add(Lifespan(purpose))}
+	if err := oneTimeCode.SetCode(code); err != nil {
+		return "", err
+	}
+	if _, err := oneTimeCodeRepo.CreateOneTimeCode(oneTimeCode); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Verify uses up the code sent for the purpose to the email. See OneTimeCodeRepository.ConsumeOneTimeCode for the errors.
+func Verify(oneTimeCodeRepo repository.OneTimeCodeRepositoryInterface, purpose, email, code string, now time.Time) error {
+	return oneTimeCodeRepo.ConsumeOneTimeCode(purpose, email, code, MaxAttempts(), now)
+}
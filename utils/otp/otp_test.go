@@ -0,0 +1,43 @@
+package otp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/database"
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/GDGVIT/attendance-app-backend/repository"
+	"github.com/GDGVIT/attendance-app-backend/utils/test_utils"
+)
+
+func TestIssueAndVerify(t *testing.T) {
+	db, err := test_utils.SetupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to set up the test database: %v", err)
+	}
+	defer db.Migrator().DropTable(&models.OneTimeCode{})
+	database.DB = db
+	oneTimeCodeRepo := repository.NewOneTimeCodeRepository()
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	code, err := Issue(oneTimeCodeRepo, models.OneTimeCodeForgot, "jane@example.com", now)
+	if err != nil {
+		t.Fatalf("Issue returned an error: %v", err)
+	}
+	if len(code) != digits {
+		t.Errorf("Expected a code of %d digits, got %s", digits, code)
+	}
+
+	var stored models.OneTimeCode
+	db.First(&stored)
+	if stored.CodeHash == code || !stored.ExpiresAt.Equal(now.Add(3*time.Minute)) {
+		t.Errorf("Expected a hashed code expiring in 3 minutes, got %+v", stored)
+	}
+
+	if err := Verify(oneTimeCodeRepo, models.OneTimeCodeForgot, "jane@example.com", code, now.Add(4*time.Minute)); err != repository.ErrOneTimeCodeExpired {
+		t.Errorf("Expected ErrOneTimeCodeExpired, got: %v", err)
+	}
+	if err := Verify(oneTimeCodeRepo, models.OneTimeCodeForgot, "jane@example.com", code, now.Add(time.Minute)); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package rrule
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported values of FREQ.
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+const untilLayout = "20060102T150405Z"
+
+// Rule is the subset of an RFC 5545 RRULE that meeting series support: FREQ, INTERVAL, BYDAY (weekly only), COUNT and UNTIL.
+// Occurrences keep the time of day of the first occurrence.
+type Rule struct {
+	Frequency string
+	Interval  int
+	ByDay     []time.Weekday
+	Count     int       // 0 for no limit
+	Until     time.Time // zero for no limit
+}
+
+// Parse reads a rule like "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10", optionally prefixed with "RRULE:".
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, errors.New("recurrence rule cannot be empty")
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return Rule{}, errors.New("invalid recurrence rule part " + part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = strings.ToUpper(value)
+			if rule.Frequency != Daily && rule.Frequency != Weekly && rule.Frequency != Monthly {
+				return Rule{}, errors.New("recurrence frequency must be DAILY, WEEKLY or MONTHLY")
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval <= 0 {
+				return Rule{}, errors.New("recurrence interval must be a positive number")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count <= 0 {
+				return Rule{}, errors.New("recurrence count must be a positive number")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse(untilLayout, value)
+			if err != nil {
+				// a date only UNTIL includes the whole day
+				date, dateErr := time.Parse("20060102", value)
+				if dateErr != nil {
+					return Rule{}, errors.New("recurrence until must be formatted as YYYYMMDD or YYYYMMDDTHHMMSSZ")
+				}
+				until = date.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return Rule{}, errors.New("invalid recurrence day " + day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		default:
+			return Rule{}, errors.New("unsupported recurrence rule part " + key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return Rule{}, errors.New("recurrence frequency is required")
+	}
+	if len(rule.ByDay) > 0 && rule.Frequency != Weekly {
+		return Rule{}, errors.New("recurrence days are only supported for weekly rules")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return Rule{}, errors.New("recurrence rule cannot have both count and until")
+	}
+
+	// order days from monday, the start of the week
+	sort.Slice(rule.ByDay, func(i, j int) bool {
+		return daysFromMonday(rule.ByDay[i]) < daysFromMonday(rule.ByDay[j])
+	})
+	return rule, nil
+}
+
+// String formats the rule so that Parse reads it back unchanged.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			for name, day := range weekdays {
+				if day == weekday {
+					days = append(days, name)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+func daysFromMonday(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// Between returns, in order, the occurrences of the rule starting at start that fall within [from, to].
+// Occurrences begin at start, which counts towards COUNT when it matches the rule.
+func (r Rule) Between(start, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	r.each(start, to, func(t time.Time) {
+		if !t.Before(from) {
+			occurrences = append(occurrences, t)
+		}
+	})
+	return occurrences
+}
+
+// CountBefore returns the number of occurrences of the rule starting at start that are before t.
+func (r Rule) CountBefore(start, t time.Time) int {
+	count := 0
+	r.each(start, t.Add(-time.Nanosecond), func(time.Time) {
+		count++
+	})
+	return count
+}
+
+// each calls fn for every occurrence up to to, respecting COUNT and UNTIL.
+func (r Rule) each(start, to time.Time, fn func(time.Time)) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	end := to
+	if !r.Until.IsZero() && r.Until.Before(end) {
+		end = r.Until
+	}
+
+	emitted := 0
+	// emit reports whether to keep going
+	emit := func(t time.Time) bool {
+		if t.After(end) || (r.Count > 0 && emitted >= r.Count) {
+			return false
+		}
+		emitted++
+		fn(t)
+		return true
+	}
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+
+	switch r.Frequency {
+	case Daily:
+		for k := 0; ; k++ {
+			if !emit(at(start.Year(), start.Month(), start.Day()+k*interval)) {
+				return
+			}
+		}
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// monday of the first week
+		monday := start.Day() - daysFromMonday(start.Weekday())
+		for k := 0; ; k++ {
+			for _, day := range days {
+				t := at(start.Year(), start.Month(), monday+k*7*interval+daysFromMonday(day))
+				if t.Before(start) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		}
+	case Monthly:
+		for k := 0; ; k++ {
+			t := at(start.Year(), start.Month()+time.Month(k*interval), start.Day())
+			if t.Day() != start.Day() {
+				// months without this day are skipped, e.g. the 31st
+				if t.After(end) {
+					return
+				}
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		}
+	}
+}
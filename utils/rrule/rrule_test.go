@@ -0,0 +1,120 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		rule    string
+		valid   bool
+		encoded string
+	}{
+		{"FREQ=WEEKLY;BYDAY=TH,TU;COUNT=10", true, "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10"},
+		{"RRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20240131", true, "FREQ=DAILY;INTERVAL=2;UNTIL=20240131T235959Z"},
+		{"FREQ=MONTHLY", true, "FREQ=MONTHLY"},
+		{"", false, ""},
+		{"FREQ=YEARLY", false, ""},
+		{"FREQ=DAILY;BYDAY=MO", false, ""},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20240131", false, ""},
+		{"FREQ=WEEKLY;BYDAY=XX", false, ""},
+		{"FREQ=WEEKLY;INTERVAL=0", false, ""},
+		{"BYDAY=MO", false, ""},
+	}
+
+	for _, tc := range testCases {
+		rule, err := Parse(tc.rule)
+		if tc.valid != (err == nil) {
+			t.Errorf("Parse(%q) expected valid %v, got error %v", tc.rule, tc.valid, err)
+			continue
+		}
+		if tc.valid && rule.String() != tc.encoded {
+			t.Errorf("Parse(%q).String() expected %q, got %q", tc.rule, tc.encoded, rule.String())
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	// Tuesday 2 January 2024, 18:00
+	start := time.Date(2024, time.January, 2, 18, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 18, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		rule     string
+		from     time.Time
+		to       time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "Tuesdays and Thursdays",
+			rule:     "FREQ=WEEKLY;BYDAY=TU,TH",
+			from:     start,
+			to:       day(time.January, 12),
+			expected: []time.Time{day(time.January, 2), day(time.January, 4), day(time.January, 9), day(time.January, 11)},
+		},
+		{
+			name:     "Count includes occurrences before from",
+			rule:     "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			from:     day(time.January, 3),
+			to:       day(time.February, 1),
+			expected: []time.Time{day(time.January, 4), day(time.January, 9)},
+		},
+		{
+			name:     "Every other week until",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=20240130",
+			from:     start,
+			to:       day(time.March, 1),
+			expected: []time.Time{day(time.January, 2), day(time.January, 16), day(time.January, 30)},
+		},
+		{
+			name:     "Daily",
+			rule:     "FREQ=DAILY;INTERVAL=3;COUNT=3",
+			from:     start,
+			to:       day(time.March, 1),
+			expected: []time.Time{day(time.January, 2), day(time.January, 5), day(time.January, 8)},
+		},
+		{
+			name:     "Monthly",
+			rule:     "FREQ=MONTHLY;COUNT=2",
+			from:     start,
+			to:       day(time.December, 1),
+			expected: []time.Time{day(time.January, 2), day(time.February, 2)},
+		},
+	}
+
+	for _, tc := range testCases {
+		rule, err := Parse(tc.rule)
+		if err != nil {
+			t.Fatalf("%s: Parse returned an error: %v", tc.name, err)
+		}
+		occurrences := rule.Between(start, tc.from, tc.to)
+		if len(occurrences) != len(tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, occurrences)
+			continue
+		}
+		for i := range occurrences {
+			if !occurrences[i].Equal(tc.expected[i]) {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, occurrences)
+				break
+			}
+		}
+	}
+
+	// months without the day of the first occurrence are skipped
+	rule, _ := Parse("FREQ=MONTHLY;COUNT=3")
+	endOfMonth := time.Date(2024, time.January, 31, 18, 0, 0, 0, time.UTC)
+	occurrences := rule.Between(endOfMonth, endOfMonth, time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC))
+	if len(occurrences) != 3 || occurrences[1].Month() != time.March || occurrences[2].Month() != time.May {
+		t.Errorf("Expected occurrences in January, March and May, got %v", occurrences)
+	}
+
+	// CountBefore
+	rule, _ = Parse("FREQ=WEEKLY;BYDAY=TU,TH")
+	if count := rule.CountBefore(start, day(time.January, 9)); count != 2 {
+		t.Errorf("Expected 2 occurrences before 9 January, got %d", count)
+	}
+}
@@ -0,0 +1,62 @@
+package team
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+)
+
+// maxRosterEmails is the most emails a roster can have.
+const maxRosterEmails = 10000
+
+// ParseRoster reads the emails of a roster CSV, from the column named email or else the first column, lowercased and without duplicates.
+func ParseRoster(file io.Reader) ([]string, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("roster is empty")
+	}
+
+	// first row of the file after the header, if there is one
+	column, firstRow := 0, 1
+	for i, name := range records[0] {
+		if strings.EqualFold(strings.TrimSpace(name), "email") {
+			column, firstRow = i, 2
+			records = records[1:]
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	var emails []string
+	for i, record := range records {
+		if column >= len(record) {
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(record[column]))
+		if email == "" || seen[email] {
+			continue
+		}
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			return nil, errors.New("invalid email " + strconv.Quote(email) + " on row " + strconv.Itoa(firstRow+i))
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+	if len(emails) == 0 {
+		return nil, errors.New("roster has no emails")
+	}
+	if len(emails) > maxRosterEmails {
+		return nil, errors.New("roster has too many emails")
+	}
+	return emails, nil
+}
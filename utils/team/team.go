@@ -1,7 +1,10 @@
 package team
 
 import (
+	crand "crypto/rand"
+	"encoding/base32"
 	"math/rand"
+	"strings"
 	"time"
 )
 
@@ -22,3 +25,12 @@ func GenerateInviteCode() string {
 	code := generateRandomString(3, letterBytes) + "-" + generateRandomString(4, letterBytes) + "-" + generateRandomString(3, digitBytes)
 	return code
 }
+
+// GenerateInviteLinkCode returns an unguessable code for an invite link, 16 lowercase letters and digits.
+func GenerateInviteLinkCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToLower(base32.StdEncoding.EncodeToString(b)), nil
+}
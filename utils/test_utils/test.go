@@ -13,6 +13,6 @@ func SetupTestDB() (*gorm.DB, error) {
 		return nil, err
 	}
 	// automigrate user and authprovider models
-	db.AutoMigrate(&models.User{}, &models.AuthProvider{}, &models.DeletionConfirmation{}, &models.VerificationEntry{}, &models.ForgotPassword{}, &models.PasswordAuth{}, &models.Team{}, &models.TeamMember{}, &models.TeamEntryRequest{}, &models.Meeting{}, &models.MeetingAttendance{})
+	db.AutoMigrate(&models.User{}, &models.AuthProvider{}, &models.PasswordAuth{}, &models.Team{}, &models.TeamMember{}, &models.TeamEntryRequest{}, &models.Meeting{}, &models.MeetingAttendance{}, &models.MeetingSeries{}, &models.LeaveRequest{}, &models.TeamInviteLink{}, &models.TeamInviteUse{}, &models.TeamEmailInvite{}, &models.TeamJoinRule{}, &models.TeamRosterEntry{}, &models.TeamRole{}, &models.AuditLog{}, &models.Session{}, &models.TwoFactor{}, &models.RecoveryCode{}, &models.AuthThrottle{}, &models.OneTimeCode{}, &models.OAuthState{})
 	return db, nil
 }
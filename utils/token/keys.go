@@ -0,0 +1,221 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/GDGVIT/attendance-app-backend/infra/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+// SigningKey is a key tokens are signed or verified with. Public only keys are retired signing keys,
+// kept so tokens they signed stay valid until they expire.
+type SigningKey struct {
+	ID      string // kid header of the tokens it signs, the RFC 7638 thumbprint of the public key
+	Method  jwt.SigningMethod
+	Private crypto.Signer // nil for public only keys
+	Public  crypto.PublicKey
+}
+
+// JSONWebKey is the public part of a signing key, as published in the JWKS.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // Ed25519
+	X   string `json:"x,omitempty"`   // Ed25519 public key
+}
+
+// JSONWebKeySet is served at /.well-known/jwks.json so other services can verify our tokens.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+var (
+	keysMu      sync.RWMutex
+	signingKeys []SigningKey // the first signs, all verify
+)
+
+// LoadKeys loads the signing keys from the comma separated PEM files of JWT_KEY_FILES.
+// The first file must hold an RSA or Ed25519 private key and signs new tokens. The others, private or public keys,
+// only verify, so a new key can be put first while tokens signed by the old one are still valid.
+// Without key files tokens are signed with API_SECRET using HS256, which cannot be verified by other services.
+func LoadKeys() error {
+	keys, err := loadKeyFiles(viper.GetString("JWT_KEY_FILES"))
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		logger.Warnf("JWT_KEY_FILES not set, signing tokens with API_SECRET")
+	}
+
+	keysMu.Lock()
+	signingKeys = keys
+	keysMu.Unlock()
+	return nil
+}
+
+func loadKeyFiles(files string) ([]SigningKey, error) {
+	var keys []SigningKey
+	for _, file := range strings.Split(files, ",") {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		pemBytes, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseKey(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if len(keys) == 0 && key.Private == nil {
+			return nil, fmt.Errorf("%s: the first key signs tokens and must be a private key", file)
+		}
+		for _, loaded := range keys {
+			if loaded.ID == key.ID {
+				return nil, fmt.Errorf("%s: key loaded twice", file)
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// parseKey parses a PEM encoded RSA or Ed25519 key, private or public.
+func parseKey(pemBytes []byte) (SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return SigningKey{}, errors.New("no PEM encoded key found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	var key SigningKey
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key = SigningKey{Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}
+	case *rsa.PublicKey:
+		key = SigningKey{Method: jwt.SigningMethodRS256, Public: k}
+	case ed25519.PrivateKey:
+		key = SigningKey{Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}
+	case ed25519.PublicKey:
+		key = SigningKey{Method: jwt.SigningMethodEdDSA, Public: k}
+	default:
+		return SigningKey{}, errors.New("only RSA and Ed25519 keys are supported")
+	}
+	if rsaKey, ok := key.Public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < 2048 {
+		return SigningKey{}, errors.New("RSA keys must be at least 2048 bits")
+	}
+
+	key.ID = thumbprint(key.JWK())
+	return key, nil
+}
+
+// JWK returns the public part of the key as a JSON web key.
+func (k SigningKey) JWK() JSONWebKey {
+	jwk := JSONWebKey{Use: "sig", Alg: k.Method.Alg(), Kid: k.ID}
+	switch public := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// thumbprint returns the RFC 7638 thumbprint of a key, a stable ID that changes with the key.
+func thumbprint(jwk JSONWebKey) string {
+	var members string
+	if jwk.Kty == "RSA" {
+		members = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk.E, jwk.N)
+	} else {
+		members = fmt.Sprintf(`{"crv":%q,"kty":"OKP","x":%q}`, jwk.Crv, jwk.X)
+	}
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// currentSigningKey returns the key new tokens are signed with, false when signing with API_SECRET.
+func currentSigningKey() (SigningKey, bool) {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	if len(signingKeys) == 0 {
+		return SigningKey{}, false
+	}
+	return signingKeys[0], true
+}
+
+// verificationKey returns the public key to verify a token with, checking it is signed the way its key signs.
+// Tokens must name their key once key files are loaded, so tokens signed with API_SECRET stop being accepted.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+
+	if len(signingKeys) == 0 {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(viper.GetString("API_SECRET")), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range signingKeys {
+		if key.ID != kid {
+			continue
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.Public, nil
+	}
+	return nil, errors.New("unknown signing key")
+}
+
+// JWKS returns the public keys tokens are verified with, empty when signing with API_SECRET.
+func JWKS() JSONWebKeySet {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range signingKeys {
+		set.Keys = append(set.Keys, key.JWK())
+	}
+	return set
+}
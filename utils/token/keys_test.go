@@ -0,0 +1,140 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// writeKey writes a PEM file holding the key and returns its path.
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return path
+}
+
+func TestLoadKeys_Rotation(t *testing.T) {
+	defer func() { signingKeys = nil }()
+	viper.Set("API_SECRET", "test-secret")
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaFile := writeKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	edPublic, edPrivate, _ := ed25519.GenerateKey(rand.Reader)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edPrivate)
+	edFile := writeKey(t, "PRIVATE KEY", edDER)
+	edPublicDER, _ := x509.MarshalPKIXPublicKey(edPublic)
+	edPublicFile := writeKey(t, "PUBLIC KEY", edPublicDER)
+
+	user := models.User{Model: gorm.Model{ID: 7}}
+	secretToken, _ := GenerateToken(user, 1)
+
+	viper.Set("JWT_KEY_FILES", rsaFile)
+	if err := LoadKeys(); err != nil {
+		t.Fatalf("Failed to load keys: %v", err)
+	}
+	rsaToken, err := GenerateToken(user, 2)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if _, sessionID, err := ValidateToken(requestWithToken(rsaToken)); err != nil || sessionID != 2 {
+		t.Errorf("Expected the RS256 token to be valid, got %d: %v", sessionID, err)
+	}
+	if _, _, err := ValidateToken(requestWithToken(secretToken)); err == nil {
+		t.Errorf("Expected tokens signed with API_SECRET to be refused once keys are loaded")
+	}
+
+	// the new key signs, tokens of the old key stay valid
+	viper.Set("JWT_KEY_FILES", edFile+", "+rsaFile)
+	if err := LoadKeys(); err != nil {
+		t.Fatalf("Failed to load keys: %v", err)
+	}
+	edToken, _ := GenerateToken(user, 3)
+	if _, sessionID, err := ValidateToken(requestWithToken(edToken)); err != nil || sessionID != 3 {
+		t.Errorf("Expected the EdDSA token to be valid, got %d: %v", sessionID, err)
+	}
+	if _, _, err := ValidateToken(requestWithToken(rsaToken)); err != nil {
+		t.Errorf("Expected tokens of the old key to stay valid, got: %v", err)
+	}
+
+	jwks := JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kty != "OKP" || jwks.Keys[0].Alg != "EdDSA" || jwks.Keys[1].Kty != "RSA" || jwks.Keys[1].Alg != "RS256" {
+		t.Fatalf("Expected the Ed25519 and RSA keys to be published, got %+v", jwks)
+	}
+	if header, _ := base64.RawURLEncoding.DecodeString(strings.Split(edToken, ".")[0]); !strings.Contains(string(header), jwks.Keys[0].Kid) {
+		t.Errorf("Expected the token to name its key %s", jwks.Keys[0].Kid)
+	}
+
+	// the old key is retired
+	viper.Set("JWT_KEY_FILES", edFile)
+	if err := LoadKeys(); err != nil {
+		t.Fatalf("Failed to load keys: %v", err)
+	}
+	if _, _, err := ValidateToken(requestWithToken(rsaToken)); err == nil {
+		t.Errorf("Expected tokens of a retired key to be refused")
+	}
+
+	// public keys only verify
+	viper.Set("JWT_KEY_FILES", edPublicFile)
+	if err := LoadKeys(); err == nil {
+		t.Errorf("Expected an error when the signing key is a public key")
+	}
+	viper.Set("JWT_KEY_FILES", "")
+}
+
+func TestChallengeToken_NotInJWKS(t *testing.T) {
+	defer func() { signingKeys = nil }()
+	viper.Set("API_SECRET", "test-secret")
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	viper.Set("JWT_KEY_FILES", writeKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)))
+	defer viper.Set("JWT_KEY_FILES", "")
+	if err := LoadKeys(); err != nil {
+		t.Fatalf("Failed to load keys: %v", err)
+	}
+
+	challengeToken, err := GenerateChallengeToken(models.User{Model: gorm.Model{ID: 7}})
+	if err != nil {
+		t.Fatalf("Failed to generate challenge token: %v", err)
+	}
+	if _, _, err := ValidateToken(requestWithToken(challengeToken)); err == nil {
+		t.Errorf("Expected a challenge token to be refused as an access token")
+	}
+	// services verifying with the published keys cannot verify it either
+	if _, err := jwt.Parse(challengeToken, func(*jwt.Token) (interface{}, error) { return &rsaKey.PublicKey, nil }); err == nil {
+		t.Errorf("Expected a challenge token not to verify with the published keys")
+	}
+	if userID, err := ValidateChallengeToken(challengeToken); err != nil || userID != 7 {
+		t.Errorf("Expected user 7, got %d: %v", userID, err)
+	}
+}
+
+func TestParseKey_Thumbprint(t *testing.T) {
+	// RFC 7638 section 3.1
+	n := "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	jwk := JSONWebKey{Kty: "RSA", N: n, E: "AQAB"}
+	if got := thumbprint(jwk); got != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("Unexpected thumbprint %s", got)
+	}
+
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	if _, err := parseKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(small)})); err == nil {
+		t.Errorf("Expected small RSA keys to be refused")
+	}
+	if _, err := parseKey([]byte("not a key")); err == nil {
+		t.Errorf("Expected an error for a file without a key")
+	}
+}
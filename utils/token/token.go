@@ -1,9 +1,13 @@
 package token
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -13,22 +17,112 @@ import (
 	"github.com/spf13/viper"
 )
 
-func GenerateToken(user models.User) (string, error) {
+// AccessTokenLifespan returns how long access tokens are valid for, ACCESS_TOKEN_MINUTE_LIFESPAN minutes.
+func AccessTokenLifespan() time.Duration {
+	viper.SetDefault("ACCESS_TOKEN_MINUTE_LIFESPAN", 15)
+	return time.Duration(viper.GetInt("ACCESS_TOKEN_MINUTE_LIFESPAN")) * time.Minute
+}
+
+// RefreshTokenLifespan returns how long a session lasts without being refreshed, REFRESH_TOKEN_DAY_LIFESPAN days.
+func RefreshTokenLifespan() time.Duration {
+	viper.SetDefault("REFRESH_TOKEN_DAY_LIFESPAN", 30)
+	return time.Duration(viper.GetInt("REFRESH_TOKEN_DAY_LIFESPAN")) * 24 * time.Hour
+}
+
+// Token types, set in the typ header so access tokens and challenge tokens cannot stand in for each other.
+const (
+	accessTokenType    = "at+jwt"
+	challengeTokenType = "2fa+jwt"
+)
+
+// GenerateToken issues a short-lived access token for a user, tied to one of their sessions.
+func GenerateToken(user models.User, sessionID uint) (string, error) {
+	claims := jwt.MapClaims{}
+	claims["authorized"] = true
+	claims["id"] = user.ID
+	claims["sid"] = sessionID
+	claims["exp"] = time.Now().Add(AccessTokenLifespan()).Unix()
+	return signClaims(claims)
+}
+
+// challengeTokenLifespan is how long a user has to give their second factor after their password.
+const challengeTokenLifespan = 5 * time.Minute
 
-	tokenLifespan, err := strconv.Atoi(viper.GetString("TOKEN_HOUR_LIFESPAN"))
+// challengeKey returns the key challenge tokens are signed with. It is derived from API_SECRET and never published,
+// so services verifying our access tokens against the JWKS cannot mistake a challenge token for a login.
+func challengeKey() ([]byte, error) {
+	secret := viper.GetString("API_SECRET")
+	if secret == "" {
+		return nil, errors.New("API_SECRET is required to sign challenge tokens")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("2fa-challenge"))
+	return mac.Sum(nil), nil
+}
 
+// GenerateChallengeToken issues a token proving the user passed the first step of logging in, exchanged for a session
+// with their second factor. It is signed with its own key and type, so it is refused as an access token.
+func GenerateChallengeToken(user models.User) (string, error) {
+	key, err := challengeKey()
 	if err != nil {
 		return "", err
 	}
-
 	claims := jwt.MapClaims{}
-	claims["authorized"] = true
 	claims["id"] = user.ID
-	claims["exp"] = time.Now().Add(time.Hour * time.Duration(tokenLifespan)).Unix()
+	claims["purpose"] = "2fa"
+	claims["exp"] = time.Now().Add(challengeTokenLifespan).Unix()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["typ"] = challengeTokenType
+	return token.SignedString(key)
+}
 
-	return token.SignedString([]byte(viper.GetString("API_SECRET"))) // secret to sign the JWT
+// ValidateChallengeToken returns the user ID of a challenge token.
+func ValidateChallengeToken(tokenString string) (uint, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return challengeKey()
+	})
+	if err != nil {
+		return 0, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || token.Header["typ"] != challengeTokenType || claims["purpose"] != "2fa" {
+		return 0, errors.New("invalid challenge token provided")
+	}
+	id, _ := claims["id"].(float64)
+	return uint(id), nil
+}
 
+// signClaims signs an access token with the current signing key, or API_SECRET without key files.
+func signClaims(claims jwt.MapClaims) (string, error) {
+	key, ok := currentSigningKey()
+	if !ok {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["typ"] = accessTokenType
+		return token.SignedString([]byte(viper.GetString("API_SECRET"))) // secret to sign the JWT
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["typ"] = accessTokenType
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// GenerateRefreshToken returns a new random refresh token, only its hash is stored.
+func GenerateRefreshToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashRefreshToken returns the hash under which a refresh token is stored.
+func HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
 }
 
 func getTokenFromRequest(c *gin.Context) string {
@@ -43,28 +137,27 @@ func getTokenFromRequest(c *gin.Context) string {
 
 func GetToken(c *gin.Context) (*jwt.Token, error) {
 	tokenString := getTokenFromRequest(c)
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-
-		return []byte(viper.GetString("API_SECRET")), nil
-	})
-	return token, err
+	return jwt.Parse(tokenString, verificationKey)
 }
 
-func ValidateToken(c *gin.Context) (uint, error) {
+// ValidateToken returns the user and session IDs of the access token of the request.
+func ValidateToken(c *gin.Context) (uint, uint, error) {
 	token, err := GetToken(c)
 
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
-	if ok && token.Valid {
+	if ok && token.Valid && token.Header["typ"] == accessTokenType {
 		id, _ := claims["id"].(float64) //get the id
-		return uint(id), nil
+		sid, ok := claims["sid"].(float64)
+		if !ok {
+			// issued before sessions, cannot be revoked
+			return 0, 0, errors.New("token without session provided")
+		}
+		return uint(id), uint(sid), nil
 	}
 
-	return 0, errors.New("invalid token provided")
+	return 0, 0, errors.New("invalid token provided")
 }
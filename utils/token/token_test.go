@@ -0,0 +1,87 @@
+package token
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GDGVIT/attendance-app-backend/models"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// requestWithToken returns a context of a request authorized with the access token.
+func requestWithToken(accessToken string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest("GET", "/", nil)
+	c.Request.Header.Set("Authorization", "Bearer "+accessToken)
+	return c
+}
+
+func TestValidateToken(t *testing.T) {
+	viper.Set("API_SECRET", "test-secret")
+
+	accessToken, err := GenerateToken(models.User{Model: gorm.Model{ID: 7}}, 3)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	userID, sessionID, err := ValidateToken(requestWithToken(accessToken))
+	if err != nil || userID != 7 || sessionID != 3 {
+		t.Errorf("Expected user 7 and session 3, got %d and %d: %v", userID, sessionID, err)
+	}
+
+	// tokens from before sessions cannot be revoked, so are refused
+	legacy, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"authorized": true, "id": 7}).SignedString([]byte("test-secret"))
+	if _, _, err := ValidateToken(requestWithToken(legacy)); err == nil {
+		t.Errorf("Expected a token without session to be refused")
+	}
+
+	if _, _, err := ValidateToken(requestWithToken(accessToken + "x")); err == nil {
+		t.Errorf("Expected a tampered token to be refused")
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	first, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("Failed to generate refresh token: %v", err)
+	}
+	second, _ := GenerateRefreshToken()
+	if first == second {
+		t.Errorf("Expected refresh tokens to be random")
+	}
+	if HashRefreshToken(first) != HashRefreshToken(first) || HashRefreshToken(first) == HashRefreshToken(second) {
+		t.Errorf("Expected hashes to be stable and distinct")
+	}
+}
+
+func TestChallengeToken(t *testing.T) {
+	viper.Set("API_SECRET", "test-secret")
+
+	challengeToken, err := GenerateChallengeToken(models.User{Model: gorm.Model{ID: 7}})
+	if err != nil {
+		t.Fatalf("Failed to generate challenge token: %v", err)
+	}
+	if userID, err := ValidateChallengeToken(challengeToken); err != nil || userID != 7 {
+		t.Errorf("Expected user 7, got %d: %v", userID, err)
+	}
+	if _, _, err := ValidateToken(requestWithToken(challengeToken)); err == nil {
+		t.Errorf("Expected a challenge token to be refused as an access token")
+	}
+
+	accessToken, _ := GenerateToken(models.User{Model: gorm.Model{ID: 7}}, 3)
+	if _, err := ValidateChallengeToken(accessToken); err == nil {
+		t.Errorf("Expected an access token to be refused as a challenge token")
+	}
+
+	// a token with a session but without the access token type, as challenge tokens were once signed, is refused
+	untyped, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": 7, "sid": 3, "purpose": "2fa"}).SignedString([]byte("test-secret"))
+	if _, _, err := ValidateToken(requestWithToken(untyped)); err == nil {
+		t.Errorf("Expected a token without the access token type to be refused")
+	}
+	if _, err := ValidateChallengeToken(untyped); err == nil {
+		t.Errorf("Expected a challenge token signed with the access token key to be refused")
+	}
+}
@@ -0,0 +1,113 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Options configures code generation and validation. Period and Digits must match between the two.
+type Options struct {
+	Period time.Duration // how long a single code is valid for
+	Digits int           // length of the code
+	Skew   uint          // number of earlier periods whose codes are still accepted
+}
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded secret of 20 bytes.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// counter returns the number of periods elapsed since the unix epoch at time t.
+func counter(t time.Time, period time.Duration) uint64 {
+	return uint64(t.Unix()) / uint64(period/time.Second)
+}
+
+// hotp computes the RFC 4226 code for the given counter.
+func hotp(key []byte, count uint64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, count)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digits, value%uint32(math.Pow10(digits)))
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return nil, errors.New("invalid totp secret")
+	}
+	return key, nil
+}
+
+// GenerateCode returns the code for the period containing time t.
+func GenerateCode(secret string, t time.Time, opts Options) (string, error) {
+	if opts.Period < time.Second || opts.Digits <= 0 {
+		return "", errors.New("invalid totp options")
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, counter(t, opts.Period), opts.Digits), nil
+}
+
+// ExpiresAt returns the time at which the code for the period containing t stops being current.
+func ExpiresAt(t time.Time, opts Options) time.Time {
+	seconds := int64(opts.Period / time.Second)
+	return time.Unix((t.Unix()/seconds+1)*seconds, 0)
+}
+
+// ValidateCode checks the code against the period containing time t, and opts.Skew periods before it.
+func ValidateCode(code string, secret string, t time.Time, opts Options) bool {
+	if opts.Period < time.Second || opts.Digits <= 0 || len(code) != opts.Digits {
+		return false
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return false
+	}
+
+	current := counter(t, opts.Period)
+	for i := uint64(0); i <= uint64(opts.Skew) && i <= current; i++ {
+		expected := hotp(key, current-i, opts.Digits)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// URI returns the otpauth URI of the secret, shown as a QR code for authenticator apps to scan.
+func URI(secret, issuer, account string, opts Options) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(opts.Digits))
+	query.Set("period", fmt.Sprint(int64(opts.Period/time.Second)))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
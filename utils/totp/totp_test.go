@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B test vectors, SHA1 with the secret "12345678901234567890".
+func TestGenerateCode(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	opts := Options{Period: 30 * time.Second, Digits: 8}
+
+	testCases := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := GenerateCode(secret, time.Unix(tc.unix, 0), opts)
+		if err != nil {
+			t.Fatalf("GenerateCode returned an error: %v", err)
+		}
+		if code != tc.expected {
+			t.Errorf("At %d expected code %s, got %s", tc.unix, tc.expected, code)
+		}
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret returned an error: %v", err)
+	}
+	opts := Options{Period: 30 * time.Second, Digits: 6, Skew: 1}
+	now := time.Unix(1700000015, 0)
+
+	current, _ := GenerateCode(secret, now, opts)
+	previous, _ := GenerateCode(secret, now.Add(-30*time.Second), opts)
+	stale, _ := GenerateCode(secret, now.Add(-60*time.Second), opts)
+	next, _ := GenerateCode(secret, now.Add(30*time.Second), opts)
+
+	if !ValidateCode(current, secret, now, opts) {
+		t.Errorf("Expected current code to be valid")
+	}
+	if !ValidateCode(previous, secret, now, opts) {
+		t.Errorf("Expected just-expired code to be valid")
+	}
+	if stale != current && stale != previous && ValidateCode(stale, secret, now, opts) {
+		t.Errorf("Expected code older than the skew to be invalid")
+	}
+	if next != current && next != previous && ValidateCode(next, secret, now, opts) {
+		t.Errorf("Expected code of the next period to be invalid")
+	}
+	if ValidateCode("12345", secret, now, opts) {
+		t.Errorf("Expected code of the wrong length to be invalid")
+	}
+	if ExpiresAt(now, opts) != time.Unix(1700000040, 0) {
+		t.Errorf("Expected code to expire at the end of the period, got %v", ExpiresAt(now, opts))
+	}
+}
+
+func TestURI(t *testing.T) {
+	opts := Options{Period: 30 * time.Second, Digits: 6}
+	uri := URI("JBSWY3DPEHPK3PXP", "GDSC Attendance", "jane@example.com", opts)
+	expected := "otpauth://totp/GDSC%20Attendance:jane@example.com?algorithm=SHA1&digits=6&issuer=GDSC+Attendance&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != expected {
+		t.Errorf("Expected %s, got %s", expected, uri)
+	}
+}